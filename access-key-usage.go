@@ -0,0 +1,149 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// UsagePeriod - period of the request accounting reported by AccessKeyUsage.
+type UsagePeriod string
+
+// Supported usage periods
+const (
+	UsagePeriodDay  UsagePeriod = "24h"
+	UsagePeriodWeek UsagePeriod = "7d"
+)
+
+// AccessKeyUsage - requests and traffic of a single access key.
+type AccessKeyUsage struct {
+	AccessKey     string            `json:"accessKey"`
+	ParentUser    string            `json:"parentUser,omitempty"` // Set for service accounts and STS credentials
+	Requests      uint64            `json:"requests"`
+	Errors        uint64            `json:"errors"`
+	APICalls      map[string]uint64 `json:"apiCalls,omitempty"` // Requests per API name
+	BytesReceived uint64            `json:"bytesReceived"`
+	BytesSent     uint64            `json:"bytesSent"`
+}
+
+// AccessKeyUsageReport - server side request accounting
+// grouped by access key.
+type AccessKeyUsageReport struct {
+	Period UsagePeriod      `json:"period"`
+	Since  time.Time        `json:"since"`
+	Until  time.Time        `json:"until"`
+	Keys   []AccessKeyUsage `json:"keys"`
+}
+
+// ByUser - returns the usage aggregated per user, attributing the
+// usage of service accounts and STS credentials to their parent user.
+func (r AccessKeyUsageReport) ByUser() map[string]AccessKeyUsage {
+	users := make(map[string]AccessKeyUsage)
+	for _, k := range r.Keys {
+		user := k.ParentUser
+		if user == "" {
+			user = k.AccessKey
+		}
+		u := users[user]
+		u.AccessKey = user
+		u.Requests += k.Requests
+		u.Errors += k.Errors
+		u.BytesReceived += k.BytesReceived
+		u.BytesSent += k.BytesSent
+		for api, n := range k.APICalls {
+			if u.APICalls == nil {
+				u.APICalls = make(map[string]uint64)
+			}
+			u.APICalls[api] += n
+		}
+		users[user] = u
+	}
+	return users
+}
+
+// AccessKeyUsage - returns the requests and bytes transferred
+// per access key over period.
+func (adm *AdminClient) AccessKeyUsage(ctx context.Context, period UsagePeriod) (AccessKeyUsageReport, error) {
+	switch period {
+	case UsagePeriodDay, UsagePeriodWeek:
+	default:
+		return AccessKeyUsageReport{}, ErrInvalidArgument("unsupported usage period " + string(period))
+	}
+	queryValues := url.Values{}
+	queryValues.Set("period", string(period))
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/usage/access-keys",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return AccessKeyUsageReport{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return AccessKeyUsageReport{}, httpRespToErrorResponse(resp)
+	}
+
+	var report AccessKeyUsageReport
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	return report, err
+}
+
+// AccessKeyLastUsed - when and from where an access key was last used.
+type AccessKeyLastUsed struct {
+	LastUsed time.Time `json:"lastUsed,omitempty"` // Zero if the key was never used
+	SourceIP string    `json:"sourceIP,omitempty"`
+	API      string    `json:"api,omitempty"` // Name of the last API called
+}
+
+// Unused - returns true if the key was not used since before.
+func (l AccessKeyLastUsed) Unused(before time.Time) bool {
+	return l.LastUsed.Before(before)
+}
+
+// GetAccessKeyLastUsed - returns when and from which address each
+// of the access keys was last used, keys unknown to the server
+// are omitted from the result.
+func (adm *AdminClient) GetAccessKeyLastUsed(ctx context.Context, accessKeys ...string) (map[string]AccessKeyLastUsed, error) {
+	if len(accessKeys) == 0 {
+		return nil, ErrInvalidArgument("no access keys specified")
+	}
+	queryValues := url.Values{}
+	queryValues["accessKey"] = accessKeys
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/access-key/last-used",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	lastUsed := make(map[string]AccessKeyLastUsed, len(accessKeys))
+	err = json.NewDecoder(resp.Body).Decode(&lastUsed)
+	return lastUsed, err
+}
@@ -0,0 +1,79 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAccessKeyUsageByUser(t *testing.T) {
+	report := AccessKeyUsageReport{Keys: []AccessKeyUsage{
+		{AccessKey: "alice", Requests: 10, BytesSent: 100, APICalls: map[string]uint64{"GetObject": 10}},
+		{AccessKey: "svc1", ParentUser: "alice", Requests: 5, BytesSent: 50, APICalls: map[string]uint64{"GetObject": 2, "PutObject": 3}},
+		{AccessKey: "bob", Requests: 1},
+	}}
+	users := report.ByUser()
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	alice := users["alice"]
+	if alice.Requests != 15 || alice.BytesSent != 150 || alice.APICalls["GetObject"] != 12 || alice.APICalls["PutObject"] != 3 {
+		t.Errorf("unexpected usage of alice: %+v", alice)
+	}
+}
+
+func TestGetAccessKeyLastUsed(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/access-key/last-used" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		keys := r.URL.Query()["accessKey"]
+		if !reflect.DeepEqual(keys, []string{"alice", "svc1"}) {
+			writeTestError(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]AccessKeyLastUsed{
+			"alice": {LastUsed: now, SourceIP: "10.0.0.1", API: "GetObject"},
+		})
+	})
+
+	ctx := context.Background()
+	lastUsed, err := adm.GetAccessKeyLastUsed(ctx, "alice", "svc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lastUsed) != 1 {
+		t.Fatalf("expected 1 key, got %+v", lastUsed)
+	}
+	if alice := lastUsed["alice"]; !alice.LastUsed.Equal(now) || alice.SourceIP != "10.0.0.1" || alice.Unused(now.Add(-time.Hour)) {
+		t.Errorf("unexpected last use of alice: %+v", alice)
+	}
+
+	if _, err = adm.GetAccessKeyLastUsed(ctx, "bob"); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+	if _, err = adm.GetAccessKeyLastUsed(ctx); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -37,7 +37,9 @@ import (
 	"golang.org/x/net/publicsuffix"
 )
 
-// AnonymousClient implements an anonymous http client for MinIO
+// AnonymousClient implements an anonymous http client for MinIO, it
+// only provides the unauthenticated health, readiness and version APIs
+// so probes can be built without distributing credentials.
 type AnonymousClient struct {
 	// Parsed endpoint url provided by the caller
 	endpointURL *url.URL
@@ -20,6 +20,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -59,6 +60,53 @@ func (e ErrorResponse) Error() string {
 	return e.Message
 }
 
+// Is reports whether the error response matches the target
+// sentinel error, this allows callers to branch on the kind
+// of error using errors.Is, for example:
+//
+//	if errors.Is(err, madmin.ErrNoSuchUser) {
+//	   ...
+//	}
+//
+// The complete ErrorResponse is still available through errors.As.
+func (e ErrorResponse) Is(target error) bool {
+	if sentinel, ok := adminErrCodes[e.Code]; ok {
+		return sentinel == target
+	}
+	return false
+}
+
+// Sentinel errors for well known admin API error codes.
+var (
+	ErrNoSuchUser           = errors.New("madmin: no such user")
+	ErrNoSuchGroup          = errors.New("madmin: no such group")
+	ErrNoSuchServiceAccount = errors.New("madmin: no such service account")
+	ErrPolicyNotFound       = errors.New("madmin: no such policy")
+	ErrConfigNotFound       = errors.New("madmin: config not found")
+	ErrNoSuchQuota          = errors.New("madmin: no such quota configuration")
+	ErrNoSuchTier           = errors.New("madmin: no such tier")
+	ErrHealAlreadyRunning   = errors.New("madmin: heal already running")
+	ErrHealNoSuchProcess    = errors.New("madmin: no such heal process")
+	ErrAccessDenied         = errors.New("madmin: access denied")
+)
+
+// adminErrCodes maps the error codes sent by the server
+// to their sentinel errors.
+var adminErrCodes = map[string]error{
+	"XMinioAdminNoSuchUser":               ErrNoSuchUser,
+	"XMinioAdminNoSuchGroup":              ErrNoSuchGroup,
+	"XMinioAdminServiceAccountNotFound":   ErrNoSuchServiceAccount,
+	"XMinioAdminNoSuchPolicy":             ErrPolicyNotFound,
+	"XMinioConfigNotFoundError":           ErrConfigNotFound,
+	"XMinioAdminNoSuchConfigTarget":       ErrConfigNotFound,
+	"XMinioAdminNoSuchQuotaConfiguration": ErrNoSuchQuota,
+	"XMinioAdminTierNotFound":             ErrNoSuchTier,
+	"XMinioHealAlreadyRunning":            ErrHealAlreadyRunning,
+	"XMinioHealNoSuchProcess":             ErrHealNoSuchProcess,
+	"AccessDenied":                        ErrAccessDenied,
+	// Add more admin error codes here.
+}
+
 const (
 	reportIssue = "Please report this issue at https://github.com/minio/minio/issues."
 )
@@ -119,12 +167,11 @@ func httpRespToErrorResponse(resp *http.Response) error {
 //	}
 //	...
 func ToErrorResponse(err error) ErrorResponse {
-	switch err := err.(type) {
-	case ErrorResponse:
-		return err
-	default:
-		return ErrorResponse{}
+	var errResp ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp
 	}
+	return ErrorResponse{}
 }
 
 // ErrInvalidArgument - Invalid argument response.
@@ -0,0 +1,49 @@
+//
+// MinIO Object Storage (c) 2022 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorResponseIs(t *testing.T) {
+	testCases := []struct {
+		err    error
+		target error
+		match  bool
+	}{
+		{ErrorResponse{Code: "XMinioAdminNoSuchUser"}, ErrNoSuchUser, true},
+		{ErrorResponse{Code: "XMinioAdminNoSuchUser"}, ErrPolicyNotFound, false},
+		{ErrorResponse{Code: "XMinioAdminNoSuchPolicy"}, ErrPolicyNotFound, true},
+		{ErrorResponse{Code: "XMinioHealAlreadyRunning"}, ErrHealAlreadyRunning, true},
+		{ErrorResponse{Code: "XMinioUnknownCode"}, ErrNoSuchUser, false},
+		{fmt.Errorf("wrapped: %w", ErrorResponse{Code: "XMinioAdminNoSuchGroup"}), ErrNoSuchGroup, true},
+	}
+
+	for i, testCase := range testCases {
+		if got := errors.Is(testCase.err, testCase.target); got != testCase.match {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.match, got)
+		}
+	}
+
+	wrapped := fmt.Errorf("wrapped: %w", ErrorResponse{Code: "XMinioAdminNoSuchUser", Message: "no such user"})
+	if resp := ToErrorResponse(wrapped); resp.Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected wrapped error response to be unwrapped, got %#v", resp)
+	}
+}
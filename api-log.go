@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // LogMask is a bit mask for log types.
@@ -82,21 +84,82 @@ type LogInfo struct {
 	Err        error  `json:"-"`
 }
 
+// LogLevel is the severity of a console log message
+type LogLevel string
+
+// Console log severities, in increasing order
+const (
+	LogLevelInfo    LogLevel = "INFO"
+	LogLevelWarning LogLevel = "WARNING"
+	LogLevelError   LogLevel = "ERROR"
+	LogLevelFatal   LogLevel = "FATAL"
+)
+
+var logLevelOrder = map[LogLevel]int{
+	LogLevelInfo:    1,
+	LogLevelWarning: 2,
+	LogLevelError:   3,
+	LogLevelFatal:   4,
+}
+
+// AtLeast returns whether l is at least as severe as min, unknown levels
+// are always included.
+func (l LogLevel) AtLeast(min LogLevel) bool {
+	lo, ok := logLevelOrder[l]
+	if !ok {
+		return true
+	}
+	return lo >= logLevelOrder[min]
+}
+
+// LogOpts - options of GetLogsWithOpts
+type LogOpts struct {
+	Nodes    []string // Only logs of these nodes, all nodes if empty
+	Kind     LogKind  // Only logs of this kind, all kinds if empty
+	Severity LogLevel // Only logs at least as severe, all levels if empty
+	Backlog  int      // Number of past log lines sent from the server's ring buffer before new lines
+}
+
 // GetLogs - listen on console log messages.
 func (adm AdminClient) GetLogs(ctx context.Context, node string, lineCnt int, logKind string) <-chan LogInfo {
+	var nodes []string
+	if node != "" {
+		nodes = []string{node}
+	}
+	return adm.GetLogsWithOpts(ctx, LogOpts{
+		Nodes:   nodes,
+		Kind:    LogKind(logKind),
+		Backlog: lineCnt,
+	})
+}
+
+// GetLogsWithOpts - listen on console log messages of the selected nodes
+// and severity, starting with the last opts.Backlog lines logged. When the
+// connection is lost it is resumed after the last received log line, so
+// the backlog is neither sent again nor delivered twice. Lines logged at
+// the same time as the last received line are delivered once as well.
+func (adm AdminClient) GetLogsWithOpts(ctx context.Context, opts LogOpts) <-chan LogInfo {
 	logCh := make(chan LogInfo, 1)
 
 	// Only success, start a routine to start reading line by line.
 	go func(logCh chan<- LogInfo) {
 		defer close(logCh)
 		urlValues := make(url.Values)
-		urlValues.Set("node", node)
-		urlValues.Set("limit", strconv.Itoa(lineCnt))
-		urlValues.Set("logType", logKind)
+		urlValues.Set("node", strings.Join(opts.Nodes, ","))
+		urlValues.Set("limit", strconv.Itoa(opts.Backlog))
+		urlValues.Set("logType", string(opts.Kind))
+		if opts.Severity != "" {
+			urlValues.Set("severity", string(opts.Severity))
+		}
+		var (
+			last, resumeAfter time.Time
+			lastCount, skip   int // lines received at last, left to skip at resumeAfter
+		)
 		for {
 			reqData := requestData{
 				relPath:     adminAPIPrefix + "/log",
 				queryValues: urlValues,
+				category:    RequestCategoryStreaming,
 			}
 			// Execute GET to call log handler
 			resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
@@ -106,7 +169,11 @@ func (adm AdminClient) GetLogs(ctx context.Context, node string, lineCnt int, lo
 			}
 
 			if resp.StatusCode != http.StatusOK {
-				logCh <- LogInfo{Err: httpRespToErrorResponse(resp)}
+				select {
+				case <-ctx.Done():
+				case logCh <- LogInfo{Err: httpRespToErrorResponse(resp)}:
+				}
+				closeResponse(resp)
 				return
 			}
 			dec := json.NewDecoder(resp.Body)
@@ -115,13 +182,45 @@ func (adm AdminClient) GetLogs(ctx context.Context, node string, lineCnt int, lo
 				if err = dec.Decode(&info); err != nil {
 					break
 				}
+				if t, terr := time.Parse(time.RFC3339Nano, info.Time); terr == nil {
+					// Skip lines received before the reconnect, the server
+					// sends all lines logged at resumeAfter again.
+					if !resumeAfter.IsZero() {
+						if t.Before(resumeAfter) {
+							continue
+						}
+						if t.Equal(resumeAfter) && skip > 0 {
+							skip--
+							continue
+						}
+					}
+					switch {
+					case t.After(last):
+						last, lastCount = t, 1
+					case t.Equal(last):
+						lastCount++
+					}
+				}
+				// Servers without severity support send all levels.
+				if opts.Severity != "" && !LogLevel(info.Level).AtLeast(opts.Severity) {
+					continue
+				}
 				select {
 				case <-ctx.Done():
+					closeResponse(resp)
 					return
 				case logCh <- info:
 				}
 			}
-
+			closeResponse(resp)
+
+			// Resume after the last received line instead of
+			// requesting the backlog again.
+			urlValues.Del("limit")
+			if !last.IsZero() {
+				resumeAfter, skip = last, lastCount
+				urlValues.Set("since", last.Format(time.RFC3339Nano))
+			}
 		}
 	}(logCh)
 
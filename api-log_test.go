@@ -0,0 +1,136 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetLogsResume(t *testing.T) {
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	line := func(i int) LogInfo {
+		var info LogInfo
+		info.Time = t0.Add(time.Duration(i) * time.Second).Format(time.RFC3339Nano)
+		info.ConsoleMsg = time.Duration(i).String()
+		return info
+	}
+
+	var conns int
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		conns++
+		q := r.URL.Query()
+		enc := json.NewEncoder(w)
+		switch conns {
+		case 1:
+			if q.Get("limit") != "5" || q.Get("since") != "" {
+				writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			enc.Encode(line(1))
+			enc.Encode(line(2))
+		case 2:
+			if q.Has("limit") || q.Get("since") != line(2).Time {
+				writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			enc.Encode(line(2))
+			enc.Encode(line(3))
+		default:
+			<-r.Context().Done()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []string
+	for info := range adm.GetLogsWithOpts(ctx, LogOpts{Backlog: 5}) {
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+		got = append(got, info.ConsoleMsg)
+		if len(got) == 3 {
+			cancel()
+		}
+	}
+	if len(got) != 3 || got[0] != line(1).ConsoleMsg || got[2] != line(3).ConsoleMsg {
+		t.Fatalf("expected lines 1 to 3 once, got %v", got)
+	}
+}
+
+func TestGetLogsResumeSameTime(t *testing.T) {
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	line := func(msg string, sec int) LogInfo {
+		var info LogInfo
+		info.Time = t0.Add(time.Duration(sec) * time.Second).Format(time.RFC3339Nano)
+		info.ConsoleMsg = msg
+		return info
+	}
+
+	var conns int
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		conns++
+		since := r.URL.Query().Get("since")
+		enc := json.NewEncoder(w)
+		switch conns {
+		case 1:
+			enc.Encode(line("a", 1))
+			enc.Encode(line("b", 2))
+			enc.Encode(line("c", 2))
+		case 2:
+			if since != line("", 2).Time {
+				writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			// "d" was logged at the same time as "c" but
+			// not received before the connection was lost.
+			enc.Encode(line("b", 2))
+			enc.Encode(line("c", 2))
+			enc.Encode(line("d", 2))
+		case 3:
+			if since != line("", 2).Time {
+				writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			enc.Encode(line("b", 2))
+			enc.Encode(line("c", 2))
+			enc.Encode(line("d", 2))
+			enc.Encode(line("e", 3))
+		default:
+			<-r.Context().Done()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got string
+	for info := range adm.GetLogsWithOpts(ctx, LogOpts{}) {
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+		got += info.ConsoleMsg
+		if len(got) == 5 {
+			cancel()
+		}
+	}
+	if got != "abcde" {
+		t.Fatalf("expected abcde, got %s", got)
+	}
+}
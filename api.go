@@ -65,11 +65,29 @@ type AdminClient struct {
 	// Needs allocation.
 	httpClient *http.Client
 
+	// Transport of httpClient before wrapping, its dial, proxy
+	// and TLS settings are also used by websocket connections.
+	transport http.RoundTripper
+
 	random *rand.Rand
 
+	// Retry policy applied to all requests.
+	retryPolicy RetryPolicy
+
 	// Advanced functionality.
 	isTraceEnabled bool
 	traceOutput    io.Writer
+
+	// Request tracing hooks.
+	tracer           RequestTracer
+	transportWrapper func(http.RoundTripper) http.RoundTripper
+
+	// Timeouts of calls without a context deadline.
+	defaultTimeout   time.Duration
+	categoryTimeouts map[RequestCategory]time.Duration
+
+	// Generate idempotency keys of plain mutating calls.
+	autoIdempotencyKeys bool
 }
 
 // Global constants.
@@ -91,8 +109,41 @@ const (
 
 // Options for New method
 type Options struct {
-	Creds  *credentials.Credentials
-	Secure bool
+	Creds *credentials.Credentials
+	// CredsProvider is used when Creds is not set, e.g. a
+	// credentials.Chain of STS AssumeRole or web identity providers.
+	// Credentials are retrieved again once they expire.
+	CredsProvider credentials.Provider
+	Secure        bool
+	// RetryPolicy overrides the default retry behavior,
+	// see DefaultRetryPolicy for the defaults.
+	RetryPolicy *RetryPolicy
+	// Tracer is called for every admin API call, see RequestTracer.
+	Tracer RequestTracer
+	// TransportWrapper wraps the HTTP transport of the client, e.g. to
+	// instrument it or to propagate trace context headers. It is also
+	// applied to transports set with SetCustomTransport.
+	TransportWrapper func(http.RoundTripper) http.RoundTripper
+	// DefaultTimeout is applied to metadata calls whose context has no
+	// deadline, zero disables it.
+	DefaultTimeout time.Duration
+	// CategoryTimeouts overrides the timeout of calls per category whose
+	// context has no deadline, streaming and transfer calls have no
+	// timeout unless set here.
+	CategoryTimeouts map[RequestCategory]time.Duration
+	// TransportOpts tunes the default HTTP transport, when unset
+	// DefaultTransport is used.
+	TransportOpts *TransportOptions
+	// ClientCertFile and ClientKeyFile are the PEM encoded client
+	// certificate and key presented on TLS connections, they are
+	// reloaded when the files change. Transports set with
+	// SetCustomTransport must configure client certificates themselves.
+	ClientCertFile string
+	ClientKeyFile  string
+	// AutoIdempotencyKeys makes AddUser, SetPolicy and StartBatchJob
+	// send a generated idempotency key, reused by all retries of a
+	// call, so a retried call is applied only once by the server.
+	AutoIdempotencyKeys bool
 	// Add future fields here
 }
 
@@ -100,7 +151,7 @@ type Options struct {
 func New(endpoint string, accessKeyID, secretAccessKey string, secure bool) (*AdminClient, error) {
 	creds := credentials.NewStaticV4(accessKeyID, secretAccessKey, "")
 
-	clnt, err := privateNew(endpoint, creds, secure)
+	clnt, err := privateNew(endpoint, &Options{Creds: creds, Secure: secure})
 	if err != nil {
 		return nil, err
 	}
@@ -109,14 +160,14 @@ func New(endpoint string, accessKeyID, secretAccessKey string, secure bool) (*Ad
 
 // NewWithOptions - instantiate minio admin client with options.
 func NewWithOptions(endpoint string, opts *Options) (*AdminClient, error) {
-	clnt, err := privateNew(endpoint, opts.Creds, opts.Secure)
+	clnt, err := privateNew(endpoint, opts)
 	if err != nil {
 		return nil, err
 	}
 	return clnt, nil
 }
 
-func privateNew(endpoint string, creds *credentials.Credentials, secure bool) (*AdminClient, error) {
+func privateNew(endpoint string, opts *Options) (*AdminClient, error) {
 	// Initialize cookies to preserve server sent cookies if any and replay
 	// them upon each request.
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
@@ -125,7 +176,7 @@ func privateNew(endpoint string, creds *credentials.Credentials, secure bool) (*
 	}
 
 	// construct endpoint.
-	endpointURL, err := getEndpointURL(endpoint, secure)
+	endpointURL, err := getEndpointURL(endpoint, opts.Secure)
 	if err != nil {
 		return nil, err
 	}
@@ -133,18 +184,50 @@ func privateNew(endpoint string, creds *credentials.Credentials, secure bool) (*
 	clnt := new(AdminClient)
 
 	// Save the credentials.
-	clnt.credsProvider = creds
+	clnt.credsProvider = opts.Creds
+	if clnt.credsProvider == nil && opts.CredsProvider != nil {
+		clnt.credsProvider = credentials.New(opts.CredsProvider)
+	}
 
 	// Remember whether we are using https or not
-	clnt.secure = secure
+	clnt.secure = opts.Secure
 
 	// Save endpoint URL, user agent for future uses.
 	clnt.endpointURL = endpointURL
 
+	// Save the request tracing hooks.
+	clnt.tracer = opts.Tracer
+	clnt.transportWrapper = opts.TransportWrapper
+
+	// Save the timeouts of calls without a context deadline.
+	clnt.defaultTimeout = opts.DefaultTimeout
+	clnt.categoryTimeouts = opts.CategoryTimeouts
+
+	clnt.autoIdempotencyKeys = opts.AutoIdempotencyKeys
+
 	// Instantiate http client and bucket location cache.
+	transport := DefaultTransport(opts.Secure)
+	if opts.TransportOpts != nil {
+		transport = NewTransport(opts.Secure, *opts.TransportOpts)
+	}
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		if !opts.Secure {
+			return nil, ErrInvalidArgument("client certificates require a secure connection")
+		}
+		if err = setClientCertificate(transport, opts.ClientCertFile, opts.ClientKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	clnt.transport = transport
 	clnt.httpClient = &http.Client{
 		Jar:       jar,
-		Transport: DefaultTransport(secure),
+		Transport: clnt.wrapTransport(transport),
+	}
+
+	// Save the retry policy, filling in defaults for unset fields.
+	clnt.retryPolicy = DefaultRetryPolicy()
+	if opts.RetryPolicy != nil {
+		clnt.retryPolicy = opts.RetryPolicy.withDefaults()
 	}
 
 	// Add locked pseudo-random number generator.
@@ -181,7 +264,8 @@ func (adm *AdminClient) SetCustomTransport(customHTTPTransport http.RoundTripper
 	//   api.SetTransport(tr)
 	//
 	if adm.httpClient != nil {
-		adm.httpClient.Transport = customHTTPTransport
+		adm.transport = customHTTPTransport
+		adm.httpClient.Transport = adm.wrapTransport(customHTTPTransport)
 	}
 }
 
@@ -211,8 +295,31 @@ type requestData struct {
 	queryValues   url.Values
 	relPath       string // URL path relative to admin API base endpoint
 	content       []byte
+	// contentSHA256 is the precomputed hex encoded SHA-256 of
+	// content, for content sent repeatedly.
+	contentSHA256 string
+	// encrypt makes content be encrypted with the secret key on
+	// every attempt, so a retry after refreshing credentials
+	// uses the refreshed secret key.
+	encrypt bool
+	// contentStream is uploaded with a streaming signature instead
+	// of content, contentLength bytes are read from contentOffset.
+	contentStream io.ReadSeeker
+	contentOffset int64
+	contentLength int64
 	// endpointOverride overrides target URL with anonymousClient
 	endpointOverride *url.URL
+	// category selects the default timeout of the request
+	category RequestCategory
+	// minTimeout is the time the request is expected to run on
+	// the server, the request timeout is never shorter.
+	minTimeout time.Duration
+	// noRetry sends the request only once, for calls that
+	// must not be repeated.
+	noRetry bool
+	// idempotencyKey is sent with every attempt of the request
+	// so the server applies a mutating request only once.
+	idempotencyKey string
 }
 
 // Filter out signature value from Authorization header.
@@ -361,7 +468,36 @@ func (adm AdminClient) ExecuteMethod(ctx context.Context, method string, reqData
 // request upon any error up to maxRetries attempts in a binomially
 // delayed manner using a standard back off algorithm.
 func (adm AdminClient) executeMethod(ctx context.Context, method string, reqData requestData) (res *http.Response, err error) {
-	reqRetry := MaxRetry // Indicates how many times we can retry the request
+	policy := adm.getRetryPolicy()
+	if reqData.noRetry {
+		policy.MaxRetry = 1
+	}
+
+	var (
+		attempts       int
+		credsRefreshed bool
+	)
+	start := time.Now()
+	ctx, endTrace := adm.startRequest(ctx, method, reqData)
+
+	// The timeout context is canceled once the response body is closed.
+	ctx, cancelTimeout := adm.withRequestTimeout(ctx, reqData.category, reqData.minTimeout)
+	defer func() {
+		if err != nil || res == nil {
+			cancelTimeout()
+			return
+		}
+		res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancelTimeout}
+	}()
+
+	defer func() {
+		result := RequestResult{Attempts: attempts, Duration: time.Since(start), Err: err}
+		if res != nil {
+			result.StatusCode = res.StatusCode
+		}
+		endTrace(result)
+	}()
+
 	defer func() {
 		if err != nil {
 			// close idle connections before returning, upon error.
@@ -375,7 +511,9 @@ func (adm AdminClient) executeMethod(ctx context.Context, method string, reqData
 	// Indicate to our routine to exit cleanly upon return.
 	defer cancel()
 
-	for range adm.newRetryTimer(retryCtx, reqRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter) {
+	for range adm.newRetryTimer(retryCtx, policy.MaxRetry, policy.Unit, policy.Cap, policy.Jitter) {
+		attempts++
+
 		// Instantiate a new request.
 		var req *http.Request
 		req, err = adm.newRequest(ctx, method, reqData)
@@ -428,8 +566,16 @@ func (adm AdminClient) executeMethod(ctx context.Context, method string, reqData
 			continue // Retry.
 		}
 
+		// Refresh temporary credentials that expired before
+		// the provider noticed, once per call.
+		if errResponse.Code == "ExpiredToken" && !credsRefreshed && adm.credsProvider != nil {
+			credsRefreshed = true
+			adm.credsProvider.Expire()
+			continue // Retry.
+		}
+
 		// Verify if http status code is retryable.
-		if isHTTPStatusRetryable(res.StatusCode) {
+		if policy.isHTTPStatusRetryable(res.StatusCode) {
 			continue // Retry.
 		}
 
@@ -513,12 +659,35 @@ func (adm AdminClient) newRequest(ctx context.Context, method string, reqData re
 	for k, v := range reqData.customHeaders {
 		req.Header.Set(k, v[0])
 	}
-	if length := len(reqData.content); length > 0 {
+	if reqData.idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, reqData.idempotencyKey)
+	}
+	if reqData.contentStream != nil {
+		// Rewind the payload, the request may be a retry.
+		if _, err = reqData.contentStream.Seek(reqData.contentOffset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(io.LimitReader(reqData.contentStream, reqData.contentLength))
+		req = signer.StreamingSignV4(req, accessKeyID, secretAccessKey, sessionToken, location,
+			reqData.contentLength, time.Now().UTC())
+		return req, nil
+	}
+	content := reqData.content
+	if reqData.encrypt {
+		if content, err = EncryptData(secretAccessKey, content); err != nil {
+			return nil, err
+		}
+	}
+	if length := len(content); length > 0 {
 		req.ContentLength = int64(length)
 	}
-	sum := sha256.Sum256(reqData.content)
-	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
-	req.Body = ioutil.NopCloser(bytes.NewReader(reqData.content))
+	contentSHA256 := reqData.contentSHA256
+	if contentSHA256 == "" || reqData.encrypt {
+		sum := sha256.Sum256(content)
+		contentSHA256 = hex.EncodeToString(sum[:])
+	}
+	req.Header.Set("X-Amz-Content-Sha256", contentSHA256)
+	req.Body = ioutil.NopCloser(bytes.NewReader(content))
 
 	req = signer.SignV4(*req, accessKeyID, secretAccessKey, sessionToken, location)
 	return req, nil
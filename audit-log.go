@@ -0,0 +1,163 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AuditAPIDetails - details of the API call of an audit entry
+type AuditAPIDetails struct {
+	Name            string `json:"name,omitempty"`
+	Bucket          string `json:"bucket,omitempty"`
+	Object          string `json:"object,omitempty"`
+	Status          string `json:"status,omitempty"`
+	StatusCode      int    `json:"statusCode,omitempty"`
+	InputBytes      int64  `json:"rx"`
+	OutputBytes     int64  `json:"tx"`
+	TimeToFirstByte string `json:"timeToFirstByte,omitempty"`
+	TimeToResponse  string `json:"timeToResponse,omitempty"`
+}
+
+// AuditEntry - audit event of an API call
+type AuditEntry struct {
+	Version      string                 `json:"version"`
+	DeploymentID string                 `json:"deploymentid,omitempty"`
+	Time         time.Time              `json:"time"`
+	Event        string                 `json:"event,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Trigger      string                 `json:"trigger"`
+	API          AuditAPIDetails        `json:"api"`
+	RemoteHost   string                 `json:"remotehost,omitempty"`
+	RequestID    string                 `json:"requestID,omitempty"`
+	UserAgent    string                 `json:"userAgent,omitempty"`
+	AccessKey    string                 `json:"accessKey,omitempty"`
+	ParentUser   string                 `json:"parentUser,omitempty"`
+	ReqClaims    map[string]interface{} `json:"requestClaims,omitempty"`
+	ReqQuery     map[string]string      `json:"requestQuery,omitempty"`
+	ReqHeader    map[string]string      `json:"requestHeader,omitempty"`
+	RespHeader   map[string]string      `json:"responseHeader,omitempty"`
+	Tags         map[string]interface{} `json:"tags,omitempty"`
+}
+
+// AuditLogOpts - filters of AuditLogStream, an entry is sent
+// only if it matches all of the set filters
+type AuditLogOpts struct {
+	Bucket   string   // Only API calls on this bucket
+	APINames []string // Only these API calls, e.g. "PutObject"
+	User     string   // Only API calls by this access key or its parent user
+}
+
+func (o AuditLogOpts) match(e AuditEntry) bool {
+	if o.Bucket != "" && e.API.Bucket != o.Bucket {
+		return false
+	}
+	if len(o.APINames) > 0 {
+		found := false
+		for _, name := range o.APINames {
+			if strings.EqualFold(name, e.API.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if o.User != "" && e.AccessKey != o.User && e.ParentUser != o.User {
+		return false
+	}
+	return true
+}
+
+// AuditLogResult - contains an audit entry or an error
+type AuditLogResult struct {
+	Entry AuditEntry
+	Err   error
+}
+
+// AuditLogStream - subscribes to the audit events of the cluster matching opts
+func (adm *AdminClient) AuditLogStream(ctx context.Context, opts AuditLogOpts) <-chan AuditLogResult {
+	auditCh := make(chan AuditLogResult)
+
+	go func(auditCh chan<- AuditLogResult) {
+		defer close(auditCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case auditCh <- AuditLogResult{Err: err}:
+			}
+		}
+
+		urlValues := make(url.Values)
+		if opts.Bucket != "" {
+			urlValues.Set("bucket", opts.Bucket)
+		}
+		for _, name := range opts.APINames {
+			urlValues.Add("api", name)
+		}
+		if opts.User != "" {
+			urlValues.Set("user", opts.User)
+		}
+
+		resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+			relPath:     adminAPIPrefix + "/audit-log",
+			queryValues: urlValues,
+			category:    RequestCategoryStreaming,
+		})
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var entry AuditEntry
+			if err = dec.Decode(&entry); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			// The server filters as well, this protects
+			// against servers ignoring some of the filters.
+			if !opts.match(entry) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case auditCh <- AuditLogResult{Entry: entry}:
+			}
+		}
+	}(auditCh)
+
+	return auditCh
+}
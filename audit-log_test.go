@@ -0,0 +1,68 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAuditLogStream(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/audit-log" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("bucket") != "photos" {
+			writeTestError(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		if !reflect.DeepEqual(q["api"], []string{"PutObject", "DeleteObject"}) || q.Get("user") != "alice" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(AuditEntry{API: AuditAPIDetails{Name: "PutObject", Bucket: "photos", Object: "a.jpg"}, AccessKey: "alice"})
+		// Ignored filters are applied by the client.
+		enc.Encode(AuditEntry{API: AuditAPIDetails{Name: "GetObject", Bucket: "photos"}, AccessKey: "alice"})
+		enc.Encode(AuditEntry{API: AuditAPIDetails{Name: "DeleteObject", Bucket: "photos", Object: "b.jpg"}, AccessKey: "svc1", ParentUser: "alice"})
+	})
+
+	opts := AuditLogOpts{Bucket: "photos", APINames: []string{"PutObject", "DeleteObject"}, User: "alice"}
+	var entries []AuditEntry
+	for res := range adm.AuditLogStream(context.Background(), opts) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		entries = append(entries, res.Entry)
+	}
+	if len(entries) != 2 || entries[0].API.Object != "a.jpg" || entries[1].API.Object != "b.jpg" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	var results []AuditLogResult
+	for res := range adm.AuditLogStream(context.Background(), AuditLogOpts{Bucket: "videos"}) {
+		results = append(results, res)
+	}
+	if len(results) != 1 || ToErrorResponse(results[0].Err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %+v", results)
+	}
+}
@@ -53,6 +53,7 @@ func (adm *AdminClient) GetBucketBandwidth(ctx context.Context, buckets ...strin
 	reqData := requestData{
 		relPath:     adminAPIPrefix + "/bandwidth",
 		queryValues: queryValues,
+		category:    RequestCategoryStreaming,
 	}
 	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
 	if err != nil {
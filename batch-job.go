@@ -0,0 +1,316 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BatchJobType type to describe batch job types
+type BatchJobType string
+
+// Supported batch job types
+const (
+	BatchJobReplicate BatchJobType = "replicate"
+	BatchJobKeyRotate BatchJobType = "keyrotate"
+	BatchJobExpire    BatchJobType = "expire"
+)
+
+// SupportedJobTypes supported job types
+var SupportedJobTypes = []BatchJobType{
+	BatchJobReplicate,
+	BatchJobKeyRotate,
+	BatchJobExpire,
+}
+
+// BatchJobStatus - status of a batch job
+type BatchJobStatus string
+
+// Batch job status values
+const (
+	BatchJobRunning   BatchJobStatus = "running"
+	BatchJobCompleted BatchJobStatus = "completed"
+	BatchJobFailed    BatchJobStatus = "failed"
+	BatchJobCanceled  BatchJobStatus = "canceled"
+)
+
+// BatchJobResult returned by StartBatchJob
+type BatchJobResult struct {
+	ID      string        `json:"id"`
+	Type    BatchJobType  `json:"type"`
+	User    string        `json:"user,omitempty"`
+	Started time.Time     `json:"started"`
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+
+	// Replayed is true if the job was started by an earlier
+	// request with the same idempotency key.
+	Replayed bool `json:"-"`
+}
+
+// StartBatchJob start a new batch job, input job description is in YAML.
+func (adm *AdminClient) StartBatchJob(ctx context.Context, job string) (BatchJobResult, error) {
+	return adm.startBatchJob(ctx, job, adm.autoIdempotencyKey())
+}
+
+// StartBatchJobWithIdempotencyKey starts a new batch job only once for a
+// given idempotency key, so the call can be retried after a network error
+// without starting duplicate jobs. The result of a retried call describes
+// the job started by the first one.
+func (adm *AdminClient) StartBatchJobWithIdempotencyKey(ctx context.Context, idempotencyKey, job string) (BatchJobResult, error) {
+	if idempotencyKey == "" {
+		return BatchJobResult{}, ErrInvalidArgument("idempotency key cannot be empty")
+	}
+	return adm.startBatchJob(ctx, job, idempotencyKey)
+}
+
+func (adm *AdminClient) startBatchJob(ctx context.Context, job, idempotencyKey string) (BatchJobResult, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodPost,
+		requestData{
+			relPath:        adminAPIPrefix + "/start-job",
+			content:        []byte(job),
+			idempotencyKey: idempotencyKey,
+		},
+	)
+	if err != nil {
+		return BatchJobResult{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return BatchJobResult{}, httpRespToErrorResponse(resp)
+	}
+
+	res := BatchJobResult{}
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return res, err
+	}
+	res.Replayed = isIdempotencyReplay(resp)
+	return res, nil
+}
+
+// DescribeBatchJob - describes a currently running Job, returns the
+// job definition in YAML.
+func (adm *AdminClient) DescribeBatchJob(ctx context.Context, jobID string) (string, error) {
+	if jobID == "" {
+		return "", ErrInvalidArgument("job ID cannot be empty")
+	}
+	values := make(url.Values)
+	values.Set("jobId", jobID)
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/describe-job",
+			queryValues: values,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// CancelBatchJob cancels ongoing batch job.
+func (adm *AdminClient) CancelBatchJob(ctx context.Context, jobID string) error {
+	if jobID == "" {
+		return ErrInvalidArgument("job ID cannot be empty")
+	}
+	values := make(url.Values)
+	values.Set("id", jobID)
+
+	resp, err := adm.executeMethod(ctx, http.MethodDelete,
+		requestData{
+			relPath:     adminAPIPrefix + "/cancel-job",
+			queryValues: values,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusNoContent {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// ListBatchJobsFilter returns list based on following
+// filtering params.
+type ListBatchJobsFilter struct {
+	ByJobType BatchJobType
+	ByStatus  BatchJobStatus
+}
+
+// BatchJobInfo - describes a batch job
+type BatchJobInfo struct {
+	ID        string         `json:"id"`
+	Type      BatchJobType   `json:"type"`
+	User      string         `json:"user,omitempty"`
+	Status    BatchJobStatus `json:"status,omitempty"`
+	Started   time.Time      `json:"started"`
+	Completed time.Time      `json:"completed,omitempty"`
+}
+
+// ListBatchJobsResult contains entries for all current jobs.
+type ListBatchJobsResult struct {
+	Jobs []BatchJobInfo `json:"jobs"`
+}
+
+// ListBatchJobs list all the currently active batch jobs
+func (adm *AdminClient) ListBatchJobs(ctx context.Context, fl *ListBatchJobsFilter) (ListBatchJobsResult, error) {
+	if fl == nil {
+		return ListBatchJobsResult{}, ErrInvalidArgument("filter cannot be nil")
+	}
+
+	values := make(url.Values)
+	if fl.ByJobType != "" {
+		values.Set("jobType", string(fl.ByJobType))
+	}
+	if fl.ByStatus != "" {
+		values.Set("status", string(fl.ByStatus))
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/list-jobs",
+			queryValues: values,
+		},
+	)
+	if err != nil {
+		return ListBatchJobsResult{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return ListBatchJobsResult{}, httpRespToErrorResponse(resp)
+	}
+
+	d := json.NewDecoder(resp.Body)
+	result := ListBatchJobsResult{}
+	if err = d.Decode(&result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// BatchJobProgressEvent - progress of a batch job at a point in time
+type BatchJobProgressEvent struct {
+	JobID    string         `json:"jobId"`
+	JobType  BatchJobType   `json:"jobType"`
+	Status   BatchJobStatus `json:"status"`
+	Time     time.Time      `json:"time"`
+	Bucket   string         `json:"bucket,omitempty"`
+	Object   string         `json:"object,omitempty"`
+	Attempts int            `json:"attempts,omitempty"`
+
+	ObjectsScanned   uint64 `json:"objectsScanned"`
+	ObjectsSucceeded uint64 `json:"objectsSucceeded"`
+	ObjectsFailed    uint64 `json:"objectsFailed"`
+	BytesTransferred uint64 `json:"bytesTransferred,omitempty"`
+	BytesFailed      uint64 `json:"bytesFailed,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// Done returns true once the job reached a terminal state.
+func (e BatchJobProgressEvent) Done() bool {
+	switch e.Status {
+	case BatchJobCompleted, BatchJobFailed, BatchJobCanceled:
+		return true
+	}
+	return false
+}
+
+// BatchJobProgressResult - contains the progress event or an error
+type BatchJobProgressResult struct {
+	Event BatchJobProgressEvent
+	Err   error
+}
+
+// BatchJobProgress - streams progress events of a batch job until it
+// completes or the context is canceled.
+func (adm *AdminClient) BatchJobProgress(ctx context.Context, jobID string) <-chan BatchJobProgressResult {
+	progressCh := make(chan BatchJobProgressResult)
+
+	go func(progressCh chan<- BatchJobProgressResult) {
+		defer close(progressCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case progressCh <- BatchJobProgressResult{Err: err}:
+			}
+		}
+
+		if jobID == "" {
+			sendErr(ErrInvalidArgument("job ID cannot be empty"))
+			return
+		}
+		values := make(url.Values)
+		values.Set("jobId", jobID)
+
+		resp, err := adm.executeMethod(ctx, http.MethodGet,
+			requestData{
+				relPath:     adminAPIPrefix + "/job-progress",
+				queryValues: values,
+				category:    RequestCategoryStreaming,
+			})
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var event BatchJobProgressEvent
+			if err = dec.Decode(&event); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case progressCh <- BatchJobProgressResult{Event: event}:
+			}
+			if event.Done() {
+				return
+			}
+		}
+	}(progressCh)
+
+	return progressCh
+}
@@ -0,0 +1,65 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBatchJobProgress(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/job-progress" || r.URL.Query().Get("jobId") != "job1" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchJob")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(BatchJobProgressEvent{JobID: "job1", Status: BatchJobRunning})
+		enc.Encode(BatchJobProgressEvent{JobID: "job1", Status: BatchJobCompleted})
+		enc.Encode(BatchJobProgressEvent{JobID: "job1", Status: BatchJobRunning})
+	})
+
+	var events []BatchJobProgressEvent
+	for res := range adm.BatchJobProgress(context.Background(), "job1") {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		events = append(events, res.Event)
+	}
+	if len(events) != 2 || !events[1].Done() {
+		t.Fatalf("expected events until completion, got %+v", events)
+	}
+
+	for res := range adm.BatchJobProgress(context.Background(), "job2") {
+		if ToErrorResponse(res.Err).Code != "XMinioAdminNoSuchJob" {
+			t.Fatalf("expected XMinioAdminNoSuchJob, got %v", res.Err)
+		}
+	}
+
+	// Errors are not sent once the context is canceled,
+	// so an abandoned channel does not leak the goroutine.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	progressCh := adm.BatchJobProgress(ctx, "")
+	time.Sleep(100 * time.Millisecond)
+	if res, ok := <-progressCh; ok {
+		t.Fatalf("expected closed channel, got %+v", res)
+	}
+}
@@ -0,0 +1,199 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package batch provides typed builders for the MinIO batch job
+// definitions accepted by madmin.AdminClient.StartBatchJob.
+//
+// Jobs are validated on the client before being rendered as YAML, so
+// errors in a job definition are reported before it is submitted.
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion is the batch job definition version produced by this package.
+const APIVersion = "v1"
+
+// Job is implemented by all batch job definitions.
+type Job interface {
+	// Type returns the batch job type.
+	Type() madmin.BatchJobType
+	// Validate returns an error if the job definition is invalid.
+	Validate() error
+	// YAML returns the validated job definition as YAML.
+	YAML() (string, error)
+}
+
+// Credentials used to access a remote endpoint.
+type Credentials struct {
+	AccessKey    string `yaml:"accessKey"`
+	SecretKey    string `yaml:"secretKey"`
+	SessionToken string `yaml:"sessionToken,omitempty"`
+}
+
+func (c Credentials) validate() error {
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return errors.New("access key and secret key must both be set")
+	}
+	return nil
+}
+
+// KV is a key value pair matched against object tags or metadata.
+type KV struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// Filter selects the objects a job applies to.
+type Filter struct {
+	NewerThan     time.Duration `yaml:"newerThan,omitempty"`
+	OlderThan     time.Duration `yaml:"olderThan,omitempty"`
+	CreatedAfter  time.Time     `yaml:"createdAfter,omitempty"`
+	CreatedBefore time.Time     `yaml:"createdBefore,omitempty"`
+	Tags          []KV          `yaml:"tags,omitempty"`
+	Metadata      []KV          `yaml:"metadata,omitempty"`
+}
+
+func (f Filter) validate() error {
+	if f.NewerThan < 0 || f.OlderThan < 0 {
+		return errors.New("filter durations must not be negative")
+	}
+	if f.NewerThan > 0 && f.OlderThan > 0 && f.NewerThan >= f.OlderThan {
+		return errors.New("filter newerThan must be less than olderThan")
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return errors.New("filter createdAfter must be before createdBefore")
+	}
+	for _, kv := range append(f.Tags, f.Metadata...) {
+		if kv.Key == "" {
+			return errors.New("filter tag and metadata keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// Notify configures a webhook notified about the job progress.
+type Notify struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Token    string `yaml:"token,omitempty"`
+}
+
+func (n Notify) validate() error {
+	if n.Token != "" && n.Endpoint == "" {
+		return errors.New("notify token requires an endpoint")
+	}
+	if n.Endpoint != "" && !strings.HasPrefix(n.Endpoint, "http://") && !strings.HasPrefix(n.Endpoint, "https://") {
+		return fmt.Errorf("notify endpoint %q must be an http(s) URL", n.Endpoint)
+	}
+	return nil
+}
+
+// Retry configures how often failed objects are retried.
+type Retry struct {
+	Attempts int           `yaml:"attempts,omitempty"`
+	Delay    time.Duration `yaml:"delay,omitempty"`
+}
+
+func (r Retry) validate() error {
+	if r.Attempts < 0 || r.Delay < 0 {
+		return errors.New("retry attempts and delay must not be negative")
+	}
+	return nil
+}
+
+// Flags common to all job types.
+type Flags struct {
+	Filter Filter `yaml:"filter,omitempty"`
+	Notify Notify `yaml:"notify,omitempty"`
+	Retry  Retry  `yaml:"retry,omitempty"`
+}
+
+func (f Flags) validate() error {
+	if err := f.Filter.validate(); err != nil {
+		return err
+	}
+	if err := f.Notify.validate(); err != nil {
+		return err
+	}
+	return f.Retry.validate()
+}
+
+// render validates job and marshals it under its type key.
+func render(job Job) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", err
+	}
+	buf, err := yaml.Marshal(map[string]interface{}{
+		string(job.Type()): job,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// Parse parses a YAML batch job definition into its typed job, the
+// returned job is validated.
+func Parse(definition string) (Job, error) {
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) != 1 {
+		return nil, errors.New("batch job definition must contain exactly one job")
+	}
+
+	var job Job
+	for typ, node := range raw {
+		switch madmin.BatchJobType(typ) {
+		case madmin.BatchJobReplicate:
+			job = &ReplicateJob{}
+		case madmin.BatchJobKeyRotate:
+			job = &KeyRotateJob{}
+		case madmin.BatchJobExpire:
+			job = &ExpireJob{}
+		default:
+			return nil, fmt.Errorf("unsupported batch job type %q", typ)
+		}
+		if err := node.Decode(job); err != nil {
+			return nil, err
+		}
+	}
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Validate validates a YAML batch job definition.
+func Validate(definition string) error {
+	_, err := Parse(definition)
+	return err
+}
+
+func validateAPIVersion(v string) error {
+	if v != "" && v != APIVersion {
+		return fmt.Errorf("unsupported apiVersion %q, expected %q", v, APIVersion)
+	}
+	return nil
+}
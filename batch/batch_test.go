@@ -0,0 +1,112 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package batch
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJobYAMLRoundTrip(t *testing.T) {
+	jobs := []Job{
+		ReplicateJob{
+			Source: ReplicateEndpoint{Bucket: "src", Prefix: "logs/"},
+			Target: ReplicateEndpoint{
+				Type:        TypeS3,
+				Bucket:      "dst",
+				Endpoint:    "https://play.min.io",
+				Credentials: &Credentials{AccessKey: "access", SecretKey: "secret"},
+			},
+			Flags: Flags{
+				Filter: Filter{OlderThan: 24 * time.Hour, Tags: []KV{{Key: "env", Value: "prod"}}},
+				Retry:  Retry{Attempts: 3, Delay: time.Second},
+			},
+		},
+		KeyRotateJob{
+			Bucket:     "bucket",
+			Encryption: Encryption{Type: EncryptionSSEKMS, Key: "my-key"},
+		},
+		ExpireJob{
+			Bucket: "bucket",
+			Rules: []ExpireRule{
+				{Type: ExpireObject, OlderThan: 720 * time.Hour, Size: ExpireSize{LessThan: 1 << 20}},
+				{Type: ExpireDeleted, Purge: ExpirePurge{RetainVersions: 1}},
+			},
+		},
+	}
+	for i, job := range jobs {
+		def, err := job.YAML()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !strings.HasPrefix(def, string(job.Type())+":") {
+			t.Errorf("Test %d: expected definition to start with the job type, got %s", i+1, def)
+		}
+		parsed, err := Parse(def)
+		if err != nil {
+			t.Fatalf("Test %d: failed to parse %s: %v", i+1, def, err)
+		}
+		if parsed.Type() != job.Type() {
+			t.Errorf("Test %d: expected job type %s, got %s", i+1, job.Type(), parsed.Type())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		definition string
+		wantErr    bool
+	}{
+		{
+			definition: "keyrotate:\n  apiVersion: v1\n  bucket: b\n  encryption:\n    type: sse-s3\n",
+		},
+		{
+			definition: "keyrotate:\n  apiVersion: v2\n  bucket: b\n  encryption:\n    type: sse-s3\n",
+			wantErr:    true,
+		},
+		{
+			definition: "keyrotate:\n  bucket: b\n  encryption:\n    type: sse-kms\n",
+			wantErr:    true,
+		},
+		{
+			definition: "replicate:\n  source:\n    bucket: b\n  target:\n    bucket: b\n",
+			wantErr:    true,
+		},
+		{
+			definition: "replicate:\n  source:\n    bucket: a\n  target:\n    bucket: b\n    endpoint: https://remote\n",
+			wantErr:    true,
+		},
+		{
+			definition: "expire:\n  bucket: b\n  rules:\n    - type: deleted\n      tags:\n        - key: k\n          value: v\n",
+			wantErr:    true,
+		},
+		{
+			definition: "unknown:\n  bucket: b\n",
+			wantErr:    true,
+		},
+		{
+			definition: "expire:\n  bucket: b\n  rules: []\n",
+			wantErr:    true,
+		},
+	}
+	for i, tc := range testCases {
+		if err := Validate(tc.definition); (err != nil) != tc.wantErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// Expire rule types
+const (
+	ExpireObject  = "object"
+	ExpireDeleted = "deleted"
+)
+
+// ExpireSize matches objects by size, zero values are ignored.
+type ExpireSize struct {
+	LessThan    int64 `yaml:"lessThan,omitempty"`
+	GreaterThan int64 `yaml:"greaterThan,omitempty"`
+}
+
+// ExpirePurge controls how many versions of a matching object are kept.
+type ExpirePurge struct {
+	RetainVersions int `yaml:"retainVersions,omitempty"`
+}
+
+// ExpireRule selects the objects or delete markers to expire.
+type ExpireRule struct {
+	Type          string        `yaml:"type"`
+	Name          string        `yaml:"name,omitempty"`
+	OlderThan     time.Duration `yaml:"olderThan,omitempty"`
+	CreatedBefore time.Time     `yaml:"createdBefore,omitempty"`
+	Tags          []KV          `yaml:"tags,omitempty"`
+	Metadata      []KV          `yaml:"metadata,omitempty"`
+	Size          ExpireSize    `yaml:"size,omitempty"`
+	Purge         ExpirePurge   `yaml:"purge,omitempty"`
+}
+
+func (r ExpireRule) validate() error {
+	switch r.Type {
+	case ExpireObject:
+	case ExpireDeleted:
+		if len(r.Tags) > 0 || len(r.Metadata) > 0 || r.Size != (ExpireSize{}) {
+			return errors.New("tags, metadata and size can not be used to expire delete markers")
+		}
+	default:
+		return fmt.Errorf("unsupported expire rule type %q", r.Type)
+	}
+	if r.OlderThan < 0 {
+		return errors.New("olderThan must not be negative")
+	}
+	if r.Size.LessThan < 0 || r.Size.GreaterThan < 0 {
+		return errors.New("size bounds must not be negative")
+	}
+	if r.Size.LessThan > 0 && r.Size.GreaterThan >= r.Size.LessThan {
+		return errors.New("size greaterThan must be less than lessThan")
+	}
+	if r.Purge.RetainVersions < 0 {
+		return errors.New("purge retainVersions must not be negative")
+	}
+	return nil
+}
+
+// ExpireJob expires the objects of a bucket matching any of the rules.
+type ExpireJob struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Bucket     string       `yaml:"bucket"`
+	Prefix     string       `yaml:"prefix,omitempty"`
+	Rules      []ExpireRule `yaml:"rules"`
+	Notify     Notify       `yaml:"notify,omitempty"`
+	Retry      Retry        `yaml:"retry,omitempty"`
+}
+
+// Type returns madmin.BatchJobExpire.
+func (j ExpireJob) Type() madmin.BatchJobType {
+	return madmin.BatchJobExpire
+}
+
+// Validate returns an error if the job definition is invalid.
+func (j ExpireJob) Validate() error {
+	if err := validateAPIVersion(j.APIVersion); err != nil {
+		return err
+	}
+	if j.Bucket == "" {
+		return errors.New("bucket must be set")
+	}
+	if len(j.Rules) == 0 {
+		return errors.New("at least one expire rule must be set")
+	}
+	for i, r := range j.Rules {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	if err := j.Notify.validate(); err != nil {
+		return err
+	}
+	return j.Retry.validate()
+}
+
+// YAML returns the validated job definition as YAML.
+func (j ExpireJob) YAML() (string, error) {
+	j.APIVersion = APIVersion
+	return render(j)
+}
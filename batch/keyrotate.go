@@ -0,0 +1,87 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package batch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/minio/madmin-go"
+)
+
+// Key rotation encryption types
+const (
+	EncryptionSSES3  = "sse-s3"
+	EncryptionSSEKMS = "sse-kms"
+)
+
+// Encryption describes the encryption the objects are rotated to.
+type Encryption struct {
+	Type    string            `yaml:"type"`
+	Key     string            `yaml:"key,omitempty"`
+	Context map[string]string `yaml:"context,omitempty"`
+}
+
+func (e Encryption) validate() error {
+	switch e.Type {
+	case EncryptionSSES3:
+		if e.Key != "" || len(e.Context) > 0 {
+			return errors.New("encryption key and context are only valid for sse-kms")
+		}
+	case EncryptionSSEKMS:
+		if e.Key == "" {
+			return errors.New("encryption key must be set for sse-kms")
+		}
+	default:
+		return fmt.Errorf("unsupported encryption type %q", e.Type)
+	}
+	return nil
+}
+
+// KeyRotateJob re-encrypts the objects of a bucket with a new key.
+type KeyRotateJob struct {
+	APIVersion string     `yaml:"apiVersion"`
+	Bucket     string     `yaml:"bucket"`
+	Prefix     string     `yaml:"prefix,omitempty"`
+	Encryption Encryption `yaml:"encryption"`
+	Flags      Flags      `yaml:"flags,omitempty"`
+}
+
+// Type returns madmin.BatchJobKeyRotate.
+func (j KeyRotateJob) Type() madmin.BatchJobType {
+	return madmin.BatchJobKeyRotate
+}
+
+// Validate returns an error if the job definition is invalid.
+func (j KeyRotateJob) Validate() error {
+	if err := validateAPIVersion(j.APIVersion); err != nil {
+		return err
+	}
+	if j.Bucket == "" {
+		return errors.New("bucket must be set")
+	}
+	if err := j.Encryption.validate(); err != nil {
+		return err
+	}
+	return j.Flags.validate()
+}
+
+// YAML returns the validated job definition as YAML.
+func (j KeyRotateJob) YAML() (string, error) {
+	j.APIVersion = APIVersion
+	return render(j)
+}
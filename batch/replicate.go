@@ -0,0 +1,102 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package batch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/minio/madmin-go"
+)
+
+// Replicate endpoint types
+const (
+	TypeS3 = "s3"
+)
+
+// ReplicateEndpoint is the source or target of a replication job, an
+// empty Endpoint refers to the cluster running the job.
+type ReplicateEndpoint struct {
+	Type        string       `yaml:"type,omitempty"`
+	Bucket      string       `yaml:"bucket"`
+	Prefix      string       `yaml:"prefix,omitempty"`
+	Endpoint    string       `yaml:"endpoint,omitempty"`
+	Path        string       `yaml:"path,omitempty"`
+	Credentials *Credentials `yaml:"credentials,omitempty"`
+}
+
+func (e ReplicateEndpoint) validate(side string) error {
+	if e.Type != "" && e.Type != TypeS3 {
+		return fmt.Errorf("%s: unsupported type %q", side, e.Type)
+	}
+	if e.Bucket == "" {
+		return fmt.Errorf("%s: bucket must be set", side)
+	}
+	switch e.Path {
+	case "", "on", "off", "auto":
+	default:
+		return fmt.Errorf("%s: path must be one of on, off or auto", side)
+	}
+	if e.Endpoint != "" {
+		if e.Credentials == nil {
+			return fmt.Errorf("%s: credentials are required for a remote endpoint", side)
+		}
+		if err := e.Credentials.validate(); err != nil {
+			return fmt.Errorf("%s: %w", side, err)
+		}
+	}
+	return nil
+}
+
+// ReplicateJob copies objects between a source and a target bucket.
+type ReplicateJob struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Source     ReplicateEndpoint `yaml:"source"`
+	Target     ReplicateEndpoint `yaml:"target"`
+	Flags      Flags             `yaml:"flags,omitempty"`
+}
+
+// Type returns madmin.BatchJobReplicate.
+func (j ReplicateJob) Type() madmin.BatchJobType {
+	return madmin.BatchJobReplicate
+}
+
+// Validate returns an error if the job definition is invalid.
+func (j ReplicateJob) Validate() error {
+	if err := validateAPIVersion(j.APIVersion); err != nil {
+		return err
+	}
+	if err := j.Source.validate("source"); err != nil {
+		return err
+	}
+	if err := j.Target.validate("target"); err != nil {
+		return err
+	}
+	if j.Source.Endpoint != "" && j.Target.Endpoint != "" {
+		return errors.New("either source or target must be the local cluster")
+	}
+	if j.Source.Endpoint == j.Target.Endpoint && j.Source.Bucket == j.Target.Bucket {
+		return errors.New("source and target must not be the same bucket")
+	}
+	return j.Flags.validate()
+}
+
+// YAML returns the validated job definition as YAML.
+func (j ReplicateJob) YAML() (string, error) {
+	j.APIVersion = APIVersion
+	return render(j)
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 )
@@ -35,6 +34,7 @@ func (adm *AdminClient) ExportBucketMetadata(ctx context.Context, bucket string)
 		http.MethodGet, requestData{
 			relPath:     path,
 			queryValues: queryValues,
+			category:    RequestCategoryTransfer,
 		},
 	)
 	if err != nil {
@@ -74,22 +74,34 @@ type BucketMetaImportErrs struct {
 
 // ImportBucketMetadata makes an admin call to set bucket metadata of a bucket from imported content
 func (adm *AdminClient) ImportBucketMetadata(ctx context.Context, bucket string, contentReader io.ReadCloser) (r BucketMetaImportErrs, err error) {
-	content, err := ioutil.ReadAll(contentReader)
-	if err != nil {
-		return r, err
-	}
+	return adm.ImportBucketMetadataWithOptions(ctx, bucket, contentReader, BucketMetaImportOpts{})
+}
 
+// BucketMetaImportOpts - options of a bucket metadata import
+type BucketMetaImportOpts struct {
+	// StreamingUpload uploads large seekable content, e.g. files,
+	// with a streaming signature instead of buffering it in memory.
+	// Only set it for servers accepting aws-chunked payloads.
+	StreamingUpload bool
+}
+
+// ImportBucketMetadataWithOptions makes an admin call to set bucket metadata
+// of a bucket from imported content, as ImportBucketMetadata with opts.
+func (adm *AdminClient) ImportBucketMetadataWithOptions(ctx context.Context, bucket string, contentReader io.ReadCloser, opts BucketMetaImportOpts) (r BucketMetaImportErrs, err error) {
 	path := adminAPIPrefix + "/import-bucket-metadata"
 	queryValues := url.Values{}
 	queryValues.Set("bucket", bucket)
 
-	resp, err := adm.executeMethod(ctx,
-		http.MethodPut, requestData{
-			relPath:     path,
-			queryValues: queryValues,
-			content:     content,
-		},
-	)
+	reqData := requestData{
+		relPath:     path,
+		queryValues: queryValues,
+		category:    RequestCategoryTransfer,
+	}
+	if err = reqData.setContent(contentReader, opts.StreamingUpload); err != nil {
+		return r, err
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
 	defer closeResponse(resp)
 
 	if err != nil {
@@ -0,0 +1,116 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Call home configuration keys
+const (
+	CallhomeFrequencyKey = "frequency"
+)
+
+// CallhomeConfig - typed call home configuration, controlling the
+// periodic upload of diagnostics and logs to SUBNET.
+type CallhomeConfig struct {
+	Enable    bool
+	Frequency time.Duration
+}
+
+// GetCallhomeConfig - returns the call home configuration of the cluster.
+func (adm *AdminClient) GetCallhomeConfig(ctx context.Context) (CallhomeConfig, error) {
+	buf, err := adm.GetConfigKV(ctx, CallhomeSubSys)
+	if err != nil {
+		return CallhomeConfig{}, err
+	}
+	cfgs, err := ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return CallhomeConfig{}, err
+	}
+
+	var cfg CallhomeConfig
+	if len(cfgs) == 0 {
+		return cfg, nil
+	}
+	if v, ok := cfgs[0].Lookup(EnableKey); ok {
+		cfg.Enable = v == EnableOn || v == "true"
+	}
+	if v, ok := cfgs[0].Lookup(CallhomeFrequencyKey); ok && v != "" {
+		if cfg.Frequency, err = time.ParseDuration(v); err != nil {
+			return CallhomeConfig{}, err
+		}
+	}
+	return cfg, nil
+}
+
+// SetCallhomeConfig - updates the call home configuration of the
+// cluster, a zero frequency keeps the server default.
+func (adm *AdminClient) SetCallhomeConfig(ctx context.Context, cfg CallhomeConfig) (restart bool, err error) {
+	if cfg.Frequency < 0 {
+		return false, ErrInvalidArgument("call home frequency must not be negative")
+	}
+	kv := CallhomeSubSys + KvSpaceSeparator + EnableKey + KvSeparator + EnableOff
+	if cfg.Enable {
+		kv = CallhomeSubSys + KvSpaceSeparator + EnableKey + KvSeparator + EnableOn
+	}
+	if cfg.Frequency > 0 {
+		kv += KvSpaceSeparator + CallhomeFrequencyKey + KvSeparator + cfg.Frequency.String()
+	}
+	return adm.SetConfigKV(ctx, kv)
+}
+
+// CallhomeStatus - status of the call home uploads of the cluster.
+type CallhomeStatus struct {
+	Enabled     bool          `json:"enabled"`
+	Frequency   time.Duration `json:"frequency"`
+	LastAttempt time.Time     `json:"lastAttempt,omitempty"`
+	LastSuccess time.Time     `json:"lastSuccess,omitempty"`
+	LastError   string        `json:"lastError,omitempty"`
+	NextRun     time.Time     `json:"nextRun,omitempty"`
+}
+
+// Overdue returns true if call home is enabled but no upload
+// succeeded within the last two periods.
+func (s CallhomeStatus) Overdue() bool {
+	if !s.Enabled || s.Frequency <= 0 {
+		return false
+	}
+	return time.Since(s.LastSuccess) > 2*s.Frequency
+}
+
+// CallhomeStatus - returns the status of the call home uploads.
+func (adm *AdminClient) CallhomeStatus(ctx context.Context) (CallhomeStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: adminAPIPrefix + "/callhome/status",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return CallhomeStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return CallhomeStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	var status CallhomeStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	return status, err
+}
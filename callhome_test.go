@@ -0,0 +1,102 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCallhomeConfig(t *testing.T) {
+	var set string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/get-config-kv":
+			if r.URL.Query().Get("key") != CallhomeSubSys {
+				writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+				return
+			}
+			data, err := EncryptData("minio123", []byte("callhome enable=on frequency=12h0m0s"))
+			if err != nil {
+				t.Error(err)
+			}
+			w.Write(data)
+		case "/minio/admin/v3/set-config-kv":
+			data, _ := io.ReadAll(r.Body)
+			buf, err := DecryptData("minio123", bytes.NewReader(data))
+			if err != nil {
+				t.Error(err)
+			}
+			set = string(buf)
+			w.Header().Set(ConfigAppliedHeader, ConfigAppliedTrue)
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	cfg, err := adm.GetCallhomeConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enable || cfg.Frequency != 12*time.Hour {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	restart, err := adm.SetCallhomeConfig(ctx, CallhomeConfig{Enable: true, Frequency: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restart || set != "callhome enable=on frequency=1h0m0s" {
+		t.Fatalf("unexpected update %q (restart %v)", set, restart)
+	}
+
+	if _, err = adm.SetCallhomeConfig(ctx, CallhomeConfig{Frequency: -time.Hour}); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestCallhomeStatus(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/callhome/status" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		json.NewEncoder(w).Encode(CallhomeStatus{Enabled: true, Frequency: time.Hour, LastSuccess: now})
+	})
+
+	status, err := adm.CallhomeStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Enabled || status.Frequency != time.Hour || !status.LastSuccess.Equal(now) || status.Overdue() {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	adm = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	if _, err = adm.CallhomeStatus(context.Background()); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -49,6 +50,12 @@ const (
 
 	// Proc controller group path.
 	cgroupFileTemplate = "/proc/%d/cgroup"
+
+	// Mount point of the cgroup v2 unified hierarchy.
+	cgroupV2SysPath = "/sys/fs/cgroup"
+
+	// Points to sys path of the cgroup v1 cpu controller.
+	cgroupCPUSysPath = "/sys/fs/cgroup/cpu"
 )
 
 // CGEntries - represents all the entries in a process cgroup file
@@ -156,6 +163,15 @@ func GetMemoryLimit(pid int) (limit uint64, err error) {
 		return 0, err
 	}
 
+	if cg.IsV2() {
+		var v string
+		v, err = readV2Value(cg[""], "memory.max")
+		if err != nil {
+			return 0, err
+		}
+		return parseMax(v)
+	}
+
 	path := cg["memory"]
 
 	limit, err = getManagerKernValue("memory", path, memoryLimitKernelParam)
@@ -175,3 +191,182 @@ func GetMemoryLimit(pid int) (limit uint64, err error) {
 
 	return limit, err
 }
+
+// IsV2 returns true if the process is only part of
+// the cgroup v2 unified hierarchy.
+func (cg CGEntries) IsV2() bool {
+	_, ok := cg[""]
+	return ok && len(cg) == 1
+}
+
+// IOLimit is the io.max limit of a block device, zero values are unlimited.
+type IOLimit struct {
+	// Device is the major:minor number of the device.
+	Device    string `json:"device"`
+	ReadBPS   uint64 `json:"rbps,omitempty"`
+	WriteBPS  uint64 `json:"wbps,omitempty"`
+	ReadIOPS  uint64 `json:"riops,omitempty"`
+	WriteIOPS uint64 `json:"wiops,omitempty"`
+}
+
+// Limits are the resource limits a process is subject to.
+type Limits struct {
+	// Version of the cgroup hierarchy, 1 or 2.
+	Version int `json:"version"`
+	// MemoryMax is the memory limit in bytes, math.MaxUint64 if unlimited.
+	MemoryMax uint64 `json:"memory_max"`
+	// MemoryUsage is the memory currently charged to the cgroup.
+	MemoryUsage uint64 `json:"memory_usage"`
+	// CPUMax is the number of CPUs the cgroup may use, 0 if unlimited.
+	CPUMax float64 `json:"cpu_max,omitempty"`
+	// IOMax are the per device IO limits, only supported with cgroup v2.
+	IOMax []IOLimit `json:"io_max,omitempty"`
+}
+
+// GetLimits returns the memory, cpu and io limits of the cgroup of the
+// given process, for both cgroup v1 and the cgroup v2 unified hierarchy.
+func GetLimits(pid int) (Limits, error) {
+	cg, err := GetEntries(pid)
+	if err != nil {
+		return Limits{}, err
+	}
+	if cg.IsV2() {
+		return getV2Limits(cg[""])
+	}
+
+	limits := Limits{Version: 1}
+	if limits.MemoryMax, err = GetMemoryLimit(pid); err != nil {
+		return Limits{}, err
+	}
+	if v, err := readV1Value(cgroupMemSysPath, cg["memory"], "memory.usage_in_bytes"); err == nil {
+		limits.MemoryUsage, _ = strconv.ParseUint(v, 10, 64)
+	}
+
+	quota, err := readV1Value(cgroupCPUSysPath, cg["cpu"], "cpu.cfs_quota_us")
+	if err != nil {
+		return limits, nil
+	}
+	period, err := readV1Value(cgroupCPUSysPath, cg["cpu"], "cpu.cfs_period_us")
+	if err != nil {
+		return limits, nil
+	}
+	limits.CPUMax, err = parseCPUQuota(quota, period)
+	return limits, err
+}
+
+func getV2Limits(path string) (limits Limits, err error) {
+	limits.Version = 2
+
+	v, err := readV2Value(path, "memory.max")
+	if err != nil {
+		return Limits{}, err
+	}
+	if limits.MemoryMax, err = parseMax(v); err != nil {
+		return Limits{}, err
+	}
+	if v, err = readV2Value(path, "memory.current"); err == nil {
+		limits.MemoryUsage, _ = strconv.ParseUint(v, 10, 64)
+	}
+
+	// cpu and io controllers may not be enabled for the cgroup.
+	if v, err = readV2Value(path, "cpu.max"); err == nil {
+		fields := strings.Fields(v)
+		if len(fields) != 2 {
+			return Limits{}, fmt.Errorf("invalid cpu.max %q", v)
+		}
+		if fields[0] == "max" {
+			fields[0] = "-1"
+		}
+		if limits.CPUMax, err = parseCPUQuota(fields[0], fields[1]); err != nil {
+			return Limits{}, err
+		}
+	}
+	if v, err = readV2Value(path, "io.max"); err == nil {
+		if limits.IOMax, err = parseIOMax(strings.NewReader(v)); err != nil {
+			return Limits{}, err
+		}
+	}
+	return limits, nil
+}
+
+// readV2Value reads the cgroup v2 interface file of the cgroup at path,
+// falling back to the root of the hierarchy when the cgroup path is not
+// visible, as happens inside containers.
+func readV2Value(path, file string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(cgroupV2SysPath, path, file))
+	if os.IsNotExist(err) && path != "/" {
+		b, err = ioutil.ReadFile(filepath.Join(cgroupV2SysPath, file))
+	}
+	return strings.TrimSpace(string(b)), err
+}
+
+// readV1Value reads the interface file of the cgroup v1 controller
+// mounted at root, ignoring docker paths like getMemoryLimitFilePath.
+func readV1Value(root, path, file string) (string, error) {
+	if !strings.HasPrefix(path, dockerPrefixName) {
+		root = filepath.Join(root, path)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(root, file))
+	return strings.TrimSpace(string(b)), err
+}
+
+// parseMax parses a cgroup v2 limit, "max" is returned as math.MaxUint64.
+func parseMax(v string) (uint64, error) {
+	if v == "max" {
+		return math.MaxUint64, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// parseCPUQuota returns the number of CPUs allowed by a quota over a
+// period, a negative quota is unlimited and returned as 0.
+func parseCPUQuota(quota, period string) (float64, error) {
+	q, err := strconv.ParseInt(quota, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	p, err := strconv.ParseInt(period, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if q < 0 || p <= 0 {
+		return 0, nil
+	}
+	return float64(q) / float64(p), nil
+}
+
+// parseIOMax parses the cgroup v2 io.max file, made of lines like
+// "8:16 rbps=2097152 wbps=max riops=max wiops=120".
+func parseIOMax(r io.Reader) ([]IOLimit, error) {
+	var limits []IOLimit
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		limit := IOLimit{Device: fields[0]}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 || kv[1] == "max" {
+				continue
+			}
+			n, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			switch kv[0] {
+			case "rbps":
+				limit.ReadBPS = n
+			case "wbps":
+				limit.WriteBPS = n
+			case "riops":
+				limit.ReadIOPS = n
+			case "wiops":
+				limit.WriteIOPS = n
+			}
+		}
+		limits = append(limits, limit)
+	}
+	return limits, scanner.Err()
+}
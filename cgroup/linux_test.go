@@ -21,7 +21,10 @@ package cgroup
 
 import (
 	"io/ioutil"
+	"math"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -138,3 +141,49 @@ func TestMemoryLimitPath(t *testing.T) {
 		}
 	}
 }
+
+// Tests detection of the cgroup v2 unified hierarchy.
+func TestCGroupV2(t *testing.T) {
+	cg, err := parseProcCGroup(strings.NewReader("0::/system.slice/minio.service\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cg.IsV2() {
+		t.Fatal("expected cgroup v2")
+	}
+	cg, err = parseProcCGroup(strings.NewReader("4:memory:/user.slice\n0::/user.slice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cg.IsV2() {
+		t.Fatal("expected hybrid cgroup v1")
+	}
+}
+
+// Tests parsing of cgroup v2 limits.
+func TestParseV2Limits(t *testing.T) {
+	if v, err := parseMax("max"); err != nil || v != math.MaxUint64 {
+		t.Fatalf("expected unlimited memory, got %d, %v", v, err)
+	}
+	if v, err := parseMax("1073741824"); err != nil || v != 1<<30 {
+		t.Fatalf("expected 1GiB, got %d, %v", v, err)
+	}
+	if v, err := parseCPUQuota("150000", "100000"); err != nil || v != 1.5 {
+		t.Fatalf("expected 1.5 CPUs, got %v, %v", v, err)
+	}
+	if v, err := parseCPUQuota("-1", "100000"); err != nil || v != 0 {
+		t.Fatalf("expected unlimited CPUs, got %v, %v", v, err)
+	}
+
+	limits, err := parseIOMax(strings.NewReader("8:16 rbps=2097152 wbps=max riops=max wiops=120\n8:0 rbps=max wbps=max riops=max wiops=max\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []IOLimit{
+		{Device: "8:16", ReadBPS: 2097152, WriteIOPS: 120},
+		{Device: "8:0"},
+	}
+	if !reflect.DeepEqual(limits, expected) {
+		t.Fatalf("expected %v, got %v", expected, limits)
+	}
+}
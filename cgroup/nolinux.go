@@ -25,3 +25,26 @@ import "errors"
 func GetMemoryLimit(pid int) (limit uint64, err error) {
 	return limit, errors.New("Not implemented for non-linux platforms")
 }
+
+// IOLimit is the io.max limit of a block device, zero values are unlimited.
+type IOLimit struct {
+	Device    string `json:"device"`
+	ReadBPS   uint64 `json:"rbps,omitempty"`
+	WriteBPS  uint64 `json:"wbps,omitempty"`
+	ReadIOPS  uint64 `json:"riops,omitempty"`
+	WriteIOPS uint64 `json:"wiops,omitempty"`
+}
+
+// Limits are the resource limits a process is subject to.
+type Limits struct {
+	Version     int       `json:"version"`
+	MemoryMax   uint64    `json:"memory_max"`
+	MemoryUsage uint64    `json:"memory_usage"`
+	CPUMax      float64   `json:"cpu_max,omitempty"`
+	IOMax       []IOLimit `json:"io_max,omitempty"`
+}
+
+// GetLimits - Not implemented in non-linux platforms
+func GetLimits(pid int) (Limits, error) {
+	return Limits{}, errors.New("Not implemented for non-linux platforms")
+}
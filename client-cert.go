@@ -0,0 +1,111 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// clientCertLoader presents a client certificate loaded from disk,
+// reloading it when the certificate or key file changes.
+type clientCertLoader struct {
+	certFile, keyFile string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod time.Time
+	keyMod  time.Time
+}
+
+// newClientCertLoader loads the certificate once to
+// report invalid files at client construction.
+func newClientCertLoader(certFile, keyFile string) (*clientCertLoader, error) {
+	l := &clientCertLoader{certFile: certFile, keyFile: keyFile}
+	if _, err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// load returns the current certificate, reloading it if the
+// files were modified since the last load.
+func (l *clientCertLoader) load() (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	certInfo, err := os.Stat(l.certFile)
+	if err != nil {
+		return l.lastCert(err)
+	}
+	keyInfo, err := os.Stat(l.keyFile)
+	if err != nil {
+		return l.lastCert(err)
+	}
+	if l.cert != nil && certInfo.ModTime().Equal(l.certMod) && keyInfo.ModTime().Equal(l.keyMod) {
+		return l.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		// The files may be in the middle of a rotation.
+		return l.lastCert(err)
+	}
+	l.cert = &cert
+	l.certMod = certInfo.ModTime()
+	l.keyMod = keyInfo.ModTime()
+	return l.cert, nil
+}
+
+// lastCert returns the previously loaded certificate, if any, or err.
+func (l *clientCertLoader) lastCert(err error) (*tls.Certificate, error) {
+	if l.cert != nil {
+		return l.cert, nil
+	}
+	return nil, err
+}
+
+// getClientCertificate implements tls.Config.GetClientCertificate.
+func (l *clientCertLoader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.load()
+}
+
+// setClientCertificate configures tr to present the client
+// certificate of certFile and keyFile on TLS connections.
+func setClientCertificate(tr http.RoundTripper, certFile, keyFile string) error {
+	if certFile == "" || keyFile == "" {
+		return ErrInvalidArgument("both client certificate and key files are required")
+	}
+	httpTr, ok := tr.(*http.Transport)
+	if !ok {
+		return ErrInvalidArgument("client certificates require an *http.Transport")
+	}
+	loader, err := newClientCertLoader(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	if httpTr.TLSClientConfig == nil {
+		httpTr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	} else {
+		httpTr.TLSClientConfig = httpTr.TLSClientConfig.Clone()
+	}
+	httpTr.TLSClientConfig.GetClientCertificate = loader.getClientCertificate
+	return nil
+}
@@ -57,14 +57,11 @@ func (adm *AdminClient) SiteReplicationAdd(ctx context.Context, sites []PeerSite
 	if err != nil {
 		return ReplicateAddStatus{}, nil
 	}
-	encBytes, err := EncryptData(adm.getSecretKey(), sitesBytes)
-	if err != nil {
-		return ReplicateAddStatus{}, err
-	}
 
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/site-replication/add",
-		content: encBytes,
+		content: sitesBytes,
+		encrypt: true,
 	}
 
 	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
@@ -90,6 +87,91 @@ func (adm *AdminClient) SiteReplicationAdd(ctx context.Context, sites []PeerSite
 	return res, nil
 }
 
+// SRPreflightCheck - name of a check run against a prospective peer site
+type SRPreflightCheck string
+
+// Checks run by SRPeerPreflight
+const (
+	SRPreflightConnectivity SRPreflightCheck = "connectivity"
+	SRPreflightClockSkew    SRPreflightCheck = "clock-skew"
+	SRPreflightVersion      SRPreflightCheck = "version"
+	SRPreflightIAMStore     SRPreflightCheck = "iam-store"
+)
+
+// SRPreflightCheckResult - result of a single preflight check
+type SRPreflightCheckResult struct {
+	Check  SRPreflightCheck `json:"check"`
+	Passed bool             `json:"passed"`
+	Detail string           `json:"detail,omitempty"`
+}
+
+// SRPeerPreflightResult - preflight check results of a prospective peer site
+type SRPeerPreflightResult struct {
+	Name      string                   `json:"name"`
+	Endpoint  string                   `json:"endpoint"`
+	Version   string                   `json:"version,omitempty"`
+	ClockSkew time.Duration            `json:"clockSkew,omitempty"`
+	Checks    []SRPreflightCheckResult `json:"checks"`
+}
+
+// Passed returns true if all checks passed for the peer site
+func (r SRPeerPreflightResult) Passed() bool {
+	for _, c := range r.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// SRPreflightResult - preflight check results of all prospective peer sites
+type SRPreflightResult struct {
+	Peers []SRPeerPreflightResult `json:"peers"`
+}
+
+// Passed returns true if all checks passed for all peer sites, i.e
+// SiteReplicationAdd is expected to succeed with these sites
+func (r SRPreflightResult) Passed() bool {
+	for _, p := range r.Peers {
+		if !p.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// SRPeerPreflight - validates connectivity, clock skew, version and IAM store
+// compatibility with the prospective peer sites without adding them
+func (adm *AdminClient) SRPeerPreflight(ctx context.Context, sites []PeerSite) (SRPreflightResult, error) {
+	if len(sites) == 0 {
+		return SRPreflightResult{}, ErrInvalidArgument("at least one peer site is required")
+	}
+	sitesBytes, err := json.Marshal(sites)
+	if err != nil {
+		return SRPreflightResult{}, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/site-replication/preflight",
+		content: sitesBytes,
+		encrypt: true,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return SRPreflightResult{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return SRPreflightResult{}, httpRespToErrorResponse(resp)
+	}
+
+	var res SRPreflightResult
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	return res, err
+}
+
 // SiteReplicationInfo - contains cluster replication information.
 type SiteReplicationInfo struct {
 	Enabled                 bool       `json:"enabled"`
@@ -147,14 +229,11 @@ func (adm *AdminClient) SRPeerJoin(ctx context.Context, r SRPeerJoinReq) error {
 	if err != nil {
 		return err
 	}
-	encBuf, err := EncryptData(adm.getSecretKey(), b)
-	if err != nil {
-		return err
-	}
 
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/site-replication/peer/join",
-		content: encBuf,
+		content: b,
+		encrypt: true,
 	}
 
 	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
@@ -765,14 +844,11 @@ func (adm *AdminClient) SiteReplicationEdit(ctx context.Context, site PeerInfo)
 	if err != nil {
 		return ReplicateEditStatus{}, nil
 	}
-	encBytes, err := EncryptData(adm.getSecretKey(), sitesBytes)
-	if err != nil {
-		return ReplicateEditStatus{}, err
-	}
 
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/site-replication/edit",
-		content: encBytes,
+		content: sitesBytes,
+		encrypt: true,
 	}
 
 	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
@@ -883,3 +959,94 @@ const (
 	ReplicateRemoveStatusSuccess = "Requested site(s) were removed from cluster replication successfully."
 	ReplicateRemoveStatusPartial = "Some site(s) could not be removed from cluster replication configuration."
 )
+
+// SRBucketPeerStatus - replication status of a bucket towards a peer site
+type SRBucketPeerStatus struct {
+	DeploymentID string `json:"deploymentID"`
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	Online       bool   `json:"online"`
+
+	// Lag is the age of the oldest object not yet replicated to the peer.
+	Lag            time.Duration `json:"lag"`
+	LastReplicated time.Time     `json:"lastReplicated,omitempty"`
+	PendingCount   uint64        `json:"pendingCount"`
+	PendingSize    uint64        `json:"pendingSize"`
+	FailedCount    uint64        `json:"failedCount"`
+	FailedSize     uint64        `json:"failedSize"`
+
+	ResyncStatus string `json:"resyncStatus,omitempty"`
+}
+
+// SRBucketStatus - per peer site replication status of a bucket
+type SRBucketStatus struct {
+	Bucket string               `json:"bucket"`
+	Peers  []SRBucketPeerStatus `json:"peers"`
+}
+
+// SRBucketStatus - returns the replication lag and failed counts of bucket
+// towards every peer site
+func (adm *AdminClient) SRBucketStatus(ctx context.Context, bucket string) (status SRBucketStatus, err error) {
+	if bucket == "" {
+		return status, ErrInvalidArgument("bucket name cannot be empty")
+	}
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucket)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/site-replication/bucket-status",
+		queryValues: urlValues,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	return status, err
+}
+
+// SRResyncStatus - status of a site replication resync request
+type SRResyncStatus struct {
+	Bucket    string `json:"bucket"`
+	Peer      string `json:"peer"`
+	ResyncID  string `json:"resyncID"`
+	Status    string `json:"status"`
+	ErrDetail string `json:"errorDetail,omitempty"`
+}
+
+// SRResyncBucket - starts a resync of bucket towards peer, identified by its
+// site name or deployment ID, replicating the objects missing on the peer
+func (adm *AdminClient) SRResyncBucket(ctx context.Context, bucket, peer string) (st SRResyncStatus, err error) {
+	if bucket == "" {
+		return st, ErrInvalidArgument("bucket name cannot be empty")
+	}
+	if peer == "" {
+		return st, ErrInvalidArgument("peer cannot be empty")
+	}
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucket)
+	urlValues.Set("peer", peer)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/site-replication/resync/bucket",
+		queryValues: urlValues,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return st, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return st, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&st)
+	return st, err
+}
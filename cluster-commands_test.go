@@ -0,0 +1,124 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSRBucketStatus(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/site-replication/bucket-status" ||
+			r.URL.Query().Get("bucket") != "photos" {
+			writeTestError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		json.NewEncoder(w).Encode(SRBucketStatus{
+			Bucket: "photos",
+			Peers: []SRBucketPeerStatus{
+				{Name: "site2", Online: true, Lag: time.Minute, PendingCount: 3, FailedCount: 1},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	status, err := adm.SRBucketStatus(ctx, "photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Peers) != 1 || status.Peers[0].Lag != time.Minute || status.Peers[0].FailedCount != 1 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	if _, err = adm.SRBucketStatus(ctx, "other"); ToErrorResponse(err).Code != "NoSuchBucket" {
+		t.Errorf("expected NoSuchBucket, got %v", err)
+	}
+	if _, err = adm.SRBucketStatus(ctx, ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSRResyncBucket(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPut || r.URL.Path != "/minio/admin/v3/site-replication/resync/bucket" ||
+			q.Get("bucket") != "photos" || q.Get("peer") != "site2" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(SRResyncStatus{Bucket: "photos", Peer: "site2", ResyncID: "r1", Status: "started"})
+	})
+
+	ctx := context.Background()
+	st, err := adm.SRResyncBucket(ctx, "photos", "site2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.ResyncID != "r1" || st.Status != "started" {
+		t.Fatalf("unexpected resync status %+v", st)
+	}
+
+	if _, err = adm.SRResyncBucket(ctx, "photos", "site3"); ToErrorResponse(err).Code != "InvalidRequest" {
+		t.Errorf("expected InvalidRequest, got %v", err)
+	}
+	if _, err = adm.SRResyncBucket(ctx, "photos", ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSRPeerPreflight(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		data, err := DecryptData("minio123", bytes.NewReader(data))
+		var sites []PeerSite
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/site-replication/preflight" ||
+			err != nil || json.Unmarshal(data, &sites) != nil || len(sites) != 2 {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		res := SRPreflightResult{}
+		for _, site := range sites {
+			res.Peers = append(res.Peers, SRPeerPreflightResult{
+				Name:   site.Name,
+				Checks: []SRPreflightCheckResult{{Check: SRPreflightClockSkew, Passed: site.Name != "site3"}},
+			})
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+
+	ctx := context.Background()
+	res, err := adm.SRPeerPreflight(ctx, []PeerSite{{Name: "site2"}, {Name: "site3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Peers) != 2 || !res.Peers[0].Passed() || res.Peers[1].Passed() || res.Passed() {
+		t.Fatalf("unexpected preflight result %+v", res)
+	}
+
+	if _, err = adm.SRPeerPreflight(ctx, []PeerSite{{Name: "site2"}}); ToErrorResponse(err).Code != "InvalidRequest" {
+		t.Errorf("expected InvalidRequest, got %v", err)
+	}
+	if _, err = adm.SRPeerPreflight(ctx, nil); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -18,6 +18,8 @@ package madmin
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
@@ -31,7 +33,10 @@ const (
 	minIOHealingDrives         = "x-minio-healing-drives"
 	clusterCheckEndpoint       = "/minio/health/cluster"
 	clusterReadCheckEndpoint   = "/minio/health/cluster/read"
+	readinessCheckEndpoint     = "/minio/health/ready"
+	serverVersionEndpoint      = "/minio/version"
 	maintanenceURLParameterKey = "maintenance"
+	componentsURLParameterKey  = "components"
 )
 
 // HealthResult represents the cluster health result
@@ -40,12 +45,64 @@ type HealthResult struct {
 	MaintenanceMode bool
 	WriteQuorum     int
 	HealingDrives   int
+
+	// Components is only set when HealthOpts.Components is
+	// requested and the server supports per-component health.
+	Components *HealthComponents
+}
+
+// HealthComponents represents the health of the individual
+// components the cluster depends on.
+type HealthComponents struct {
+	ErasureSets []ErasureSetHealth `json:"erasureSets,omitempty"`
+	KMS         ComponentHealth    `json:"kms"`
+}
+
+// ErasureSetHealth represents the health of a single erasure set.
+type ErasureSetHealth struct {
+	PoolIndex     int  `json:"poolIndex"`
+	SetIndex      int  `json:"setIndex"`
+	Healthy       bool `json:"healthy"`
+	OnlineDrives  int  `json:"onlineDrives"`
+	HealingDrives int  `json:"healingDrives"`
+	WriteQuorum   int  `json:"writeQuorum"`
+}
+
+// ComponentHealth represents the health of an external
+// component, e.g. the KMS.
+type ComponentHealth struct {
+	Configured bool   `json:"configured"`
+	Online     bool   `json:"online"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ErasureSetsOnline returns the number of healthy erasure sets
+// and the total number of erasure sets.
+func (h HealthComponents) ErasureSetsOnline() (online, total int) {
+	for _, set := range h.ErasureSets {
+		if set.Healthy {
+			online++
+		}
+	}
+	return online, len(h.ErasureSets)
+}
+
+// HealingDrives returns the number of drives being healed
+// across all erasure sets.
+func (h HealthComponents) HealingDrives() (healing int) {
+	for _, set := range h.ErasureSets {
+		healing += set.HealingDrives
+	}
+	return healing
 }
 
 // HealthOpts represents the input options for the health check
 type HealthOpts struct {
 	ClusterRead bool
 	Maintenance bool
+	// Components requests per-component health details,
+	// only applies to the cluster write check.
+	Components bool
 }
 
 // Healthy will hit `/minio/health/cluster` and `/minio/health/cluster/ready` anonymous APIs to check the cluster health
@@ -53,14 +110,17 @@ func (an *AnonymousClient) Healthy(ctx context.Context, opts HealthOpts) (result
 	if opts.ClusterRead {
 		return an.clusterReadCheck(ctx)
 	}
-	return an.clusterCheck(ctx, opts.Maintenance)
+	return an.clusterCheck(ctx, opts.Maintenance, opts.Components)
 }
 
-func (an *AnonymousClient) clusterCheck(ctx context.Context, maintenance bool) (result HealthResult, err error) {
+func (an *AnonymousClient) clusterCheck(ctx context.Context, maintenance, components bool) (result HealthResult, err error) {
 	urlValues := make(url.Values)
 	if maintenance {
 		urlValues.Set(maintanenceURLParameterKey, "true")
 	}
+	if components {
+		urlValues.Set(componentsURLParameterKey, "true")
+	}
 
 	resp, err := an.executeMethod(ctx, http.MethodGet, requestData{
 		relPath:     clusterCheckEndpoint,
@@ -94,6 +154,20 @@ func (an *AnonymousClient) clusterCheck(ctx context.Context, maintenance bool) (
 		default:
 			// Not Healthy
 		}
+		if components {
+			var hc HealthComponents
+			switch err = json.NewDecoder(resp.Body).Decode(&hc); {
+			case err == nil:
+				result.Components = &hc
+			case err == io.EOF:
+				// Older servers do not report components.
+			case resp.StatusCode != http.StatusOK:
+				// Unhealthy servers may respond with an error
+				// body instead, the status is the result.
+			default:
+				return result, err
+			}
+		}
 	}
 	return result, nil
 }
@@ -138,7 +212,7 @@ type AliveResult struct {
 func (an *AnonymousClient) Alive(ctx context.Context, opts AliveOpts, servers ...ServerProperties) (resultsCh chan AliveResult) {
 	resource := "/minio/health/live"
 	if opts.Readiness {
-		resource = "/minio/health/ready"
+		resource = readinessCheckEndpoint
 	}
 
 	scheme := "http"
@@ -233,3 +307,40 @@ func (an *AnonymousClient) alive(ctx context.Context, u *url.URL, resource strin
 	case resultsCh <- result:
 	}
 }
+
+// Ready will hit `/minio/health/ready` to check if the server
+// is ready to serve requests.
+func (an *AnonymousClient) Ready(ctx context.Context) (bool, error) {
+	resp, err := an.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: readinessCheckEndpoint,
+	}, nil)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusOK && resp.Header.Get("x-minio-server-status") != "offline", nil
+}
+
+// ServerVersion is the version of a MinIO server.
+type ServerVersion struct {
+	Version  string `json:"version"`
+	CommitID string `json:"commitID"`
+}
+
+// ServerVersion will hit `/minio/version` to get the version of the server.
+func (an *AnonymousClient) ServerVersion(ctx context.Context) (version ServerVersion, err error) {
+	resp, err := an.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: serverVersionEndpoint,
+	}, nil)
+	defer closeResponse(resp)
+	if err != nil {
+		return version, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return version, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&version)
+	return version, err
+}
@@ -0,0 +1,139 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestAnonymousClient returns an anonymous client of a
+// test server answering all requests with handler.
+func newTestAnonymousClient(t *testing.T, handler http.HandlerFunc) *AnonymousClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	an, err := NewAnonymousClient(strings.TrimPrefix(srv.URL, "http://"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return an
+}
+
+func TestReady(t *testing.T) {
+	testCases := []struct {
+		status int
+		server string
+		ready  bool
+	}{
+		{http.StatusOK, "", true},
+		{http.StatusOK, "offline", false},
+		{http.StatusServiceUnavailable, "", false},
+	}
+	for i, tc := range testCases {
+		an := newTestAnonymousClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/minio/health/ready" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if tc.server != "" {
+				w.Header().Set("x-minio-server-status", tc.server)
+			}
+			w.WriteHeader(tc.status)
+		})
+		ready, err := an.Ready(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ready != tc.ready {
+			t.Errorf("Test %d: expected ready %t, got %t", i+1, tc.ready, ready)
+		}
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	an := newTestAnonymousClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/version" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		json.NewEncoder(w).Encode(ServerVersion{Version: "2022-01-01T00-00-00Z", CommitID: "abc123"})
+	})
+
+	version, err := an.ServerVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Version != "2022-01-01T00-00-00Z" || version.CommitID != "abc123" {
+		t.Fatalf("unexpected version %+v", version)
+	}
+
+	an = newTestAnonymousClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	if _, err = an.ServerVersion(context.Background()); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+}
+
+func TestHealthyComponents(t *testing.T) {
+	testCases := []struct {
+		status     int
+		body       string
+		healthy    bool
+		components bool
+	}{
+		{http.StatusOK, `{"erasureSets":[{"healthy":true,"onlineDrives":4},{"healingDrives":1}],"kms":{"configured":true,"online":true}}`, true, true},
+		{http.StatusOK, "", true, false}, // Older servers
+		{http.StatusServiceUnavailable, `{"erasureSets":[{"healthy":false}]}`, false, true},
+		{http.StatusServiceUnavailable, `<?xml version="1.0"?><Error><Code>ServerNotInitialized</Code></Error>`, false, false},
+	}
+	for i, tc := range testCases {
+		an := newTestAnonymousClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/minio/health/cluster" || r.URL.Query().Get("components") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("x-minio-write-quorum", "3")
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		})
+		result, err := an.Healthy(context.Background(), HealthOpts{Components: true})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if result.Healthy != tc.healthy || result.WriteQuorum != 3 || (result.Components != nil) != tc.components {
+			t.Errorf("Test %d: unexpected result %+v", i+1, result)
+		}
+	}
+
+	an := newTestAnonymousClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"erasureSets":[{"healthy":true},{"healthy":false,"healingDrives":2}]}`))
+	})
+	result, err := an.Healthy(context.Background(), HealthOpts{Components: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if online, total := result.Components.ErasureSetsOnline(); online != 1 || total != 2 || result.Components.HealingDrives() != 2 {
+		t.Errorf("unexpected components %+v", result.Components)
+	}
+}
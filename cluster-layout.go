@@ -0,0 +1,122 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"sort"
+)
+
+// ClusterLayout is the pool, erasure set and drive topology of a cluster.
+type ClusterLayout struct {
+	// Parity drives of the standard and reduced redundancy storage classes.
+	StandardSCParity int `json:"standardSCParity"`
+	RRSCParity       int `json:"rrSCParity"`
+
+	Pools []PoolLayout `json:"pools"`
+}
+
+// PoolLayout is the layout of a server pool.
+type PoolLayout struct {
+	Index        int                `json:"index"`
+	DrivesPerSet int                `json:"drivesPerSet"`
+	Sets         []ErasureSetLayout `json:"sets"`
+}
+
+// ErasureSetLayout is the layout of an erasure set
+// along with its used and free capacity.
+type ErasureSetLayout struct {
+	PoolIndex int    `json:"poolIndex"`
+	SetIndex  int    `json:"setIndex"`
+	Drives    []Disk `json:"drives"`
+
+	RawCapacity uint64 `json:"rawCapacity"`
+	RawUsage    uint64 `json:"rawUsage"`
+	RawFree     uint64 `json:"rawFree"`
+}
+
+// OnlineDrives returns the number of online drives of the set.
+func (s ErasureSetLayout) OnlineDrives() (n int) {
+	for _, drive := range s.Drives {
+		if drive.State == DriveStateOk {
+			n++
+		}
+	}
+	return n
+}
+
+// NewClusterLayout builds the cluster layout from the drives and
+// backend information of info. Drives not yet assigned to a set are
+// left out.
+func NewClusterLayout(info StorageInfo) ClusterLayout {
+	layout := ClusterLayout{
+		StandardSCParity: info.Backend.StandardSCParity,
+		RRSCParity:       info.Backend.RRSCParity,
+	}
+
+	pools := make(map[int]map[int]*ErasureSetLayout)
+	for _, drive := range info.Disks {
+		if drive.PoolIndex < 0 || drive.SetIndex < 0 {
+			continue
+		}
+		sets, ok := pools[drive.PoolIndex]
+		if !ok {
+			sets = make(map[int]*ErasureSetLayout)
+			pools[drive.PoolIndex] = sets
+		}
+		set, ok := sets[drive.SetIndex]
+		if !ok {
+			set = &ErasureSetLayout{PoolIndex: drive.PoolIndex, SetIndex: drive.SetIndex}
+			sets[drive.SetIndex] = set
+		}
+		set.Drives = append(set.Drives, drive)
+		set.RawCapacity += drive.TotalSpace
+		set.RawUsage += drive.UsedSpace
+		set.RawFree += drive.AvailableSpace
+	}
+
+	for poolIdx, sets := range pools {
+		pool := PoolLayout{Index: poolIdx}
+		if poolIdx < len(info.Backend.DrivesPerSet) {
+			pool.DrivesPerSet = info.Backend.DrivesPerSet[poolIdx]
+		}
+		for _, set := range sets {
+			sort.Slice(set.Drives, func(i, j int) bool {
+				return set.Drives[i].DiskIndex < set.Drives[j].DiskIndex
+			})
+			pool.Sets = append(pool.Sets, *set)
+		}
+		sort.Slice(pool.Sets, func(i, j int) bool {
+			return pool.Sets[i].SetIndex < pool.Sets[j].SetIndex
+		})
+		layout.Pools = append(layout.Pools, pool)
+	}
+	sort.Slice(layout.Pools, func(i, j int) bool {
+		return layout.Pools[i].Index < layout.Pools[j].Index
+	})
+	return layout
+}
+
+// ClusterLayout - returns the pool, erasure set and drive
+// topology of the cluster with per set capacity.
+func (adm *AdminClient) ClusterLayout(ctx context.Context) (ClusterLayout, error) {
+	info, err := adm.StorageInfo(ctx)
+	if err != nil {
+		return ClusterLayout{}, err
+	}
+	return NewClusterLayout(info), nil
+}
@@ -0,0 +1,51 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import "testing"
+
+func TestNewClusterLayout(t *testing.T) {
+	info := StorageInfo{
+		Disks: []Disk{
+			{PoolIndex: 1, SetIndex: 0, DiskIndex: 0, TotalSpace: 10, UsedSpace: 4, AvailableSpace: 6, State: DriveStateOk},
+			{PoolIndex: 0, SetIndex: 1, DiskIndex: 1, TotalSpace: 10, UsedSpace: 2, AvailableSpace: 8, State: DriveStateOk},
+			{PoolIndex: 0, SetIndex: 1, DiskIndex: 0, TotalSpace: 10, UsedSpace: 3, AvailableSpace: 7, State: DriveStateOffline},
+			{PoolIndex: 0, SetIndex: 0, DiskIndex: 0, TotalSpace: 10, UsedSpace: 1, AvailableSpace: 9, State: DriveStateOk},
+			{PoolIndex: -1, SetIndex: -1, DiskIndex: -1},
+		},
+		Backend: BackendInfo{StandardSCParity: 2, DrivesPerSet: []int{2, 1}},
+	}
+
+	layout := NewClusterLayout(info)
+	if layout.StandardSCParity != 2 {
+		t.Errorf("expected parity 2, got %d", layout.StandardSCParity)
+	}
+	if len(layout.Pools) != 2 || layout.Pools[0].Index != 0 || layout.Pools[1].Index != 1 {
+		t.Fatalf("unexpected pools %+v", layout.Pools)
+	}
+	pool := layout.Pools[0]
+	if pool.DrivesPerSet != 2 || len(pool.Sets) != 2 || pool.Sets[1].SetIndex != 1 {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+	set := pool.Sets[1]
+	if set.Drives[0].DiskIndex != 0 || set.RawCapacity != 20 || set.RawUsage != 5 || set.RawFree != 15 {
+		t.Errorf("unexpected set %+v", set)
+	}
+	if set.OnlineDrives() != 1 {
+		t.Errorf("expected 1 online drive, got %d", set.OnlineDrives())
+	}
+}
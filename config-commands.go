@@ -55,14 +55,11 @@ func (adm *AdminClient) SetConfig(ctx context.Context, config io.Reader) (err er
 		return err
 	}
 	configBytes := configBuf[:n]
-	econfigBytes, err := EncryptData(adm.getSecretKey(), configBytes)
-	if err != nil {
-		return err
-	}
 
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/config",
-		content: econfigBytes,
+		content: configBytes,
+		encrypt: true,
 	}
 
 	// Execute PUT on /minio/admin/v3/config to set config.
@@ -18,20 +18,19 @@ package madmin
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // DelConfigKV - delete key from server config.
 func (adm *AdminClient) DelConfigKV(ctx context.Context, k string) (restart bool, err error) {
-	econfigBytes, err := EncryptData(adm.getSecretKey(), []byte(k))
-	if err != nil {
-		return false, err
-	}
-
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/del-config-kv",
-		content: econfigBytes,
+		content: []byte(k),
+		encrypt: true,
 	}
 
 	// Execute DELETE on /minio/admin/v3/del-config-kv to delete config key.
@@ -59,14 +58,10 @@ const (
 
 // SetConfigKV - set key value config to server.
 func (adm *AdminClient) SetConfigKV(ctx context.Context, kv string) (restart bool, err error) {
-	econfigBytes, err := EncryptData(adm.getSecretKey(), []byte(kv))
-	if err != nil {
-		return false, err
-	}
-
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/set-config-kv",
-		content: econfigBytes,
+		content: []byte(kv),
+		encrypt: true,
 	}
 
 	// Execute PUT on /minio/admin/v3/set-config-kv to set config key/value.
@@ -141,3 +136,77 @@ func (adm *AdminClient) GetConfigKVWithOptions(ctx context.Context, key string,
 
 	return DecryptData(adm.getSecretKey(), resp.Body)
 }
+
+// ConfigChangeEvent - describes a single change of the server config,
+// values are not included as they may contain secrets.
+type ConfigChangeEvent struct {
+	SubSystem string    `json:"subSystem"`
+	Target    string    `json:"target,omitempty"`
+	Keys      []string  `json:"keys,omitempty"` // Keys set or deleted, empty if the whole target changed.
+	Deleted   bool      `json:"deleted,omitempty"`
+	RestoreID string    `json:"restoreId,omitempty"` // Config history entry of the change.
+	Time      time.Time `json:"time"`
+	Err       error     `json:"-"`
+}
+
+// WatchConfig - listens for changes of the server config of subSys, all
+// subsystems are watched when subSys is empty. Events are sent on the
+// returned channel as soon as the config is changed by any client, the
+// channel is closed when ctx is canceled or the connection is lost.
+func (adm *AdminClient) WatchConfig(ctx context.Context, subSys string) <-chan ConfigChangeEvent {
+	eventCh := make(chan ConfigChangeEvent)
+
+	go func(eventCh chan<- ConfigChangeEvent) {
+		defer close(eventCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case eventCh <- ConfigChangeEvent{Err: err}:
+			}
+		}
+
+		v := url.Values{}
+		if subSys != "" {
+			v.Set("subSys", subSys)
+		}
+
+		// Execute GET on /minio/admin/v3/watch-config-kv?subSys={subSys}
+		resp, err := adm.executeMethod(ctx,
+			http.MethodGet,
+			requestData{
+				relPath:     adminAPIPrefix + "/watch-config-kv",
+				queryValues: v,
+				category:    RequestCategoryStreaming,
+			})
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var event ConfigChangeEvent
+			if err = dec.Decode(&event); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+		}
+	}(eventCh)
+
+	// Returns the event channel, for caller to start reading from.
+	return eventCh
+}
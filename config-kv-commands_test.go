@@ -0,0 +1,53 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestWatchConfig(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/watch-config-kv" || r.URL.Query().Get("subSys") != "api" {
+			writeTestError(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(ConfigChangeEvent{SubSystem: "api", Keys: []string{"requests_max"}, RestoreID: "r1"})
+		enc.Encode(ConfigChangeEvent{SubSystem: "api", Deleted: true})
+	})
+
+	var events []ConfigChangeEvent
+	for event := range adm.WatchConfig(context.Background(), "api") {
+		if event.Err != nil {
+			t.Fatal(event.Err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 || events[0].Keys[0] != "requests_max" || events[0].RestoreID != "r1" || !events[1].Deleted {
+		t.Fatalf("unexpected events %+v", events)
+	}
+
+	for event := range adm.WatchConfig(context.Background(), "") {
+		if ToErrorResponse(event.Err).Code != "AccessDenied" {
+			t.Fatalf("expected AccessDenied, got %v", event.Err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config implements typed accessors for the MinIO server
+// configuration subsystems on top of the generic key=value config API.
+//
+// Every subsystem is described by a struct whose fields are tagged with
+// the server config keys as listed by HelpConfigKV, CheckSchema verifies
+// that the tagged keys are still known to the server.
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// kvTag is the struct tag holding the server config key of a field.
+const kvTag = "kv"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Client provides typed access to the server configuration.
+type Client struct {
+	adm *madmin.AdminClient
+}
+
+// New returns a new typed config client using adm.
+func New(adm *madmin.AdminClient) *Client {
+	return &Client{adm: adm}
+}
+
+// get - reads the default target of subSys from the server into v,
+// which must be a pointer to a struct with kv tagged fields.
+func (c *Client) get(ctx context.Context, subSys string, v interface{}) error {
+	buf, err := c.adm.GetConfigKV(ctx, subSys)
+	if err != nil {
+		return err
+	}
+
+	cfgs, err := madmin.ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.SubSystem != subSys || cfg.Target != "" {
+			continue
+		}
+		return decodeKVs(cfg, v)
+	}
+	return fmt.Errorf("config subsystem %s not found", subSys)
+}
+
+// set - writes all kv tagged fields of v as the default target of subSys.
+func (c *Client) set(ctx context.Context, subSys string, v interface{}) (restart bool, err error) {
+	kvs, err := encodeKVs(v)
+	if err != nil {
+		return false, err
+	}
+	return c.adm.SetConfigKV(ctx, subSys+madmin.KvSpaceSeparator+kvs)
+}
+
+// decodeKVs - sets the kv tagged fields of the struct pointed to by
+// v from the effective values in cfg, missing keys are left untouched.
+func decodeKVs(cfg madmin.SubsysConfig, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		key := rt.Field(i).Tag.Get(kvTag)
+		if key == "" {
+			continue
+		}
+		val, ok := cfg.Lookup(key)
+		if !ok {
+			continue
+		}
+		if err := decodeValue(rv.Field(i), madmin.SanitizeValue(val)); err != nil {
+			return fmt.Errorf("invalid value %q for %s %s: %w", val, cfg.SubSystem, key, err)
+		}
+	}
+	return nil
+}
+
+func decodeValue(f reflect.Value, val string) error {
+	if f.Type() == durationType {
+		if val == "" {
+			f.SetInt(0)
+			return nil
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(val)
+	case reflect.Bool:
+		switch val {
+		case madmin.EnableOn:
+			f.SetBool(true)
+		case madmin.EnableOff, "":
+			f.SetBool(false)
+		default:
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return err
+			}
+			f.SetBool(b)
+		}
+	case reflect.Int, reflect.Int64:
+		if val == "" {
+			f.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint64:
+		if val == "" {
+			f.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float64:
+		if val == "" {
+			f.SetFloat(0)
+			return nil
+		}
+		n, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	case reflect.Slice:
+		var elems []string
+		if val != "" {
+			elems = strings.Split(val, ",")
+		}
+		f.Set(reflect.ValueOf(elems))
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
+
+// encodeKVs - returns the kv tagged fields of the struct pointed
+// to by v as space separated key=value pairs.
+func encodeKVs(v interface{}) (string, error) {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+
+	var kvs []string
+	for i := 0; i < rt.NumField(); i++ {
+		key := rt.Field(i).Tag.Get(kvTag)
+		if key == "" {
+			continue
+		}
+		val, err := encodeValue(rv.Field(i))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		if val == "" || madmin.HasSpace(val) {
+			val = madmin.KvDoubleQuote + val + madmin.KvDoubleQuote
+		}
+		kvs = append(kvs, key+madmin.KvSeparator+val)
+	}
+	return strings.Join(kvs, madmin.KvSpaceSeparator), nil
+}
+
+func encodeValue(f reflect.Value) (string, error) {
+	if f.Type() == durationType {
+		return time.Duration(f.Int()).String(), nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		return f.String(), nil
+	case reflect.Bool:
+		if f.Bool() {
+			return madmin.EnableOn, nil
+		}
+		return madmin.EnableOff, nil
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(f.Int(), 10), nil
+	case reflect.Uint64:
+		return strconv.FormatUint(f.Uint(), 10), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(f.Float(), 'f', -1, 64), nil
+	case reflect.Slice:
+		return strings.Join(f.Interface().([]string), ","), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", f.Type())
+	}
+}
+
+// CheckSchema - verifies that every typed config key is still listed
+// in the HelpConfigKV schema of the server, returning an error naming
+// the unknown keys otherwise.
+func (c *Client) CheckSchema(ctx context.Context) error {
+	var unknown []string
+	for _, s := range subsystems {
+		help, err := c.adm.HelpConfigKV(ctx, s.subSys, "", false)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]bool, len(help.KeysHelp))
+		for _, k := range help.Keys() {
+			known[k] = true
+		}
+
+		rt := reflect.TypeOf(s.config)
+		for i := 0; i < rt.NumField(); i++ {
+			key := rt.Field(i).Tag.Get(kvTag)
+			if key != "" && !known[key] {
+				unknown = append(unknown, s.subSys+":"+key)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("config keys unknown to the server: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
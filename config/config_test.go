@@ -0,0 +1,83 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestEncodeDecodeKVs(t *testing.T) {
+	testCases := []struct {
+		subSys string
+		in     interface{}
+		out    interface{}
+		kvs    string
+	}{
+		{
+			subSys: madmin.CompressionSubSys,
+			in: &CompressionConfig{
+				Enable:     true,
+				Extensions: []string{".txt", ".log"},
+				MimeTypes:  []string{"text/*"},
+			},
+			out: &CompressionConfig{},
+			kvs: `enable=on allow_encryption=off extensions=.txt,.log mime_types=text/*`,
+		},
+		{
+			subSys: madmin.ScannerSubSys,
+			in: &ScannerConfig{
+				Speed:   "default",
+				Delay:   2.5,
+				MaxWait: 15 * time.Second,
+				Cycle:   time.Minute,
+			},
+			out: &ScannerConfig{},
+			kvs: `speed=default delay=2.5 max_wait=15s cycle=1m0s`,
+		},
+		{
+			subSys: madmin.SiteSubSys,
+			in:     &SiteConfig{Name: "dc 1"},
+			out:    &SiteConfig{},
+			kvs:    `name="dc 1" region=""`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		kvs, err := encodeKVs(testCase.in)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if kvs != testCase.kvs {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.kvs, kvs)
+		}
+
+		cfgs, err := madmin.ParseServerConfigOutput(testCase.subSys + " " + kvs)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err = decodeKVs(cfgs[0], testCase.out); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(testCase.in, testCase.out) {
+			t.Fatalf("Test %d: expected %#v, got %#v", i+1, testCase.in, testCase.out)
+		}
+	}
+}
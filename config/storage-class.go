@@ -0,0 +1,100 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+)
+
+const storageClassParityPrefix = "EC:"
+
+// StorageClassParity returns the storage class value of parity, e.g. "EC:4".
+func StorageClassParity(parity int) string {
+	return storageClassParityPrefix + strconv.Itoa(parity)
+}
+
+// Parity returns the parity of the standard and reduced
+// redundancy storage classes, zero if not set.
+func (c StorageClassConfig) Parity() (standard, rrs int, err error) {
+	if standard, err = parseStorageClassParity(c.Standard); err != nil {
+		return 0, 0, err
+	}
+	if rrs, err = parseStorageClassParity(c.RRS); err != nil {
+		return 0, 0, err
+	}
+	return standard, rrs, nil
+}
+
+func parseStorageClassParity(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(v, storageClassParityPrefix) {
+		return 0, fmt.Errorf("invalid storage class %q", v)
+	}
+	parity, err := strconv.Atoi(strings.TrimPrefix(v, storageClassParityPrefix))
+	if err != nil || parity < 0 {
+		return 0, fmt.Errorf("invalid storage class %q", v)
+	}
+	return parity, nil
+}
+
+// Validate checks the parity settings against the erasure backend of the
+// cluster. When the standard parity is not set, the reduced redundancy
+// parity is checked against the current standard parity of the backend.
+func (c StorageClassConfig) Validate(backend madmin.BackendInfo) error {
+	standard, rrs, err := c.Parity()
+	if err != nil {
+		return err
+	}
+	effective := standard
+	if effective == 0 {
+		effective = backend.StandardSCParity
+	}
+	if rrs > 0 && effective > 0 && rrs > effective {
+		return madmin.ErrInvalidArgument(fmt.Sprintf("reduced redundancy parity %d must not exceed standard parity %d", rrs, effective))
+	}
+	for pool, drives := range backend.DrivesPerSet {
+		if max := drives / 2; standard > max || rrs > max {
+			return madmin.ErrInvalidArgument(fmt.Sprintf("parity must not exceed %d for the %d drives per set of pool %d", max, drives, pool))
+		}
+	}
+	return nil
+}
+
+// EstimateStorageClassImpact - estimates the usable capacity of the cluster
+// before and after applying cfg. The new parity only applies to objects
+// written after the change.
+func (c *Client) EstimateStorageClassImpact(ctx context.Context, cfg StorageClassConfig) (madmin.StorageClassImpact, error) {
+	standard, _, err := cfg.Parity()
+	if err != nil {
+		return madmin.StorageClassImpact{}, err
+	}
+	info, err := c.adm.StorageInfo(ctx)
+	if err != nil {
+		return madmin.StorageClassImpact{}, err
+	}
+	if standard == 0 {
+		standard = info.Backend.StandardSCParity
+	}
+	return madmin.EstimateStorageClassImpact(madmin.NewClusterLayout(info), info.Backend.StandardSCParity, standard), nil
+}
@@ -0,0 +1,53 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestStorageClassConfigValidate(t *testing.T) {
+	testCases := []struct {
+		cfg     StorageClassConfig
+		backend madmin.BackendInfo
+		success bool
+	}{
+		{StorageClassConfig{Standard: "EC:4", RRS: "EC:2"}, madmin.BackendInfo{DrivesPerSet: []int{16, 8}}, true},
+		{StorageClassConfig{Standard: "EC:5"}, madmin.BackendInfo{DrivesPerSet: []int{16, 8}}, false},
+		{StorageClassConfig{Standard: "EC:2", RRS: "EC:3"}, madmin.BackendInfo{DrivesPerSet: []int{16}}, false},
+		{StorageClassConfig{RRS: "EC:1"}, madmin.BackendInfo{DrivesPerSet: []int{4}, StandardSCParity: 2}, true},
+		// The reduced redundancy parity is checked against the current standard parity.
+		{StorageClassConfig{RRS: "EC:3"}, madmin.BackendInfo{DrivesPerSet: []int{8}, StandardSCParity: 2}, false},
+		{StorageClassConfig{Standard: "EC:-1"}, madmin.BackendInfo{DrivesPerSet: []int{4}}, false},
+		{StorageClassConfig{Standard: "4"}, madmin.BackendInfo{DrivesPerSet: []int{4}}, false},
+	}
+	for i, tc := range testCases {
+		if err := tc.cfg.Validate(tc.backend); (err == nil) != tc.success {
+			t.Errorf("case %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestStorageClassParity(t *testing.T) {
+	cfg := StorageClassConfig{Standard: StorageClassParity(4)}
+	standard, rrs, err := cfg.Parity()
+	if err != nil || standard != 4 || rrs != 0 {
+		t.Fatalf("unexpected parity %d, %d, %v", standard, rrs, err)
+	}
+}
@@ -0,0 +1,164 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// subsystems lists the typed subsystems verified by CheckSchema.
+var subsystems = []struct {
+	subSys string
+	config interface{}
+}{
+	{madmin.APISubSys, APIConfig{}},
+	{madmin.CompressionSubSys, CompressionConfig{}},
+	{madmin.HealSubSys, HealConfig{}},
+	{madmin.ScannerSubSys, ScannerConfig{}},
+	{madmin.SiteSubSys, SiteConfig{}},
+	{madmin.StorageClassSubSys, StorageClassConfig{}},
+}
+
+// APIConfig - typed configuration of the api subsystem.
+type APIConfig struct {
+	RequestsMax                 int           `kv:"requests_max"`
+	RequestsDeadline            time.Duration `kv:"requests_deadline"`
+	ClusterDeadline             time.Duration `kv:"cluster_deadline"`
+	CorsAllowOrigin             []string      `kv:"cors_allow_origin"`
+	RemoteTransportDeadline     time.Duration `kv:"remote_transport_deadline"`
+	ListQuorum                  string        `kv:"list_quorum"`
+	ReplicationWorkers          int           `kv:"replication_workers"`
+	ReplicationFailedWorkers    int           `kv:"replication_failed_workers"`
+	TransitionWorkers           int           `kv:"transition_workers"`
+	StaleUploadsCleanupInterval time.Duration `kv:"stale_uploads_cleanup_interval"`
+	StaleUploadsExpiry          time.Duration `kv:"stale_uploads_expiry"`
+	DeleteCleanupInterval       time.Duration `kv:"delete_cleanup_interval"`
+}
+
+// GetAPIConfig - returns the api subsystem configuration.
+func (c *Client) GetAPIConfig(ctx context.Context) (cfg APIConfig, err error) {
+	err = c.get(ctx, madmin.APISubSys, &cfg)
+	return cfg, err
+}
+
+// SetAPIConfig - sets the api subsystem configuration.
+func (c *Client) SetAPIConfig(ctx context.Context, cfg APIConfig) (restart bool, err error) {
+	return c.set(ctx, madmin.APISubSys, &cfg)
+}
+
+// CompressionConfig - typed configuration of the compression subsystem.
+type CompressionConfig struct {
+	Enable          bool     `kv:"enable"`
+	AllowEncryption bool     `kv:"allow_encryption"`
+	Extensions      []string `kv:"extensions"`
+	MimeTypes       []string `kv:"mime_types"`
+}
+
+// GetCompressionConfig - returns the compression subsystem configuration.
+func (c *Client) GetCompressionConfig(ctx context.Context) (cfg CompressionConfig, err error) {
+	err = c.get(ctx, madmin.CompressionSubSys, &cfg)
+	return cfg, err
+}
+
+// SetCompressionConfig - sets the compression subsystem configuration.
+func (c *Client) SetCompressionConfig(ctx context.Context, cfg CompressionConfig) (restart bool, err error) {
+	return c.set(ctx, madmin.CompressionSubSys, &cfg)
+}
+
+// HealConfig - typed configuration of the heal subsystem.
+type HealConfig struct {
+	Bitrotscan   string        `kv:"bitrotscan"` // "on", "off" or the scan cycle, e.g. "12m"
+	MaxSleep     time.Duration `kv:"max_sleep"`
+	MaxIO        int           `kv:"max_io"`
+	DriveWorkers string        `kv:"drive_workers"` // Healing workers per drive, empty for the server default
+}
+
+// GetHealConfig - returns the heal subsystem configuration.
+func (c *Client) GetHealConfig(ctx context.Context) (cfg HealConfig, err error) {
+	err = c.get(ctx, madmin.HealSubSys, &cfg)
+	return cfg, err
+}
+
+// SetHealConfig - sets the heal subsystem configuration.
+func (c *Client) SetHealConfig(ctx context.Context, cfg HealConfig) (restart bool, err error) {
+	return c.set(ctx, madmin.HealSubSys, &cfg)
+}
+
+// ScannerConfig - typed configuration of the scanner subsystem.
+type ScannerConfig struct {
+	Speed   string        `kv:"speed"`
+	Delay   float64       `kv:"delay"`
+	MaxWait time.Duration `kv:"max_wait"`
+	Cycle   time.Duration `kv:"cycle"`
+}
+
+// GetScannerConfig - returns the scanner subsystem configuration.
+func (c *Client) GetScannerConfig(ctx context.Context) (cfg ScannerConfig, err error) {
+	err = c.get(ctx, madmin.ScannerSubSys, &cfg)
+	return cfg, err
+}
+
+// SetScannerConfig - sets the scanner subsystem configuration.
+func (c *Client) SetScannerConfig(ctx context.Context, cfg ScannerConfig) (restart bool, err error) {
+	return c.set(ctx, madmin.ScannerSubSys, &cfg)
+}
+
+// SiteConfig - typed configuration of the site subsystem.
+type SiteConfig struct {
+	Name   string `kv:"name"`
+	Region string `kv:"region"`
+}
+
+// GetSiteConfig - returns the site subsystem configuration.
+func (c *Client) GetSiteConfig(ctx context.Context) (cfg SiteConfig, err error) {
+	err = c.get(ctx, madmin.SiteSubSys, &cfg)
+	return cfg, err
+}
+
+// SetSiteConfig - sets the site subsystem configuration.
+func (c *Client) SetSiteConfig(ctx context.Context, cfg SiteConfig) (restart bool, err error) {
+	return c.set(ctx, madmin.SiteSubSys, &cfg)
+}
+
+// StorageClassConfig - typed configuration of the storage_class subsystem,
+// the values are parities like "EC:4", see StorageClassParity.
+type StorageClassConfig struct {
+	Standard string `kv:"standard"`
+	RRS      string `kv:"rrs"`
+}
+
+// GetStorageClassConfig - returns the storage_class subsystem configuration.
+func (c *Client) GetStorageClassConfig(ctx context.Context) (cfg StorageClassConfig, err error) {
+	err = c.get(ctx, madmin.StorageClassSubSys, &cfg)
+	return cfg, err
+}
+
+// SetStorageClassConfig - validates the storage_class subsystem configuration
+// against the erasure backend of the cluster and sets it.
+func (c *Client) SetStorageClassConfig(ctx context.Context, cfg StorageClassConfig) (restart bool, err error) {
+	info, err := c.adm.StorageInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err = cfg.Validate(info.Backend); err != nil {
+		return false, err
+	}
+	return c.set(ctx, madmin.StorageClassSubSys, &cfg)
+}
@@ -0,0 +1,79 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// DriveAdminState is the administrative state requested for a drive.
+type DriveAdminState string
+
+const (
+	// DriveAdminStateOffline takes the drive offline, requests are no
+	// longer sent to it so it can be safely removed.
+	DriveAdminStateOffline DriveAdminState = "offline"
+	// DriveAdminStateOnline brings a drive taken offline back online.
+	DriveAdminStateOnline DriveAdminState = "online"
+	// DriveAdminStateFormat formats a replaced drive and starts healing
+	// it with the data of its erasure set.
+	DriveAdminStateFormat DriveAdminState = "format"
+)
+
+// IsValid returns true if the drive state is supported.
+func (s DriveAdminState) IsValid() bool {
+	switch s {
+	case DriveAdminStateOffline, DriveAdminStateOnline, DriveAdminStateFormat:
+		return true
+	}
+	return false
+}
+
+// SetDriveState - sets the administrative state of the drive at path drive
+// of the server endpoint, for instance to hot-swap a failed drive by taking
+// it offline, replacing it and formatting the new one.
+func (adm *AdminClient) SetDriveState(ctx context.Context, endpoint, drive string, state DriveAdminState) error {
+	if endpoint == "" || drive == "" {
+		return ErrInvalidArgument("endpoint and drive must be set")
+	}
+	if !state.IsValid() {
+		return ErrInvalidArgument("invalid drive state " + string(state))
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("endpoint", endpoint)
+	queryValues.Set("drive", drive)
+	queryValues.Set("state", string(state))
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/drive/state",
+			queryValues: queryValues,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSetDriveState(t *testing.T) {
+	var state string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/drive/state" || q.Get("endpoint") != "node1:9000" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("drive") != "/data1" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminDriveNotFound")
+			return
+		}
+		state = q.Get("state")
+	})
+
+	ctx := context.Background()
+	if err := adm.SetDriveState(ctx, "node1:9000", "/data1", DriveAdminStateOffline); err != nil {
+		t.Fatal(err)
+	}
+	if state != "offline" {
+		t.Fatalf("expected state offline, got %q", state)
+	}
+
+	if err := adm.SetDriveState(ctx, "node1:9000", "/data9", DriveAdminStateFormat); ToErrorResponse(err).Code != "XMinioAdminDriveNotFound" {
+		t.Errorf("expected XMinioAdminDriveNotFound, got %v", err)
+	}
+	if err := adm.SetDriveState(ctx, "node1:9000", "/data1", DriveAdminState("removed")); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,450 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/secure-io/sio-go"
+	"github.com/secure-io/sio-go/sioutil"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// RecipientPublicKey is the X25519 public key of
+// a recipient of data encrypted with EncryptStream.
+type RecipientPublicKey [32]byte
+
+// RecipientPrivateKey is the X25519 private key decrypting
+// data encrypted for its RecipientPublicKey.
+type RecipientPrivateKey [32]byte
+
+// GenerateRecipientKey generates a new random recipient private key.
+func GenerateRecipientKey() (key RecipientPrivateKey, err error) {
+	_, err = io.ReadFull(rand.Reader, key[:])
+	return key, err
+}
+
+// Public returns the public key of the private key.
+func (k RecipientPrivateKey) Public() (pub RecipientPublicKey, err error) {
+	b, err := curve25519.X25519(k[:], curve25519.Basepoint)
+	if err != nil {
+		return pub, err
+	}
+	copy(pub[:], b)
+	return pub, nil
+}
+
+// RecipientIdentity decrypts streams encrypted for its public key.
+// RecipientPrivateKey implements it with a key held in memory, other
+// implementations may keep the private key in a hardware token or
+// HSM that supports X25519 key agreement, e.g. through PKCS#11.
+type RecipientIdentity interface {
+	// Public returns the public key of the identity.
+	Public() (RecipientPublicKey, error)
+	// SharedSecret returns the X25519 shared secret of the private
+	// key of the identity and the peer public key.
+	SharedSecret(peer RecipientPublicKey) ([]byte, error)
+}
+
+// SharedSecret returns the X25519 shared secret of k and peer.
+func (k RecipientPrivateKey) SharedSecret(peer RecipientPublicKey) ([]byte, error) {
+	return curve25519.X25519(k[:], peer[:])
+}
+
+// RSARecipientIdentity is the RecipientIdentity of an RSA private key,
+// e.g. held in an HSM or a hardware token like a YubiKey and accessed
+// through PKCS#11, which only exposes a crypto.Decrypter. It decrypts
+// streams encrypted for its public key with EncryptStreamForRSA, the
+// data key is decrypted with RSA-OAEP SHA-256.
+type RSARecipientIdentity struct {
+	decrypter   crypto.Decrypter
+	fingerprint [32]byte
+}
+
+// NewRSARecipientIdentity returns the identity of the RSA private key
+// of decrypter, e.g. an *rsa.PrivateKey or a PKCS#11 key.
+func NewRSARecipientIdentity(decrypter crypto.Decrypter) (RSARecipientIdentity, error) {
+	pub, ok := decrypter.Public().(*rsa.PublicKey)
+	if !ok {
+		return RSARecipientIdentity{}, ErrInvalidArgument("private key is not an RSA key")
+	}
+	fingerprint, err := rsaRecipientFingerprint(pub)
+	if err != nil {
+		return RSARecipientIdentity{}, err
+	}
+	return RSARecipientIdentity{decrypter: decrypter, fingerprint: fingerprint}, nil
+}
+
+var errRSAIdentity = errors.New("madmin: RSA identities do not support X25519 key agreement")
+
+// Public returns an error, RSA identities have no X25519 public key.
+func (RSARecipientIdentity) Public() (RecipientPublicKey, error) {
+	return RecipientPublicKey{}, errRSAIdentity
+}
+
+// SharedSecret returns an error, RSA identities do not
+// support X25519 key agreement.
+func (RSARecipientIdentity) SharedSecret(RecipientPublicKey) ([]byte, error) {
+	return nil, errRSAIdentity
+}
+
+// unwrapKey decrypts the data key wrapped for the identity,
+// it returns nil if none of the keys is wrapped for it.
+func (id RSARecipientIdentity) unwrapKey(keys []rsaWrappedKey) []byte {
+	for _, key := range keys {
+		if key.fingerprint != id.fingerprint {
+			continue
+		}
+		dataKey, err := id.decrypter.Decrypt(rand.Reader, key.ciphertext, &rsa.OAEPOptions{Hash: crypto.SHA256})
+		if err == nil && len(dataKey) == 32 {
+			return dataKey
+		}
+	}
+	return nil
+}
+
+// rsaWrappedKey is a data key encrypted for an RSA recipient,
+// identified by the SHA-256 fingerprint of its public key.
+type rsaWrappedKey struct {
+	fingerprint [32]byte
+	ciphertext  []byte
+}
+
+// rsaRecipientFingerprint returns the SHA-256 hash
+// of the PKIX encoding of the public key.
+func rsaRecipientFingerprint(pub *rsa.PublicKey) (fingerprint [32]byte, err error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return fingerprint, err
+	}
+	return sha256.Sum256(der), nil
+}
+
+// ErrNotRecipient indicates that the data was not
+// encrypted for the provided private key.
+var ErrNotRecipient = errors.New("madmin: data is not encrypted for this key")
+
+const (
+	// multiRecipientV1 is the version of the multi
+	// recipient format, distinct from the AEAD IDs.
+	multiRecipientV1 = 0x10
+	// multiRecipientV2 adds RSA recipients to the
+	// X25519 recipients of multiRecipientV1.
+	multiRecipientV2 = 0x11
+
+	recipientAESGCM           = 0x00
+	recipientChaCha20Poly1305 = 0x01
+
+	maxRecipients = 255
+	wrappedKeyLen = 32 + 16 // data key | GCM tag
+)
+
+// EncryptStream returns a writer encrypting everything written to it
+// to w, so that any of the recipients can decrypt it with DecryptStream.
+// The writer must be closed to complete the encrypted stream, which
+// also closes w if it implements io.Closer.
+//
+// The encrypted stream consists of:
+//
+//	version | AEAD ID | ephemeral key | #recipients | wrapped keys | nonce | encrypted data
+//	   1         1           32              1          n * 48        8     ~ len(data)
+func EncryptStream(w io.Writer, recipients ...RecipientPublicKey) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, ErrInvalidArgument("between 1 and 255 recipients are required")
+	}
+	return EncryptStreamForRSA(w, recipients)
+}
+
+// EncryptStreamForRSA is like EncryptStream but the data key is also
+// encrypted with RSA-OAEP SHA-256 for rsaRecipients, so that keys held
+// in an HSM or hardware token can decrypt it using RSARecipientIdentity.
+//
+// With RSA recipients, the encrypted stream consists of:
+//
+//	version | AEAD ID | ephemeral key | #recipients | wrapped keys | #RSA recipients | RSA wrapped keys | nonce | encrypted data
+//	   1         1           32              1          n * 48              1           m * (32 + 2 + k)    8     ~ len(data)
+//
+// where each RSA wrapped key is the SHA-256 fingerprint of the public key,
+// the big endian length of the encrypted data key and the encrypted data key.
+func EncryptStreamForRSA(w io.Writer, recipients []RecipientPublicKey, rsaRecipients ...*rsa.PublicKey) (io.WriteCloser, error) {
+	if len(recipients)+len(rsaRecipients) == 0 || len(recipients) > maxRecipients || len(rsaRecipients) > maxRecipients {
+		return nil, ErrInvalidArgument("between 1 and 255 recipients are required")
+	}
+
+	var ephemeral RecipientPrivateKey
+	defer Wipe(ephemeral[:])
+	if _, err := io.ReadFull(rand.Reader, ephemeral[:]); err != nil {
+		return nil, err
+	}
+	ephemeralPub, err := ephemeral.Public()
+	if err != nil {
+		return nil, err
+	}
+
+	id, algorithm := byte(recipientAESGCM), sio.AES_256_GCM
+	if !FIPSEnabled() && !sioutil.NativeAES() {
+		id, algorithm = recipientChaCha20Poly1305, sio.ChaCha20Poly1305
+	}
+
+	dataKey := sioutil.MustRandom(32)
+	defer Wipe(dataKey)
+	version := byte(multiRecipientV1)
+	if len(rsaRecipients) > 0 {
+		version = multiRecipientV2
+	}
+	header := bytes.NewBuffer(make([]byte, 0, 2+32+1+len(recipients)*wrappedKeyLen+8))
+	header.WriteByte(version)
+	header.WriteByte(id)
+	header.Write(ephemeralPub[:])
+	header.WriteByte(byte(len(recipients)))
+	for _, recipient := range recipients {
+		secret, err := ephemeral.SharedSecret(recipient)
+		if err != nil {
+			return nil, err
+		}
+		kek, err := recipientKEK(secret, ephemeralPub, recipient)
+		if err != nil {
+			return nil, err
+		}
+		header.Write(kek.Seal(nil, make([]byte, kek.NonceSize()), dataKey, ephemeralPub[:]))
+	}
+	if version == multiRecipientV2 {
+		header.WriteByte(byte(len(rsaRecipients)))
+		for _, recipient := range rsaRecipients {
+			fingerprint, err := rsaRecipientFingerprint(recipient)
+			if err != nil {
+				return nil, err
+			}
+			wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, recipient, dataKey, nil)
+			if err != nil {
+				return nil, err
+			}
+			var size [2]byte
+			binary.BigEndian.PutUint16(size[:], uint16(len(wrapped)))
+			header.Write(fingerprint[:])
+			header.Write(size[:])
+			header.Write(wrapped)
+		}
+	}
+
+	stream, err := algorithm.Stream(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	header.Write(sioutil.MustRandom(stream.NonceSize()))
+	if _, err = w.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+
+	// The header is authenticated as associated data, so
+	// recipients cannot be added or removed undetected.
+	nonce := header.Bytes()[header.Len()-stream.NonceSize():]
+	return stream.EncryptWriter(w, nonce, header.Bytes()), nil
+}
+
+// DecryptStream returns a reader decrypting the stream r encrypted with
+// EncryptStream for the public key of the identity key. It returns ErrNotRecipient if
+// key is not one of the recipients. Reading returns ErrMaliciousData if
+// the stream was modified.
+func DecryptStream(r io.Reader, key RecipientIdentity) (io.Reader, error) {
+	stream, header, err := openRecipientStream(r, key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := header[len(header)-stream.NonceSize():]
+	return stream.DecryptReader(r, nonce, header), nil
+}
+
+// DecryptStreamAt is like DecryptStream but provides random access to
+// the plaintext of the size bytes of r. Reading from an offset only
+// fetches and decrypts the chunks containing it, so partially
+// downloaded streams can be resumed without reading them again.
+func DecryptStreamAt(r io.ReaderAt, size int64, key RecipientIdentity) (*io.SectionReader, error) {
+	stream, header, err := openRecipientStream(io.NewSectionReader(r, 0, size), key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := header[len(header)-stream.NonceSize():]
+	ciphertext := io.NewSectionReader(r, int64(len(header)), size-int64(len(header)))
+	plaintext := stream.DecryptReaderAt(ciphertext, nonce, header)
+	return io.NewSectionReader(plaintext, 0, recipientPlaintextSize(ciphertext.Size())), nil
+}
+
+// recipientPlaintextSize returns the plaintext size of size
+// bytes of data encrypted with a sio stream.
+func recipientPlaintextSize(size int64) int64 {
+	const chunk = sio.BufSize + 16 // plaintext | tag
+	plaintext := (size / chunk) * sio.BufSize
+	if rem := size % chunk; rem > 16 {
+		plaintext += rem - 16
+	}
+	return plaintext
+}
+
+// openRecipientStream reads the header of a stream encrypted with
+// EncryptStream or EncryptStreamForRSA and returns the decryption
+// stream for key and the header, which is authenticated as associated data.
+func openRecipientStream(r io.Reader, key RecipientIdentity) (*sio.Stream, []byte, error) {
+	var header []byte
+	read := func(n int) ([]byte, error) {
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		header = append(header, buf...)
+		return buf, nil
+	}
+
+	prefix, err := read(2 + 32 + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	version := prefix[0]
+	if version != multiRecipientV1 && version != multiRecipientV2 {
+		return nil, nil, errors.New("madmin: invalid multi recipient format version")
+	}
+
+	var algorithm sio.Algorithm
+	switch prefix[1] {
+	case recipientAESGCM:
+		algorithm = sio.AES_256_GCM
+	case recipientChaCha20Poly1305:
+		algorithm = sio.ChaCha20Poly1305
+	default:
+		return nil, nil, errors.New("madmin: invalid encryption algorithm ID")
+	}
+	var ephemeralPub RecipientPublicKey
+	copy(ephemeralPub[:], prefix[2:34])
+	n := int(prefix[34])
+
+	wrappedKeys, err := read(n * wrappedKeyLen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rsaKeys []rsaWrappedKey
+	if version == multiRecipientV2 {
+		m, err := read(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := 0; i < int(m[0]); i++ {
+			b, err := read(32 + 2)
+			if err != nil {
+				return nil, nil, err
+			}
+			var key rsaWrappedKey
+			copy(key.fingerprint[:], b)
+			if key.ciphertext, err = read(int(binary.BigEndian.Uint16(b[32:]))); err != nil {
+				return nil, nil, err
+			}
+			rsaKeys = append(rsaKeys, key)
+		}
+	}
+
+	if _, err = read(8); err != nil { // nonce
+		return nil, nil, err
+	}
+
+	var dataKey []byte
+	if id, ok := key.(RSARecipientIdentity); ok {
+		dataKey = id.unwrapKey(rsaKeys)
+	} else if n > 0 {
+		pub, err := key.Public()
+		if err != nil {
+			return nil, nil, err
+		}
+		secret, err := key.SharedSecret(ephemeralPub)
+		if err != nil {
+			return nil, nil, err
+		}
+		kek, err := recipientKEK(secret, ephemeralPub, pub)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := 0; i < n && dataKey == nil; i++ {
+			wrapped := wrappedKeys[i*wrappedKeyLen : (i+1)*wrappedKeyLen]
+			dataKey, _ = kek.Open(nil, make([]byte, kek.NonceSize()), wrapped, ephemeralPub[:])
+		}
+	}
+	if dataKey == nil {
+		return nil, nil, ErrNotRecipient
+	}
+	defer Wipe(dataKey)
+
+	stream, err := algorithm.Stream(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, header, nil
+}
+
+// EncryptDataFor encrypts data so that any of the
+// recipients can decrypt it with DecryptDataWith.
+func EncryptDataFor(data []byte, recipients ...RecipientPublicKey) ([]byte, error) {
+	var ciphertext bytes.Buffer
+	w, err := EncryptStream(&ciphertext, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return ciphertext.Bytes(), nil
+}
+
+// DecryptDataWith decrypts data encrypted with
+// EncryptDataFor using the identity of a recipient.
+func DecryptDataWith(key RecipientIdentity, data io.Reader) ([]byte, error) {
+	r, err := DecryptStream(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+// recipientKEK derives the key wrapping the data key for
+// a recipient from the X25519 shared secret, and wipes it.
+func recipientKEK(secret []byte, ephemeralPub, recipient RecipientPublicKey) (cipher.AEAD, error) {
+	defer Wipe(secret)
+	salt := append(ephemeralPub[:], recipient[:]...)
+	kek := make([]byte, 32)
+	defer Wipe(kek)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte("madmin multi recipient")), kek); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
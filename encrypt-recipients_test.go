@@ -0,0 +1,233 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestEncryptForRecipients(t *testing.T) {
+	var (
+		private []RecipientPrivateKey
+		public  []RecipientPublicKey
+	)
+	for i := 0; i < 3; i++ {
+		key, err := GenerateRecipientKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := key.Public()
+		if err != nil {
+			t.Fatal(err)
+		}
+		private, public = append(private, key), append(public, pub)
+	}
+
+	data := bytes.Repeat([]byte("madmin"), 100000)
+	ciphertext, err := EncryptDataFor(data, public[:2]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every recipient can decrypt the data.
+	for i, key := range private[:2] {
+		plaintext, err := DecryptDataWith(key, bytes.NewReader(ciphertext))
+		if err != nil {
+			t.Fatalf("recipient %d: %v", i, err)
+		}
+		if !bytes.Equal(plaintext, data) {
+			t.Fatalf("recipient %d: plaintext does not match", i)
+		}
+	}
+
+	// Other keys cannot.
+	if _, err = DecryptDataWith(private[2], bytes.NewReader(ciphertext)); err != ErrNotRecipient {
+		t.Fatalf("expected ErrNotRecipient, got %v", err)
+	}
+
+	// Modified ciphertexts are detected.
+	tampered := append([]byte{}, ciphertext...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err = DecryptDataWith(private[0], bytes.NewReader(tampered)); err != ErrMaliciousData {
+		t.Fatalf("expected ErrMaliciousData, got %v", err)
+	}
+}
+
+func TestDecryptStreamAt(t *testing.T) {
+	key, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := key.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{0, 1, 16 << 10, 100000} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		ciphertext, err := EncryptDataFor(data, pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := DecryptStreamAt(bytes.NewReader(ciphertext), int64(len(ciphertext)), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Size() != int64(size) {
+			t.Fatalf("size %d: got plaintext size %d", size, r.Size())
+		}
+
+		// Resume reading from the middle of the stream.
+		offset := int64(size / 2)
+		if _, err = r.Seek(offset, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		rest, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !bytes.Equal(rest, data[offset:]) {
+			t.Fatalf("size %d: plaintext does not match", size)
+		}
+	}
+}
+
+// tokenIdentity simulates a hardware token, only
+// exposing key agreement but not the private key.
+type tokenIdentity struct {
+	agree func(RecipientPublicKey) ([]byte, error)
+	pub   RecipientPublicKey
+}
+
+func (t tokenIdentity) Public() (RecipientPublicKey, error) { return t.pub, nil }
+
+func (t tokenIdentity) SharedSecret(peer RecipientPublicKey) ([]byte, error) { return t.agree(peer) }
+
+func TestDecryptWithIdentity(t *testing.T) {
+	key, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := key.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokenIdentity{agree: key.SharedSecret, pub: pub}
+
+	ciphertext, err := EncryptDataFor([]byte("support bundle"), pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := DecryptDataWith(token, bytes.NewReader(ciphertext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "support bundle" {
+		t.Fatalf("plaintext does not match: %q", plaintext)
+	}
+}
+
+// hsmKey simulates an RSA key held in an HSM, only
+// exposing decryption but not the private key.
+type hsmKey struct{ key *rsa.PrivateKey }
+
+func (k hsmKey) Public() crypto.PublicKey { return &k.key.PublicKey }
+
+func (k hsmKey) Decrypt(rand io.Reader, ciphertext []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	return k.key.Decrypt(rand, ciphertext, opts)
+}
+
+func TestDecryptWithRSAIdentity(t *testing.T) {
+	x25519Key, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	x25519Pub, err := x25519Key.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rsaKeys []*rsa.PrivateKey
+	for i := 0; i < 2; i++ {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rsaKeys = append(rsaKeys, key)
+	}
+
+	data := bytes.Repeat([]byte("support bundle"), 10000)
+	var ciphertext bytes.Buffer
+	w, err := EncryptStreamForRSA(&ciphertext, []RecipientPublicKey{x25519Pub}, &rsaKeys[0].PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(data)
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := NewRSARecipientIdentity(hsmKey{rsaKeys[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []RecipientIdentity{identity, x25519Key} {
+		plaintext, err := DecryptDataWith(key, bytes.NewReader(ciphertext.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(plaintext, data) {
+			t.Fatal("plaintext does not match")
+		}
+	}
+
+	// Random access works with RSA identities too.
+	r, err := DecryptStreamAt(bytes.NewReader(ciphertext.Bytes()), int64(ciphertext.Len()), identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := ioutil.ReadAll(io.NewSectionReader(r, 1000, r.Size()))
+	if err != nil || !bytes.Equal(rest, data[1000:]) {
+		t.Fatalf("random access plaintext does not match: %v", err)
+	}
+
+	other, err := NewRSARecipientIdentity(rsaKeys[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DecryptDataWith(other, bytes.NewReader(ciphertext.Bytes())); err != ErrNotRecipient {
+		t.Fatalf("expected ErrNotRecipient, got %v", err)
+	}
+
+	// Streams without RSA recipients cannot be decrypted by RSA identities.
+	v1, err := EncryptDataFor(data, x25519Pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DecryptDataWith(identity, bytes.NewReader(v1)); err != ErrNotRecipient {
+		t.Fatalf("expected ErrNotRecipient, got %v", err)
+	}
+}
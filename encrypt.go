@@ -22,20 +22,73 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"runtime"
 
 	"github.com/secure-io/sio-go"
 	"github.com/secure-io/sio-go/sioutil"
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 )
 
+// EncryptionKDF is the password based key derivation
+// function used by EncryptDataWithOpts.
+type EncryptionKDF int
+
+// Supported key derivation functions
+const (
+	// KDFAuto selects PBKDF2 in FIPS mode and Argon2id otherwise.
+	KDFAuto EncryptionKDF = iota
+	KDFArgon2id
+	KDFScrypt
+	KDFPBKDF2
+)
+
+// EncryptionAEAD is the authenticated encryption
+// algorithm used by EncryptDataWithOpts.
+type EncryptionAEAD int
+
+// Supported authenticated encryption algorithms
+const (
+	// AEADAuto selects AES-256-GCM in FIPS mode or when the CPU
+	// supports AES natively and ChaCha20-Poly1305 otherwise.
+	AEADAuto EncryptionAEAD = iota
+	AEADAES256GCM
+	AEADChaCha20Poly1305
+)
+
+// EncryptOpts selects the algorithms of EncryptDataWithOpts.
+type EncryptOpts struct {
+	KDF  EncryptionKDF
+	AEAD EncryptionAEAD
+}
+
+// encryptionFormat is a combination of KDF and AEAD,
+// identified by the format ID of the ciphertext header.
+type encryptionFormat struct {
+	kdf  EncryptionKDF
+	aead EncryptionAEAD
+}
+
+// encryptionFormats maps the format IDs to their algorithms.
+// IDs must never be reused, so that existing ciphertexts
+// remain decryptable.
+var encryptionFormats = map[byte]encryptionFormat{
+	argon2idAESGCM:           {KDFArgon2id, AEADAES256GCM},
+	argon2idChaCHa20Poly1305: {KDFArgon2id, AEADChaCha20Poly1305},
+	pbkdf2AESGCM:             {KDFPBKDF2, AEADAES256GCM},
+	pbkdf2ChaCha20Poly1305:   {KDFPBKDF2, AEADChaCha20Poly1305},
+	scryptAESGCM:             {KDFScrypt, AEADAES256GCM},
+	scryptChaCha20Poly1305:   {KDFScrypt, AEADChaCha20Poly1305},
+}
+
 // IsEncrypted reports whether data is encrypted.
 func IsEncrypted(data []byte) bool {
 	if len(data) <= 32 {
 		return false
 	}
-	b := data[32]
-	return b == pbkdf2AESGCM || b == argon2idAESGCM || b == argon2idChaCHa20Poly1305
+	_, ok := encryptionFormats[data[32]]
+	return ok
 }
 
 // EncryptData encrypts the data with an unique key
@@ -46,37 +99,37 @@ func IsEncrypted(data []byte) bool {
 //	salt | AEAD ID | nonce | encrypted data
 //	 32      1         8      ~ len(data)
 func EncryptData(password string, data []byte) ([]byte, error) {
-	salt := sioutil.MustRandom(32)
+	return EncryptDataWithOpts(password, data, EncryptOpts{})
+}
 
-	var (
-		id     byte
-		err    error
-		stream *sio.Stream
-	)
-	if FIPSEnabled() {
-		key := pbkdf2.Key([]byte(password), salt, pbkdf2Cost, 32, sha256.New)
-		stream, err = sio.AES_256_GCM.Stream(key)
-		if err != nil {
-			return nil, err
+// EncryptDataWithOpts encrypts the data like EncryptData, using the
+// KDF and AEAD selected by opts. The AEAD ID of the ciphertext
+// identifies both algorithms, so DecryptData needs no options.
+func EncryptDataWithOpts(password string, data []byte, opts EncryptOpts) ([]byte, error) {
+	if opts.KDF == KDFAuto {
+		opts.KDF = KDFArgon2id
+		if FIPSEnabled() {
+			opts.KDF = KDFPBKDF2
 		}
-		id = pbkdf2AESGCM
-	} else {
-		key := argon2.IDKey([]byte(password), salt, argon2idTime, argon2idMemory, argon2idThreads, 32)
-		if sioutil.NativeAES() {
-			stream, err = sio.AES_256_GCM.Stream(key)
-			if err != nil {
-				return nil, err
-			}
-			id = argon2idAESGCM
-		} else {
-			stream, err = sio.ChaCha20Poly1305.Stream(key)
-			if err != nil {
-				return nil, err
-			}
-			id = argon2idChaCHa20Poly1305
+	}
+	if opts.AEAD == AEADAuto {
+		opts.AEAD = AEADChaCha20Poly1305
+		if FIPSEnabled() || sioutil.NativeAES() {
+			opts.AEAD = AEADAES256GCM
 		}
 	}
 
+	id, ok := encryptionFormatID(encryptionFormat{opts.KDF, opts.AEAD})
+	if !ok {
+		return nil, ErrInvalidArgument("unsupported KDF or AEAD")
+	}
+
+	salt := sioutil.MustRandom(32)
+	stream, err := encryptionFormats[id].stream(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
 	nonce := sioutil.MustRandom(stream.NonceSize())
 
 	// ciphertext = salt || AEAD ID | nonce | encrypted data
@@ -126,23 +179,11 @@ func DecryptData(password string, data io.Reader) ([]byte, error) {
 		return nil, err
 	}
 
-	var (
-		err    error
-		stream *sio.Stream
-	)
-	switch {
-	case id[0] == argon2idAESGCM:
-		key := argon2.IDKey([]byte(password), salt[:], argon2idTime, argon2idMemory, argon2idThreads, 32)
-		stream, err = sio.AES_256_GCM.Stream(key)
-	case id[0] == argon2idChaCHa20Poly1305:
-		key := argon2.IDKey([]byte(password), salt[:], argon2idTime, argon2idMemory, argon2idThreads, 32)
-		stream, err = sio.ChaCha20Poly1305.Stream(key)
-	case id[0] == pbkdf2AESGCM:
-		key := pbkdf2.Key([]byte(password), salt[:], pbkdf2Cost, 32, sha256.New)
-		stream, err = sio.AES_256_GCM.Stream(key)
-	default:
-		err = errors.New("madmin: invalid encryption algorithm ID")
+	format, ok := encryptionFormats[id[0]]
+	if !ok {
+		return nil, errors.New("madmin: invalid encryption algorithm ID")
 	}
+	stream, err := format.stream(password, salt[:])
 	if err != nil {
 		return nil, err
 	}
@@ -154,10 +195,68 @@ func DecryptData(password string, data io.Reader) ([]byte, error) {
 	return plaintext, err
 }
 
+// Wipe overwrites b with zeros, to remove secret
+// key material from memory once it is not needed.
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(b)
+}
+
+// encryptionFormatID returns the ID of the format f.
+func encryptionFormatID(f encryptionFormat) (byte, bool) {
+	for id, format := range encryptionFormats {
+		if format == f {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// wipeDerivedKey wipes the keys derived by encryptionFormat.stream.
+var wipeDerivedKey = Wipe
+
+// stream returns the AEAD stream keyed with
+// the key derived from password and salt.
+func (f encryptionFormat) stream(password string, salt []byte) (*sio.Stream, error) {
+	var (
+		key []byte
+		err error
+	)
+	switch f.kdf {
+	case KDFArgon2id:
+		key = argon2.IDKey([]byte(password), salt, argon2idTime, argon2idMemory, argon2idThreads, 32)
+	case KDFPBKDF2:
+		key = pbkdf2.Key([]byte(password), salt, pbkdf2Cost, 32, sha256.New)
+	case KDFScrypt:
+		key, err = scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, 32)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("madmin: invalid key derivation function")
+	}
+	// The ciphers keep their own copy of the key.
+	defer wipeDerivedKey(key)
+
+	switch f.aead {
+	case AEADAES256GCM:
+		return sio.AES_256_GCM.Stream(key)
+	case AEADChaCha20Poly1305:
+		return sio.ChaCha20Poly1305.Stream(key)
+	default:
+		return nil, errors.New("madmin: invalid encryption algorithm")
+	}
+}
+
 const (
 	argon2idAESGCM           = 0x00
 	argon2idChaCHa20Poly1305 = 0x01
 	pbkdf2AESGCM             = 0x02
+	pbkdf2ChaCha20Poly1305   = 0x03
+	scryptAESGCM             = 0x04
+	scryptChaCha20Poly1305   = 0x05
 )
 
 const (
@@ -165,4 +264,7 @@ const (
 	argon2idMemory  = 64 * 1024
 	argon2idThreads = 4
 	pbkdf2Cost      = 8192
+	scryptN         = 1 << 15
+	scryptR         = 8
+	scryptP         = 1
 )
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"testing"
 )
 
@@ -109,3 +110,73 @@ func TestIsDecrypted(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptDataWithOpts(t *testing.T) {
+	data := []byte("madmin")
+	for _, kdf := range []EncryptionKDF{KDFAuto, KDFArgon2id, KDFScrypt, KDFPBKDF2} {
+		for _, aead := range []EncryptionAEAD{AEADAuto, AEADAES256GCM, AEADChaCha20Poly1305} {
+			ciphertext, err := EncryptDataWithOpts("password", data, EncryptOpts{KDF: kdf, AEAD: aead})
+			if err != nil {
+				t.Fatalf("KDF %d, AEAD %d: failed to encrypt data: %v", kdf, aead, err)
+			}
+			if !IsEncrypted(ciphertext) {
+				t.Fatalf("KDF %d, AEAD %d: ciphertext is not encrypted", kdf, aead)
+			}
+			plaintext, err := DecryptData("password", bytes.NewReader(ciphertext))
+			if err != nil {
+				t.Fatalf("KDF %d, AEAD %d: failed to decrypt data: %v", kdf, aead, err)
+			}
+			if !bytes.Equal(plaintext, data) {
+				t.Fatalf("KDF %d, AEAD %d: plaintext does not match origin data", kdf, aead)
+			}
+		}
+	}
+
+	if _, err := EncryptDataWithOpts("password", data, EncryptOpts{KDF: 42}); err == nil {
+		t.Fatal("expected an error for an unsupported KDF")
+	}
+}
+
+func TestWipe(t *testing.T) {
+	key := []byte("secret key material")
+	Wipe(key)
+	if !bytes.Equal(key, make([]byte, len(key))) {
+		t.Fatalf("key was not wiped: %q", key)
+	}
+	Wipe(nil)
+}
+
+func TestStreamWipesDerivedKey(t *testing.T) {
+	defer func(wipe func([]byte)) { wipeDerivedKey = wipe }(wipeDerivedKey)
+
+	for id, format := range encryptionFormats {
+		var key []byte
+		wipeDerivedKey = func(b []byte) {
+			key = b
+			Wipe(b)
+		}
+		salt := make([]byte, 32)
+		stream, err := format.stream("password", salt)
+		if err != nil {
+			t.Fatalf("Format %d: %v", id, err)
+		}
+		if len(key) != 32 || !bytes.Equal(key, make([]byte, len(key))) {
+			t.Fatalf("Format %d: derived key was not wiped: %x", id, key)
+		}
+
+		// The stream keeps working with its own copy of the key.
+		nonce := make([]byte, stream.NonceSize())
+		ciphertext, err := ioutil.ReadAll(stream.EncryptReader(bytes.NewReader([]byte("data")), nonce, nil))
+		if err != nil {
+			t.Fatalf("Format %d: %v", id, err)
+		}
+		stream, err = format.stream("password", salt)
+		if err != nil {
+			t.Fatalf("Format %d: %v", id, err)
+		}
+		plaintext, err := ioutil.ReadAll(stream.DecryptReader(bytes.NewReader(ciphertext), nonce, nil))
+		if err != nil || string(plaintext) != "data" {
+			t.Fatalf("Format %d: decryption failed: %v", id, err)
+		}
+	}
+}
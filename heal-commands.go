@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -87,6 +88,13 @@ type HealTaskStatus struct {
 	StartTime     time.Time `json:"startTime"`
 	HealSettings  HealOpts  `json:"settings"`
 
+	// Counters for the heal sequence so far, only
+	// populated by servers supporting HealStatusStream.
+	ObjectsScanned uint64 `json:"objectsScanned,omitempty"`
+	ObjectsHealed  uint64 `json:"objectsHealed,omitempty"`
+	ObjectsFailed  uint64 `json:"objectsFailed,omitempty"`
+	BytesHealed    uint64 `json:"bytesHealed,omitempty"`
+
 	Items []HealResultItem `json:"items,omitempty"`
 }
 
@@ -299,6 +307,76 @@ func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string,
 	return healStart, healTaskStatus, nil
 }
 
+// HealStatusStreamResult holds a single heal status update
+// as returned by HealStatusStream, or the error encountered.
+type HealStatusStreamResult struct {
+	Status HealTaskStatus `json:"status"`
+	Err    error          `json:"-"`
+}
+
+// HealStatusStream - subscribe to the status of the heal sequence running
+// on bucket and prefix. The server sends periodic status updates over a
+// single long-lived connection until the heal sequence finishes or ctx
+// is canceled, so callers do not have to poll Heal with a client token.
+func (adm *AdminClient) HealStatusStream(ctx context.Context, bucket, prefix string) <-chan HealStatusStreamResult {
+	statusCh := make(chan HealStatusStreamResult)
+
+	go func(statusCh chan<- HealStatusStreamResult) {
+		defer close(statusCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case statusCh <- HealStatusStreamResult{Err: err}:
+			}
+		}
+
+		queryVals := make(url.Values)
+		if bucket != "" {
+			queryVals.Set("bucket", bucket)
+		}
+		if bucket != "" && prefix != "" {
+			queryVals.Set("prefix", prefix)
+		}
+
+		resp, err := adm.executeMethod(ctx,
+			http.MethodGet, requestData{
+				relPath:     adminAPIPrefix + "/heal-status-stream",
+				queryValues: queryVals,
+				category:    RequestCategoryStreaming,
+			})
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var status HealTaskStatus
+			if err = dec.Decode(&status); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case statusCh <- HealStatusStreamResult{Status: status}:
+			}
+		}
+	}(statusCh)
+
+	// Returns the status channel, for caller to start reading from.
+	return statusCh
+}
+
 // MRFStatus exposes MRF metrics of a server
 type MRFStatus struct {
 	BytesHealed uint64 `json:"bytes_healed"`
@@ -335,6 +413,37 @@ type SetStatus struct {
 	HealPriority string `json:"heal_priority"`
 	TotalObjects int    `json:"total_objects"`
 	Disks        []Disk `json:"disks"`
+
+	// QueuedItems is the number of items waiting in the heal queue of the set.
+	QueuedItems int64 `json:"queued_items"`
+	// HealedLastHour is the number of items of the set healed in the last hour.
+	HealedLastHour int64 `json:"healed_last_hour"`
+}
+
+// HealingDriveProgress is the progress of a drive of a set being healed.
+type HealingDriveProgress struct {
+	Endpoint        string  `json:"endpoint"`
+	Path            string  `json:"path"`
+	PercentComplete float64 `json:"percent_complete"`
+}
+
+// HealingDrives returns the drives of the set currently being healed.
+func (s SetStatus) HealingDrives() []HealingDriveProgress {
+	var drives []HealingDriveProgress
+	for _, disk := range s.Disks {
+		if !disk.Healing && disk.HealInfo == nil {
+			continue
+		}
+		progress := HealingDriveProgress{
+			Endpoint: disk.Endpoint,
+			Path:     disk.DrivePath,
+		}
+		if disk.HealInfo != nil {
+			progress.PercentComplete = disk.HealInfo.PercentComplete()
+		}
+		drives = append(drives, progress)
+	}
+	return drives
 }
 
 // HealingDisk contains information about
@@ -374,6 +483,24 @@ type HealingDisk struct {
 	// future add more tracking capabilities
 }
 
+// PercentComplete returns the estimated completion of the drive heal
+// as a percentage, based on bytes when known, otherwise on items.
+func (h HealingDisk) PercentComplete() float64 {
+	var done, total uint64
+	switch {
+	case h.ObjectsTotalSize > 0:
+		done, total = h.BytesDone+h.BytesFailed, h.ObjectsTotalSize
+	case h.ObjectsTotalCount > 0:
+		done, total = h.ItemsHealed+h.ItemsFailed, h.ObjectsTotalCount
+	default:
+		return 0
+	}
+	if done >= total {
+		return 100
+	}
+	return float64(done) * 100 / float64(total)
+}
+
 // Merge others into b.
 func (b *BgHealState) Merge(others ...BgHealState) {
 	// SCParity is the same from all nodes, just pick
@@ -406,6 +533,9 @@ func (b *BgHealState) Merge(others ...BgHealState) {
 				if existing.ID != set.ID {
 					continue
 				}
+				// Each node reports the queue of its local drives.
+				b.Sets[eSetIdx].QueuedItems += set.QueuedItems
+				b.Sets[eSetIdx].HealedLastHour += set.HealedLastHour
 				if len(existing.Disks) < len(set.Disks) {
 					b.Sets[eSetIdx].Disks = set.Disks
 				}
@@ -17,6 +17,9 @@
 package madmin
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 )
 
@@ -70,3 +73,71 @@ func TestHealDriveCounts(t *testing.T) {
 		t.Errorf("Expected '4', got %d after missing disks", i)
 	}
 }
+
+func TestSetStatusHealingDrives(t *testing.T) {
+	set := SetStatus{
+		Disks: []Disk{
+			{Endpoint: "http://node1/d1"},
+			{Endpoint: "http://node1/d2", Healing: true, HealInfo: &HealingDisk{ObjectsTotalSize: 400, BytesDone: 100}},
+			{Endpoint: "http://node1/d3", Healing: true, HealInfo: &HealingDisk{ObjectsTotalCount: 10, ItemsHealed: 12}},
+		},
+	}
+	drives := set.HealingDrives()
+	if len(drives) != 2 {
+		t.Fatalf("expected 2 healing drives, got %d", len(drives))
+	}
+	if drives[0].PercentComplete != 25 {
+		t.Errorf("expected 25%%, got %v", drives[0].PercentComplete)
+	}
+	if drives[1].PercentComplete != 100 {
+		t.Errorf("expected 100%%, got %v", drives[1].PercentComplete)
+	}
+}
+
+func TestBgHealStateMergeSetCounters(t *testing.T) {
+	var state BgHealState
+	state.Merge(
+		BgHealState{Sets: []SetStatus{{ID: "0-0", QueuedItems: 3, HealedLastHour: 5}}},
+		BgHealState{Sets: []SetStatus{{ID: "0-0", QueuedItems: 4, HealedLastHour: 1}}},
+	)
+	if len(state.Sets) != 1 {
+		t.Fatalf("expected 1 set, got %d", len(state.Sets))
+	}
+	if state.Sets[0].QueuedItems != 7 || state.Sets[0].HealedLastHour != 6 {
+		t.Errorf("unexpected merged counters %+v", state.Sets[0])
+	}
+}
+
+func TestHealStatusStream(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/heal-status-stream" || q.Get("bucket") != "photos" || q.Get("prefix") != "2022/" {
+			writeTestError(w, http.StatusNotFound, "XMinioHealNoSuchProcess")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(HealTaskStatus{Summary: "running", ObjectsScanned: 10})
+		enc.Encode(HealTaskStatus{Summary: "finished", ObjectsScanned: 20, ObjectsHealed: 2})
+		w.Write([]byte("{"))
+	})
+
+	var results []HealStatusStreamResult
+	for res := range adm.HealStatusStream(context.Background(), "photos", "2022/") {
+		results = append(results, res)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	if s := results[1].Status; s.Summary != "finished" || s.ObjectsScanned != 20 || s.ObjectsHealed != 2 {
+		t.Errorf("unexpected status %+v", s)
+	}
+	if results[2].Err == nil {
+		t.Error("expected decode error for the truncated status")
+	}
+
+	for res := range adm.HealStatusStream(context.Background(), "other", "") {
+		if ToErrorResponse(res.Err).Code != "XMinioHealNoSuchProcess" {
+			t.Fatalf("expected XMinioHealNoSuchProcess, got %v", res.Err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Summaries of a heal sequence reported by Heal.
+const (
+	HealSummaryRunning  = "running"
+	HealSummaryFinished = "finished"
+	HealSummaryStopped  = "stopped"
+)
+
+// HealTokenStore persists the client token of a heal sequence,
+// so the sequence can be resumed after the client restarts.
+type HealTokenStore interface {
+	// Load returns the persisted token, or an empty token if none.
+	Load() (string, error)
+	// Save persists the token.
+	Save(token string) error
+	// Delete removes the persisted token.
+	Delete() error
+}
+
+// FileHealTokenStore is a HealTokenStore keeping
+// the token in the file at the given path.
+type FileHealTokenStore string
+
+// Load returns the token stored in the file.
+func (f FileHealTokenStore) Load() (string, error) {
+	buf, err := ioutil.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(buf)), err
+}
+
+// Save writes the token to the file.
+func (f FileHealTokenStore) Save(token string) error {
+	return ioutil.WriteFile(string(f), []byte(token), 0o600)
+}
+
+// Delete removes the file.
+func (f FileHealTokenStore) Delete() error {
+	if err := os.Remove(string(f)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// HealSequence drives a heal sequence started with Heal, persisting
+// its client token so it is resumed instead of restarted after the
+// client restarts.
+type HealSequence struct {
+	adm    *AdminClient
+	bucket string
+	prefix string
+	opts   HealOpts
+	store  HealTokenStore
+
+	// PollInterval is the interval between status requests.
+	PollInterval time.Duration
+
+	// mu guards the fields below, which are updated by
+	// the Results goroutine.
+	mu        sync.Mutex
+	token     string
+	lastIndex int64
+	pending   []HealResultItem
+	status    HealTaskStatus
+}
+
+// HealSequenceResult is a single healed item of a heal
+// sequence, or the error encountered.
+type HealSequenceResult struct {
+	Item HealResultItem
+	Err  error
+}
+
+// NewHealSequence returns a heal sequence of bucket and prefix whose
+// client token is persisted in store, which may be nil.
+func (adm *AdminClient) NewHealSequence(bucket, prefix string, opts HealOpts, store HealTokenStore) *HealSequence {
+	return &HealSequence{
+		adm:          adm,
+		bucket:       bucket,
+		prefix:       prefix,
+		opts:         opts,
+		store:        store,
+		PollInterval: time.Second,
+	}
+}
+
+// ClientToken returns the client token of the sequence
+// once it was started or resumed.
+func (s *HealSequence) ClientToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// Status returns the last status received from the server.
+func (s *HealSequence) Status() HealTaskStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Start resumes the sequence of the persisted client token if the
+// server still knows it, otherwise it starts a new heal sequence.
+func (s *HealSequence) Start(ctx context.Context) error {
+	if s.store != nil {
+		token, err := s.store.Load()
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			_, status, err := s.adm.Heal(ctx, s.bucket, s.prefix, s.opts, token, false, false)
+			switch {
+			case err == nil:
+				s.mu.Lock()
+				s.token = token
+				s.mu.Unlock()
+				s.update(status)
+				return nil
+			case !errors.Is(err, ErrHealNoSuchProcess):
+				return err
+			}
+		}
+	}
+
+	start, _, err := s.adm.Heal(ctx, s.bucket, s.prefix, s.opts, "", false, false)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.token = start.ClientToken
+	s.mu.Unlock()
+	if s.store != nil {
+		return s.store.Save(start.ClientToken)
+	}
+	return nil
+}
+
+// Stop stops the heal sequence and removes the persisted token.
+func (s *HealSequence) Stop(ctx context.Context) error {
+	if _, _, err := s.adm.Heal(ctx, s.bucket, s.prefix, s.opts, "", false, true); err != nil {
+		return err
+	}
+	return s.done()
+}
+
+// Results returns the healed items of the started sequence until it
+// finishes, is stopped or ctx is canceled. Items already returned by s
+// are skipped. The index of the last returned item is not persisted,
+// after a client restart the resumed sequence returns the items the
+// server did not report to the client token yet.
+func (s *HealSequence) Results(ctx context.Context) <-chan HealSequenceResult {
+	resultCh := make(chan HealSequenceResult)
+
+	go func(resultCh chan<- HealSequenceResult) {
+		defer close(resultCh)
+
+		send := func(r HealSequenceResult) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case resultCh <- r:
+				return true
+			}
+		}
+
+		token := s.ClientToken()
+		if token == "" {
+			send(HealSequenceResult{Err: ErrInvalidArgument("heal sequence is not started")})
+			return
+		}
+
+		for {
+			for {
+				item, ok := s.nextPending()
+				if !ok {
+					break
+				}
+				if !send(HealSequenceResult{Item: item}) {
+					return
+				}
+			}
+
+			status := s.Status()
+			switch status.Summary {
+			case HealSummaryFinished:
+				if err := s.done(); err != nil {
+					send(HealSequenceResult{Err: err})
+				}
+				return
+			case HealSummaryStopped:
+				err := s.done()
+				if err == nil {
+					err = errors.New("heal sequence stopped: " + status.FailureDetail)
+				}
+				send(HealSequenceResult{Err: err})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.PollInterval):
+			}
+
+			_, next, err := s.adm.Heal(ctx, s.bucket, s.prefix, s.opts, token, false, false)
+			if err != nil {
+				send(HealSequenceResult{Err: err})
+				return
+			}
+			s.update(next)
+		}
+	}(resultCh)
+
+	return resultCh
+}
+
+// nextPending removes and returns the first queued item.
+func (s *HealSequence) nextPending() (HealResultItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) == 0 {
+		return HealResultItem{}, false
+	}
+	item := s.pending[0]
+	s.pending = s.pending[1:]
+	return item, true
+}
+
+// update records status and queues its items not returned yet.
+func (s *HealSequence) update(status HealTaskStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+	for _, item := range status.Items {
+		if item.ResultIndex > s.lastIndex {
+			s.lastIndex = item.ResultIndex
+			s.pending = append(s.pending, item)
+		}
+	}
+}
+
+// done removes the persisted token of a completed sequence.
+func (s *HealSequence) done() error {
+	if s.store != nil {
+		return s.store.Delete()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileHealTokenStore(t *testing.T) {
+	store := FileHealTokenStore(filepath.Join(t.TempDir(), "heal-token"))
+	if token, err := store.Load(); err != nil || token != "" {
+		t.Fatalf("expected empty token, got %q, %v", token, err)
+	}
+	if err := store.Save("token-1"); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := store.Load(); err != nil || token != "token-1" {
+		t.Fatalf("expected token-1, got %q, %v", token, err)
+	}
+	if err := store.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(); err != nil {
+		t.Fatalf("deleting a missing token should succeed, got %v", err)
+	}
+}
+
+func TestHealSequenceUpdate(t *testing.T) {
+	s := &HealSequence{}
+	s.update(HealTaskStatus{Items: []HealResultItem{{ResultIndex: 1}, {ResultIndex: 2}}})
+	s.pending = nil
+	s.update(HealTaskStatus{Items: []HealResultItem{{ResultIndex: 2}, {ResultIndex: 3}}})
+	if len(s.pending) != 1 || s.pending[0].ResultIndex != 3 {
+		t.Fatalf("expected only result 3 pending, got %v", s.pending)
+	}
+}
+
+func TestHealSequenceConcurrentStatus(t *testing.T) {
+	s := &HealSequence{token: "token-1"}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(1); i <= 100; i++ {
+			s.update(HealTaskStatus{Summary: HealSummaryRunning, Items: []HealResultItem{{ResultIndex: i}}})
+			s.nextPending()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		s.Status()
+		s.ClientToken()
+	}
+	<-done
+	if _, ok := s.nextPending(); ok || s.Status().Summary != HealSummaryRunning {
+		t.Fatalf("unexpected state %v", s.Status())
+	}
+}
@@ -0,0 +1,89 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Heal subsystem config keys holding the per drive heal throttle.
+const (
+	HealDriveMaxIOPS        = "drive_max_iops"
+	HealDriveMaxBytesPerSec = "drive_max_bytes_per_sec"
+)
+
+// HealThrottle holds the per drive heal throughput limits,
+// a value of zero means healing is not throttled on it.
+type HealThrottle struct {
+	MaxIOPS        uint64 `json:"maxIOPS"`
+	MaxBytesPerSec uint64 `json:"maxBytesPerSec"`
+}
+
+// GetHealThrottle - returns the per drive heal throughput limits
+// currently in effect, including any environment overrides.
+func (adm *AdminClient) GetHealThrottle(ctx context.Context) (HealThrottle, error) {
+	var throttle HealThrottle
+
+	buf, err := adm.GetConfigKV(ctx, HealSubSys)
+	if err != nil {
+		return throttle, err
+	}
+
+	cfgs, err := ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return throttle, err
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.SubSystem != HealSubSys || cfg.Target != "" {
+			continue
+		}
+		if throttle.MaxIOPS, err = lookupUint(cfg, HealDriveMaxIOPS); err != nil {
+			return throttle, err
+		}
+		if throttle.MaxBytesPerSec, err = lookupUint(cfg, HealDriveMaxBytesPerSec); err != nil {
+			return throttle, err
+		}
+	}
+	return throttle, nil
+}
+
+// SetHealThrottle - sets the per drive heal throughput limits, pass
+// zero to remove a limit. The returned restart value indicates whether
+// the server needs to be restarted for the change to take effect.
+func (adm *AdminClient) SetHealThrottle(ctx context.Context, maxIOPS, maxBytesPerSec uint64) (restart bool, err error) {
+	kv := fmt.Sprintf("%s %s=%d %s=%d", HealSubSys,
+		HealDriveMaxIOPS, maxIOPS,
+		HealDriveMaxBytesPerSec, maxBytesPerSec)
+	return adm.SetConfigKV(ctx, kv)
+}
+
+// lookupUint - looks up key in cfg as an unsigned integer,
+// a missing or empty value is returned as zero.
+func lookupUint(cfg SubsysConfig, key string) (uint64, error) {
+	v, ok := cfg.Lookup(key)
+	if !ok || v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for %s %s: %w", v, cfg.SubSystem, key, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestHealThrottle(t *testing.T) {
+	config := "heal bitrotscan=off"
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/set-config-kv":
+			data, _ := ioutil.ReadAll(r.Body)
+			buf, err := DecryptData("minio123", bytes.NewReader(data))
+			if err != nil {
+				writeTestError(w, http.StatusBadRequest, "XMinioAdminConfigBadJSON")
+				return
+			}
+			config = string(buf)
+		case "/minio/admin/v3/get-config-kv":
+			if r.URL.Query().Get("key") != HealSubSys {
+				writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+				return
+			}
+			data, err := EncryptData("minio123", []byte(config))
+			if err != nil {
+				t.Error(err)
+			}
+			w.Write(data)
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	throttle, err := adm.GetHealThrottle(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throttle != (HealThrottle{}) {
+		t.Fatalf("expected no throttle, got %+v", throttle)
+	}
+
+	if _, err = adm.SetHealThrottle(ctx, 500, 100<<20); err != nil {
+		t.Fatal(err)
+	}
+	if config != "heal drive_max_iops=500 drive_max_bytes_per_sec=104857600" {
+		t.Fatalf("unexpected config %q", config)
+	}
+	if throttle, err = adm.GetHealThrottle(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if throttle.MaxIOPS != 500 || throttle.MaxBytesPerSec != 100<<20 {
+		t.Fatalf("unexpected throttle %+v", throttle)
+	}
+
+	for _, invalid := range []string{
+		"heal drive_max_iops=-1",
+		"heal drive_max_iops=100 drive_max_bytes_per_sec=1MiB",
+	} {
+		config = invalid
+		if _, err = adm.GetHealThrottle(ctx); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
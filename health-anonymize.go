@@ -0,0 +1,260 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// HealthAnonymization selects which identifying information
+// is obfuscated in a health report before it is shared.
+type HealthAnonymization int
+
+// Supported anonymization levels.
+const (
+	// HealthAnonymizeNone keeps the report as is.
+	HealthAnonymizeNone HealthAnonymization = iota
+	// HealthAnonymizeHostnames obfuscates hostnames, IP addresses, drive
+	// serial numbers and the server configuration and environment values,
+	// which may contain endpoints and credentials.
+	HealthAnonymizeHostnames
+	// HealthAnonymizeFull additionally obfuscates bucket and object
+	// names, drive paths, domains and deployment ID.
+	HealthAnonymizeFull
+)
+
+// HealthAnonymizer obfuscates identifying information in health reports.
+// Values are replaced with a keyed hash, so the same value is replaced
+// consistently within and across reports anonymized with the same key,
+// while the original cannot be recovered without the key.
+type HealthAnonymizer struct {
+	Level HealthAnonymization
+	Key   []byte
+}
+
+// hash returns the keyed hash of value prefixed with kind.
+func (a HealthAnonymizer) hash(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.Key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return kind + "-" + hex.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// host obfuscates the host of an endpoint, which is either a URL
+// or a host with an optional port and path, keeping port and path.
+func (a HealthAnonymizer) host(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err == nil && u.Host != "" {
+			u.Host = a.host(u.Host)
+			if a.Level >= HealthAnonymizeFull && u.Path != "" {
+				u.Path = "/" + a.hash("path", u.Path)
+			}
+			return u.String()
+		}
+	}
+
+	hostPort, path := endpoint, ""
+	if i := strings.IndexByte(endpoint, '/'); i >= 0 {
+		hostPort, path = endpoint[:i], endpoint[i:]
+		if a.Level >= HealthAnonymizeFull {
+			path = "/" + a.hash("path", path)
+		}
+	}
+	if h, port, err := net.SplitHostPort(hostPort); err == nil {
+		return net.JoinHostPort(a.hash("host", h), port) + path
+	}
+	return a.hash("host", hostPort) + path
+}
+
+func (a HealthAnonymizer) path(p string) string {
+	if a.Level < HealthAnonymizeFull {
+		return p
+	}
+	return a.hash("path", p)
+}
+
+func (a HealthAnonymizer) bucket(b string) string {
+	if a.Level < HealthAnonymizeFull {
+		return b
+	}
+	return a.hash("bucket", b)
+}
+
+func (a HealthAnonymizer) buckets(bs []string) []string {
+	for i := range bs {
+		bs[i] = a.bucket(bs[i])
+	}
+	return bs
+}
+
+func (a HealthAnonymizer) disk(d *Disk) {
+	d.Endpoint = a.host(d.Endpoint)
+	d.DrivePath = a.path(d.DrivePath)
+	if h := d.HealInfo; h != nil {
+		h.Endpoint = a.host(h.Endpoint)
+		h.Path = a.path(h.Path)
+		h.Bucket = a.bucket(h.Bucket)
+		if a.Level >= HealthAnonymizeFull && h.Object != "" {
+			h.Object = a.hash("object", h.Object)
+		}
+		h.QueuedBuckets = a.buckets(h.QueuedBuckets)
+		h.HealedBuckets = a.buckets(h.HealedBuckets)
+	}
+}
+
+func (a HealthAnonymizer) smart(s *SmartInfo) {
+	s.Device = a.path(s.Device)
+	if s.Nvme != nil {
+		s.Nvme.SerialNum = a.hash("serial", s.Nvme.SerialNum)
+	}
+	if s.Ata != nil {
+		s.Ata.SerialNum = a.hash("serial", s.Ata.SerialNum)
+		s.Ata.LUWWNDeviceID = a.hash("serial", s.Ata.LUWWNDeviceID)
+	}
+}
+
+// config obfuscates all string values of a decoded configuration,
+// keeping its structure and keys.
+func (a HealthAnonymizer) config(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return a.hash("config", v)
+	case []string:
+		for i := range v {
+			v[i] = a.hash("config", v[i])
+		}
+	case map[string]string:
+		for k := range v {
+			v[k] = a.hash("config", v[k])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = a.config(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = a.config(v[k])
+		}
+	}
+	return v
+}
+
+// Anonymize obfuscates the report in place according to the anonymizer
+// level, it must be applied before the report is serialized.
+func (a HealthAnonymizer) Anonymize(info *HealthInfo) {
+	if a.Level == HealthAnonymizeNone {
+		return
+	}
+
+	sys := &info.Sys
+	for i := range sys.CPUInfo {
+		sys.CPUInfo[i].Addr = a.host(sys.CPUInfo[i].Addr)
+	}
+	// Partitions of the same drive share its SMART data.
+	smartSeen := map[*SmartInfo]bool{}
+	for i := range sys.Partitions {
+		sys.Partitions[i].Addr = a.host(sys.Partitions[i].Addr)
+		for j := range sys.Partitions[i].Partitions {
+			p := &sys.Partitions[i].Partitions[j]
+			p.Device = a.path(p.Device)
+			p.Mountpoint = a.path(p.Mountpoint)
+			if p.SMART != nil && !smartSeen[p.SMART] {
+				smartSeen[p.SMART] = true
+				a.smart(p.SMART)
+			}
+		}
+	}
+	for i := range sys.OSInfo {
+		sys.OSInfo[i].Addr = a.host(sys.OSInfo[i].Addr)
+		sys.OSInfo[i].Info.Hostname = a.hash("host", sys.OSInfo[i].Info.Hostname)
+	}
+	for i := range sys.MemInfo {
+		sys.MemInfo[i].Addr = a.host(sys.MemInfo[i].Addr)
+	}
+	for i := range sys.ProcInfo {
+		sys.ProcInfo[i].Addr = a.host(sys.ProcInfo[i].Addr)
+	}
+	for i := range sys.SysErrs {
+		sys.SysErrs[i].Addr = a.host(sys.SysErrs[i].Addr)
+	}
+	for i := range sys.SysServices {
+		sys.SysServices[i].Addr = a.host(sys.SysServices[i].Addr)
+	}
+	for i := range sys.SysConfig {
+		sys.SysConfig[i].Addr = a.host(sys.SysConfig[i].Addr)
+	}
+	for i := range sys.NetInfo {
+		sys.NetInfo[i].Addr = a.host(sys.NetInfo[i].Addr)
+	}
+
+	perf := &info.Perf
+	for i := range perf.DrivePerf {
+		perf.DrivePerf[i].Endpoint = a.host(perf.DrivePerf[i].Endpoint)
+		for j := range perf.DrivePerf[i].DrivePerf {
+			perf.DrivePerf[i].DrivePerf[j].Path = a.path(perf.DrivePerf[i].DrivePerf[j].Path)
+		}
+	}
+	for i := range perf.ObjPerf {
+		for _, stats := range []*SpeedTestStats{&perf.ObjPerf[i].PUTStats, &perf.ObjPerf[i].GETStats} {
+			for j := range stats.Servers {
+				stats.Servers[j].Endpoint = a.host(stats.Servers[j].Endpoint)
+			}
+		}
+	}
+	for i := range perf.NetPerf {
+		perf.NetPerf[i].Endpoint = a.host(perf.NetPerf[i].Endpoint)
+	}
+
+	minio := &info.Minio.Info
+	for i := range minio.Servers {
+		s := &minio.Servers[i]
+		s.Endpoint = a.host(s.Endpoint)
+		if len(s.Network) > 0 {
+			network := make(map[string]string, len(s.Network))
+			for ep, state := range s.Network {
+				network[a.host(ep)] = state
+			}
+			s.Network = network
+		}
+		for j := range s.Drives {
+			a.disk(&s.Drives[j])
+		}
+		for k, v := range s.MinioEnvVars {
+			s.MinioEnvVars[k] = a.hash("env", v)
+		}
+	}
+	info.Minio.Config.Config = a.config(info.Minio.Config.Config)
+	if a.Level >= HealthAnonymizeFull {
+		for i := range minio.Domain {
+			minio.Domain[i] = a.hash("domain", minio.Domain[i])
+		}
+		minio.DeploymentID = a.hash("deployment", minio.DeploymentID)
+	}
+}
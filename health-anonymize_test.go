@@ -0,0 +1,111 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHealthAnonymizer(t *testing.T) {
+	newReport := func() HealthInfo {
+		var info HealthInfo
+		info.Minio.Info.DeploymentID = "deployment-1"
+		info.Minio.Info.Servers = []ServerInfo{{
+			Endpoint: "node1.example.com:9000",
+			Network:  map[string]string{"node2.example.com:9000": "online"},
+			Drives: []Disk{{
+				Endpoint:  "http://node1.example.com:9000/data1",
+				DrivePath: "/data1",
+				HealInfo:  &HealingDisk{Bucket: "photos", QueuedBuckets: []string{"photos"}},
+			}},
+		}}
+		info.Minio.Info.Servers[0].MinioEnvVars = map[string]string{"MINIO_ROOT_USER": "admin"}
+		info.Minio.Config.Config = map[string]interface{}{
+			"notify_webhook": []interface{}{
+				map[string]interface{}{"endpoint": "http://hook.example.com", "enable": "on"},
+			},
+		}
+		smart := &SmartInfo{
+			Device: "/dev/nvme0n1",
+			Nvme:   &SmartNvmeInfo{SerialNum: "S4EVNX0N123456"},
+		}
+		info.Sys.Partitions = []Partitions{{
+			Partitions: []Partition{
+				{Device: "/dev/nvme0n1p1", SMART: smart},
+				{Device: "/dev/nvme0n1p2", SMART: smart},
+			},
+		}}
+		info.Perf.NetPerf = []NetperfNodeResult{{Endpoint: "node1.example.com:9000"}}
+		return info
+	}
+
+	key := []byte("secret")
+
+	info := newReport()
+	HealthAnonymizer{Level: HealthAnonymizeHostnames, Key: key}.Anonymize(&info)
+	server := info.Minio.Info.Servers[0]
+	if strings.Contains(server.Endpoint, "example.com") || !strings.HasSuffix(server.Endpoint, ":9000") {
+		t.Fatalf("expected anonymized host with port, got %s", server.Endpoint)
+	}
+	if server.Endpoint != info.Perf.NetPerf[0].Endpoint {
+		t.Fatalf("expected consistent anonymization, got %s and %s", server.Endpoint, info.Perf.NetPerf[0].Endpoint)
+	}
+	if _, ok := server.Network[server.Endpoint]; ok {
+		t.Fatalf("expected distinct hosts to be anonymized differently")
+	}
+	if env := server.MinioEnvVars["MINIO_ROOT_USER"]; env == "admin" || env == "" {
+		t.Fatalf("expected env values to be anonymized, got %q", env)
+	}
+	webhook := info.Minio.Config.Config.(map[string]interface{})["notify_webhook"].([]interface{})[0].(map[string]interface{})
+	if endpoint, ok := webhook["endpoint"].(string); !ok || strings.Contains(endpoint, "example.com") {
+		t.Fatalf("expected config values to be anonymized, got %v", webhook["endpoint"])
+	}
+	smart := info.Sys.Partitions[0].Partitions[0].SMART
+	if serial := smart.Nvme.SerialNum; serial != (HealthAnonymizer{Key: key}).hash("serial", "S4EVNX0N123456") {
+		t.Fatalf("expected serial number to be anonymized once, got %s", serial)
+	}
+	if smart.Device != "/dev/nvme0n1" {
+		t.Fatalf("expected SMART device to be kept, got %s", smart.Device)
+	}
+	if drive := server.Drives[0]; drive.DrivePath != "/data1" || drive.HealInfo.Bucket != "photos" {
+		t.Fatalf("expected paths and buckets to be kept, got %s %s", drive.DrivePath, drive.HealInfo.Bucket)
+	}
+
+	full := newReport()
+	HealthAnonymizer{Level: HealthAnonymizeFull, Key: key}.Anonymize(&full)
+	fullServer := full.Minio.Info.Servers[0]
+	if fullServer.Endpoint != server.Endpoint {
+		t.Fatalf("expected same key to anonymize hosts identically, got %s and %s", fullServer.Endpoint, server.Endpoint)
+	}
+	drive := fullServer.Drives[0]
+	if strings.Contains(drive.Endpoint, "data1") || drive.DrivePath == "/data1" {
+		t.Fatalf("expected drive paths to be anonymized, got %s %s", drive.Endpoint, drive.DrivePath)
+	}
+	if drive.HealInfo.Bucket == "photos" || drive.HealInfo.Bucket != drive.HealInfo.QueuedBuckets[0] {
+		t.Fatalf("expected buckets to be anonymized consistently, got %s %v", drive.HealInfo.Bucket, drive.HealInfo.QueuedBuckets)
+	}
+	if full.Minio.Info.DeploymentID == "deployment-1" {
+		t.Fatal("expected deployment ID to be anonymized")
+	}
+
+	other := newReport()
+	HealthAnonymizer{Level: HealthAnonymizeHostnames, Key: []byte("other")}.Anonymize(&other)
+	if other.Minio.Info.Servers[0].Endpoint == server.Endpoint {
+		t.Fatal("expected different keys to anonymize hosts differently")
+	}
+}
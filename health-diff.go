@@ -0,0 +1,250 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DriveStateChange - a drive whose state differs between two reports,
+// OldState or NewState is empty if the drive is missing in that report.
+type DriveStateChange struct {
+	Endpoint   string `json:"endpoint"`
+	OldState   string `json:"oldState,omitempty"`
+	NewState   string `json:"newState,omitempty"`
+	OldHealing bool   `json:"oldHealing,omitempty"`
+	NewHealing bool   `json:"newHealing,omitempty"`
+}
+
+// ConfigKeyChange - a server config key whose value differs between two
+// reports. Key is of the form `subsys[:target] key`, Old or New is empty
+// if the key is not set in that report.
+type ConfigKeyChange struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// NodeVersion - the MinIO version running on a node.
+type NodeVersion struct {
+	Endpoint string `json:"endpoint"`
+	Version  string `json:"version"`
+	CommitID string `json:"commitID,omitempty"`
+}
+
+// NodeVersionChange - a node whose MinIO version differs between two reports.
+type NodeVersionChange struct {
+	Endpoint string `json:"endpoint"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+}
+
+// HealthInfoDiff - structured delta between two HealthInfo reports.
+type HealthInfoDiff struct {
+	OldTimeStamp time.Time `json:"oldTimestamp"`
+	NewTimeStamp time.Time `json:"newTimestamp"`
+
+	NodesAdded   []string `json:"nodesAdded,omitempty"`
+	NodesRemoved []string `json:"nodesRemoved,omitempty"`
+
+	Drives         []DriveStateChange  `json:"drives,omitempty"`
+	Config         []ConfigKeyChange   `json:"config,omitempty"`
+	VersionChanges []NodeVersionChange `json:"versionChanges,omitempty"`
+
+	// VersionDrift lists the versions of all nodes in the
+	// new report if they do not all run the same version.
+	VersionDrift []NodeVersion `json:"versionDrift,omitempty"`
+}
+
+// IsEmpty returns true if no differences were found.
+func (d HealthInfoDiff) IsEmpty() bool {
+	return len(d.NodesAdded) == 0 && len(d.NodesRemoved) == 0 &&
+		len(d.Drives) == 0 && len(d.Config) == 0 &&
+		len(d.VersionChanges) == 0 && len(d.VersionDrift) == 0
+}
+
+// DiffHealthInfo - compares two health reports, e.g. captured before and
+// after an incident, and returns the drives which changed state, the
+// server config keys which changed and the version drift between nodes.
+func DiffHealthInfo(old, new HealthInfo) HealthInfoDiff {
+	diff := HealthInfoDiff{
+		OldTimeStamp: old.TimeStamp,
+		NewTimeStamp: new.TimeStamp,
+	}
+
+	oldServers := serversByEndpoint(old)
+	newServers := serversByEndpoint(new)
+	for ep := range newServers {
+		if _, ok := oldServers[ep]; !ok {
+			diff.NodesAdded = append(diff.NodesAdded, ep)
+		}
+	}
+	for ep, o := range oldServers {
+		n, ok := newServers[ep]
+		if !ok {
+			diff.NodesRemoved = append(diff.NodesRemoved, ep)
+			continue
+		}
+		if o.Version != n.Version {
+			diff.VersionChanges = append(diff.VersionChanges, NodeVersionChange{
+				Endpoint: ep,
+				Old:      o.Version,
+				New:      n.Version,
+			})
+		}
+	}
+	sort.Strings(diff.NodesAdded)
+	sort.Strings(diff.NodesRemoved)
+	sort.Slice(diff.VersionChanges, func(i, j int) bool {
+		return diff.VersionChanges[i].Endpoint < diff.VersionChanges[j].Endpoint
+	})
+
+	versions := make(map[string]struct{})
+	for _, s := range newServers {
+		versions[s.Version] = struct{}{}
+	}
+	if len(versions) > 1 {
+		for ep, s := range newServers {
+			diff.VersionDrift = append(diff.VersionDrift, NodeVersion{
+				Endpoint: ep,
+				Version:  s.Version,
+				CommitID: s.CommitID,
+			})
+		}
+		sort.Slice(diff.VersionDrift, func(i, j int) bool {
+			return diff.VersionDrift[i].Endpoint < diff.VersionDrift[j].Endpoint
+		})
+	}
+
+	diff.Drives = diffDrives(drivesByEndpoint(old), drivesByEndpoint(new))
+	diff.Config = diffConfigKVs(configKVs(old.Minio.Config), configKVs(new.Minio.Config))
+	return diff
+}
+
+func serversByEndpoint(info HealthInfo) map[string]ServerInfo {
+	servers := make(map[string]ServerInfo, len(info.Minio.Info.Servers))
+	for _, s := range info.Minio.Info.Servers {
+		servers[s.Endpoint] = s
+	}
+	return servers
+}
+
+func drivesByEndpoint(info HealthInfo) map[string]Disk {
+	drives := make(map[string]Disk)
+	for _, s := range info.Minio.Info.Servers {
+		for _, d := range s.Drives {
+			drives[d.Endpoint] = d
+		}
+	}
+	return drives
+}
+
+func diffDrives(old, new map[string]Disk) []DriveStateChange {
+	var changes []DriveStateChange
+	for ep, o := range old {
+		n := new[ep]
+		if o.State != n.State || o.Healing != n.Healing {
+			changes = append(changes, DriveStateChange{
+				Endpoint:   ep,
+				OldState:   o.State,
+				NewState:   n.State,
+				OldHealing: o.Healing,
+				NewHealing: n.Healing,
+			})
+		}
+	}
+	for ep, n := range new {
+		if _, ok := old[ep]; !ok {
+			changes = append(changes, DriveStateChange{
+				Endpoint:   ep,
+				NewState:   n.State,
+				NewHealing: n.Healing,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Endpoint < changes[j].Endpoint
+	})
+	return changes
+}
+
+// configKVs - flattens the server config of a health report into
+// `subsys[:target] key` to value pairs. The config is reported as
+// the output of GetConfig, a generic JSON value is flattened instead.
+func configKVs(cfg MinioConfig) map[string]string {
+	kvs := make(map[string]string)
+	switch c := cfg.Config.(type) {
+	case string:
+		subSysConfigs, err := ParseServerConfigOutput(c)
+		if err != nil {
+			return kvs
+		}
+		for _, sc := range subSysConfigs {
+			prefix := sc.SubSystem
+			if sc.Target != "" {
+				prefix += SubSystemSeparator + sc.Target
+			}
+			for _, kv := range sc.KV {
+				v, _ := sc.Lookup(kv.Key)
+				kvs[prefix+KvSpaceSeparator+kv.Key] = v
+			}
+		}
+	default:
+		flattenConfig("", c, kvs)
+	}
+	return kvs
+}
+
+func flattenConfig(prefix string, v interface{}, kvs map[string]string) {
+	switch val := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		for k, e := range val {
+			key := k
+			if prefix != "" {
+				key = prefix + KvSpaceSeparator + k
+			}
+			flattenConfig(key, e, kvs)
+		}
+	case []interface{}:
+		for i, e := range val {
+			flattenConfig(fmt.Sprintf("%s[%d]", prefix, i), e, kvs)
+		}
+	default:
+		kvs[prefix] = fmt.Sprint(val)
+	}
+}
+
+func diffConfigKVs(old, new map[string]string) []ConfigKeyChange {
+	var changes []ConfigKeyChange
+	for k, o := range old {
+		if n, ok := new[k]; !ok || o != n {
+			changes = append(changes, ConfigKeyChange{Key: k, Old: o, New: n})
+		}
+	}
+	for k, n := range new {
+		if _, ok := old[k]; !ok {
+			changes = append(changes, ConfigKeyChange{Key: k, New: n})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
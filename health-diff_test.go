@@ -0,0 +1,72 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffHealthInfo(t *testing.T) {
+	server := func(ep, version string, drives ...Disk) ServerInfo {
+		return ServerInfo{Endpoint: ep, Version: version, Drives: drives}
+	}
+	report := func(cfg string, servers ...ServerInfo) HealthInfo {
+		var info HealthInfo
+		info.Minio.Config.Config = cfg
+		info.Minio.Info.Servers = servers
+		return info
+	}
+
+	old := report("api requests_max=100\nsite name=dc1",
+		server("node1:9000", "v1", Disk{Endpoint: "node1:9000/d1", State: "ok"}),
+		server("node2:9000", "v1", Disk{Endpoint: "node2:9000/d1", State: "ok"}),
+	)
+	new := report("api requests_max=200\nsite name=dc1 region=eu",
+		server("node1:9000", "v2", Disk{Endpoint: "node1:9000/d1", State: "ok"}),
+		server("node2:9000", "v1", Disk{Endpoint: "node2:9000/d1", State: "faulty", Healing: true}),
+	)
+
+	diff := DiffHealthInfo(old, new)
+
+	expectedDrives := []DriveStateChange{
+		{Endpoint: "node2:9000/d1", OldState: "ok", NewState: "faulty", NewHealing: true},
+	}
+	if !reflect.DeepEqual(diff.Drives, expectedDrives) {
+		t.Errorf("expected drive changes %v, got %v", expectedDrives, diff.Drives)
+	}
+
+	expectedConfig := []ConfigKeyChange{
+		{Key: "api requests_max", Old: "100", New: "200"},
+		{Key: "site region", New: "eu"},
+	}
+	if !reflect.DeepEqual(diff.Config, expectedConfig) {
+		t.Errorf("expected config changes %v, got %v", expectedConfig, diff.Config)
+	}
+
+	expectedVersions := []NodeVersionChange{{Endpoint: "node1:9000", Old: "v1", New: "v2"}}
+	if !reflect.DeepEqual(diff.VersionChanges, expectedVersions) {
+		t.Errorf("expected version changes %v, got %v", expectedVersions, diff.VersionChanges)
+	}
+	if len(diff.VersionDrift) != 2 {
+		t.Errorf("expected version drift between 2 nodes, got %v", diff.VersionDrift)
+	}
+
+	if d := DiffHealthInfo(old, old); !d.IsEmpty() {
+		t.Errorf("expected no differences, got %+v", d)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/minio/madmin-go/smart"
 	"github.com/shirou/gopsutil/v3/cpu"
 	diskhw "github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/host"
@@ -284,6 +285,9 @@ type SmartInfo struct {
 	Scsi   *SmartScsiInfo `json:"scsi,omitempty"`
 	Nvme   *SmartNvmeInfo `json:"nvme,omitempty"`
 	Ata    *SmartAtaInfo  `json:"ata,omitempty"`
+
+	// Health summarizes the S.M.A.R.T data of NVMe and ATA drives.
+	Health *smart.Health `json:"health,omitempty"`
 }
 
 // SmartNvmeInfo contains NVMe drive info
@@ -307,6 +311,8 @@ type SmartNvmeInfo struct {
 	DataUnitsWrittenBytes       *big.Int `json:"dataUnitsWrittenBytes,omitempty"`
 	HostReadCommands            *big.Int `json:"hostReadCommands,omitempty"`
 	HostWriteCommands           *big.Int `json:"hostWriteCommands,omitempty"`
+
+	HealthLog *smart.NVMeHealthLog `json:"healthLog,omitempty"`
 }
 
 // SmartScsiInfo contains SCSI drive Info
@@ -332,6 +338,8 @@ type SmartAtaInfo struct {
 	SmartSupportEnabled   bool   `json:"smartSupportEnabled,omitempty"`
 	ErrorLog              string `json:"smartErrorLog,omitempty"`
 	Transport             string `json:"transport,omitempty"`
+
+	Attributes smart.ATAAttributes `json:"attributes,omitempty"`
 }
 
 // PartitionStat - includes data from both shirou/psutil.diskHw.PartitionStat as well as SMART data
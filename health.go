@@ -32,6 +32,7 @@ import (
 
 	"github.com/minio/madmin-go/cgroup"
 	"github.com/minio/madmin-go/kernel"
+	"github.com/minio/madmin-go/smart"
 	"github.com/prometheus/procfs"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
@@ -194,6 +195,56 @@ func GetCPUs(ctx context.Context, addr string) CPUs {
 	}
 }
 
+// PressureStall is the share of time tasks were stalled on a resource,
+// as averages in percent over 10, 60 and 300 seconds and the total
+// stall time in microseconds.
+type PressureStall struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	Total  uint64  `json:"total"`
+}
+
+// ResourcePressure is the Linux pressure stall information of a resource,
+// Some covers time at least one task stalled, Full time all tasks stalled.
+type ResourcePressure struct {
+	Some *PressureStall `json:"some,omitempty"`
+	Full *PressureStall `json:"full,omitempty"`
+}
+
+// Pressure contains the pressure stall information of the cpu,
+// io and memory resources of a node.
+type Pressure struct {
+	CPU    ResourcePressure `json:"cpu"`
+	IO     ResourcePressure `json:"io"`
+	Memory ResourcePressure `json:"memory"`
+}
+
+// PressureInfo contains the pressure stall information of a node.
+type PressureInfo struct {
+	NodeCommon
+
+	Pressure
+}
+
+// GetPressureInfo returns the pressure stall information of the node,
+// only available on Linux kernels built with PSI support.
+func GetPressureInfo(ctx context.Context, addr string) PressureInfo {
+	p, err := getPressure()
+	if err != nil {
+		return PressureInfo{
+			NodeCommon: NodeCommon{
+				Addr:  addr,
+				Error: err.Error(),
+			},
+		}
+	}
+	return PressureInfo{
+		NodeCommon: NodeCommon{Addr: addr},
+		Pressure:   p,
+	}
+}
+
 // Partition contains disk partition's information.
 type Partition struct {
 	Error string `json:"error,omitempty"`
@@ -207,6 +258,10 @@ type Partition struct {
 	SpaceFree    uint64 `json:"space_free,omitempty"`
 	InodeTotal   uint64 `json:"inode_total,omitempty"`
 	InodeFree    uint64 `json:"inode_free,omitempty"`
+
+	// SMART is the S.M.A.R.T data of the drive holding the partition,
+	// only set for NVMe and ATA drives whose data could be read.
+	SMART *SmartInfo `json:"smart,omitempty"`
 }
 
 // Partitions contains all disk partitions information of a node.
@@ -238,6 +293,7 @@ func GetPartitions(ctx context.Context, addr string) Partitions {
 	}
 
 	partitions := []Partition{}
+	smartInfos := map[string]*SmartInfo{}
 
 	for i := range parts {
 		usage, err := disk.UsageWithContext(ctx, parts[i].Mountpoint)
@@ -257,6 +313,7 @@ func GetPartitions(ctx context.Context, addr string) Partitions {
 				SpaceFree:    usage.Free,
 				InodeTotal:   usage.InodesTotal,
 				InodeFree:    usage.InodesFree,
+				SMART:        getCachedSmartInfo(smartInfos, parts[i].Device),
 			})
 		}
 	}
@@ -267,6 +324,79 @@ func GetPartitions(ctx context.Context, addr string) Partitions {
 	}
 }
 
+// smartDevice returns the drive holding the partition device
+// and whether it is an NVMe drive, or "" for other devices.
+func smartDevice(device string) (string, bool) {
+	name := strings.TrimPrefix(device, "/dev/")
+	if name == device {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(name, "nvme"):
+		// nvme0n1p1 -> nvme0n1
+		if i := strings.IndexByte(name[len("nvme"):], 'p'); i >= 0 {
+			name = name[:len("nvme")+i]
+		}
+		return "/dev/" + name, true
+	case strings.HasPrefix(name, "sd"), strings.HasPrefix(name, "hd"):
+		// sda1 -> sda
+		return "/dev/" + strings.TrimRight(name, "0123456789"), false
+	}
+	return "", false
+}
+
+// getSmartInfo reads the S.M.A.R.T data of the drive holding
+// the partition device, it returns nil if it is not available.
+func getSmartInfo(device string) *SmartInfo {
+	dev, nvme := smartDevice(device)
+	if dev == "" {
+		return nil
+	}
+	if nvme {
+		id, log, err := smart.ReadNVMe(dev)
+		if err != nil {
+			return nil
+		}
+		health := log.Health()
+		return &SmartInfo{
+			Device: dev,
+			Nvme: &SmartNvmeInfo{
+				SerialNum:       id.SerialNum,
+				ModelNum:        id.ModelNum,
+				FirmwareVersion: id.Firmware,
+				HealthLog:       &log,
+			},
+			Health: &health,
+		}
+	}
+	id, attrs, err := smart.ReadATA(dev)
+	if err != nil {
+		return nil
+	}
+	health := attrs.Health()
+	return &SmartInfo{
+		Device: dev,
+		Ata: &SmartAtaInfo{
+			SerialNum:        id.SerialNum,
+			ModelNum:         id.ModelNum,
+			FirmwareRevision: id.Firmware,
+			Attributes:       attrs,
+		},
+		Health: &health,
+	}
+}
+
+// getCachedSmartInfo is getSmartInfo reading each drive only once.
+func getCachedSmartInfo(cache map[string]*SmartInfo, device string) *SmartInfo {
+	dev, _ := smartDevice(device)
+	if info, ok := cache[dev]; ok {
+		return info
+	}
+	info := getSmartInfo(device)
+	cache[dev] = info
+	return info
+}
+
 // OSInfo contains operating system's information.
 type OSInfo struct {
 	NodeCommon
@@ -349,6 +479,10 @@ func GetSysConfig(ctx context.Context, addr string) SysConfig {
 
 	sc.Config["time-info"] = TimeInfo{CurrentTime: time.Now().UTC()}
 
+	if sysctls := getSysctls(sysctlKeys()); len(sysctls) > 0 {
+		sc.Config[SysConfigSysctlKey] = sysctls
+	}
+
 	return sc
 }
 
@@ -466,6 +600,9 @@ type MemInfo struct {
 	// Limit will store cgroup limit if configured and
 	// less than Total, otherwise same as Total
 	Limit uint64 `json:"limit,omitempty"`
+	// LimitAvailable is the memory left before reaching the cgroup
+	// limit if configured, otherwise same as Available
+	LimitAvailable uint64 `json:"limit_available,omitempty"`
 }
 
 // Get the final system memory limit chosen by the user.
@@ -507,6 +644,17 @@ func GetMemInfo(ctx context.Context, addr string) MemInfo {
 		}
 	}
 
+	limitAvailable := meminfo.Available
+	if limits, err := cgroup.GetLimits(os.Getpid()); err == nil && limits.MemoryMax < meminfo.Total {
+		var left uint64
+		if limits.MemoryUsage < limits.MemoryMax {
+			left = limits.MemoryMax - limits.MemoryUsage
+		}
+		if left < limitAvailable {
+			limitAvailable = left
+		}
+	}
+
 	return MemInfo{
 		NodeCommon:     NodeCommon{Addr: addr},
 		Total:          meminfo.Total,
@@ -514,6 +662,111 @@ func GetMemInfo(ctx context.Context, addr string) MemInfo {
 		SwapSpaceTotal: swapinfo.Total,
 		SwapSpaceFree:  swapinfo.Free,
 		Limit:          getMemoryLimit(meminfo.Total),
+		LimitAvailable: limitAvailable,
+	}
+}
+
+// CgroupLimits contains the cgroup limits of the MinIO
+// process along with the capacity of the host.
+type CgroupLimits struct {
+	NodeCommon
+
+	cgroup.Limits
+	HostMemory uint64 `json:"host_memory"`
+	HostCPUs   int    `json:"host_cpus"`
+}
+
+// EffectiveMemory returns the memory the process may use.
+func (c CgroupLimits) EffectiveMemory() uint64 {
+	if c.MemoryMax < c.HostMemory {
+		return c.MemoryMax
+	}
+	return c.HostMemory
+}
+
+// EffectiveCPUs returns the number of CPUs the process may use.
+func (c CgroupLimits) EffectiveCPUs() float64 {
+	if c.CPUMax > 0 && c.CPUMax < float64(c.HostCPUs) {
+		return c.CPUMax
+	}
+	return float64(c.HostCPUs)
+}
+
+// GetCgroupLimits returns the cgroup limits of the current process.
+func GetCgroupLimits(ctx context.Context, addr string) CgroupLimits {
+	limits, err := cgroup.GetLimits(os.Getpid())
+	if err != nil {
+		return CgroupLimits{
+			NodeCommon: NodeCommon{
+				Addr:  addr,
+				Error: err.Error(),
+			},
+		}
+	}
+	cl := CgroupLimits{
+		NodeCommon: NodeCommon{Addr: addr},
+		Limits:     limits,
+		HostCPUs:   runtime.NumCPU(),
+	}
+	if meminfo, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		cl.HostMemory = meminfo.Total
+	}
+	if n, err := cpu.CountsWithContext(ctx, true); err == nil && n > 0 {
+		cl.HostCPUs = n
+	}
+	return cl
+}
+
+// NetInterface contains the link settings and error
+// counters of a single network interface.
+type NetInterface struct {
+	Name      string `json:"name"`
+	Driver    string `json:"driver,omitempty"`
+	OperState string `json:"oper_state,omitempty"`
+	Speed     int64  `json:"speed,omitempty"` // In Mbit/s, 0 if unknown
+	Duplex    string `json:"duplex,omitempty"`
+	MTU       int64  `json:"mtu,omitempty"`
+
+	// Bonding: BondMode and BondSlaves are set on bond
+	// interfaces, BondMaster on the interfaces enslaved.
+	BondMode   string   `json:"bond_mode,omitempty"`
+	BondSlaves []string `json:"bond_slaves,omitempty"`
+	BondMaster string   `json:"bond_master,omitempty"`
+
+	CarrierChanges int64 `json:"carrier_changes,omitempty"`
+
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+// NetInfo contains the network interfaces of a node, it is
+// collected for HealthDataTypeSysNetInfo.
+type NetInfo struct {
+	NodeCommon
+
+	Interfaces []NetInterface `json:"interfaces,omitempty"`
+}
+
+// GetNetInfo returns the link settings and error counters of
+// all network interfaces of the node, loopback excluded.
+func GetNetInfo(ctx context.Context, addr string) NetInfo {
+	ifaces, err := getNetInterfaces()
+	if err != nil {
+		return NetInfo{
+			NodeCommon: NodeCommon{
+				Addr:  addr,
+				Error: err.Error(),
+			},
+		}
+	}
+
+	return NetInfo{
+		NodeCommon: NodeCommon{Addr: addr},
+		Interfaces: ifaces,
 	}
 }
 
@@ -740,6 +993,9 @@ type SysInfo struct {
 	SysErrs        []SysErrors    `json:"errors,omitempty"`
 	SysServices    []SysServices  `json:"services,omitempty"`
 	SysConfig      []SysConfig    `json:"config,omitempty"`
+	NetInfo        []NetInfo      `json:"net,omitempty"`
+	PressureInfo   []PressureInfo `json:"pressure,omitempty"`
+	CgroupLimits   []CgroupLimits `json:"cgroup_limits,omitempty"`
 	KubernetesInfo KubernetesInfo `json:"kubernetes"`
 }
 
@@ -910,6 +1166,9 @@ const (
 	HealthDataTypeSysErrors   HealthDataType = "syserrors"
 	HealthDataTypeSysServices HealthDataType = "sysservices"
 	HealthDataTypeSysConfig   HealthDataType = "sysconfig"
+	HealthDataTypeSysPressure HealthDataType = "syspressure"
+	HealthDataTypeSysCgroup   HealthDataType = "syscgroup"
+	HealthDataTypeSysNetInfo  HealthDataType = "sysnetinfo" // network interfaces, see NetInfo
 )
 
 // HealthDataTypesMap - Map of Health datatypes
@@ -930,6 +1189,9 @@ var HealthDataTypesMap = map[string]HealthDataType{
 	"syserrors":   HealthDataTypeSysErrors,
 	"sysservices": HealthDataTypeSysServices,
 	"sysconfig":   HealthDataTypeSysConfig,
+	"syspressure": HealthDataTypeSysPressure,
+	"syscgroup":   HealthDataTypeSysCgroup,
+	"sysnetinfo":  HealthDataTypeSysNetInfo,
 }
 
 // HealthDataTypesList - List of health datatypes
@@ -947,6 +1209,9 @@ var HealthDataTypesList = []HealthDataType{
 	HealthDataTypeSysErrors,
 	HealthDataTypeSysServices,
 	HealthDataTypeSysConfig,
+	HealthDataTypeSysPressure,
+	HealthDataTypeSysCgroup,
+	HealthDataTypeSysNetInfo,
 	HealthDataTypePerfDrive,
 	HealthDataTypePerfObj,
 	HealthDataTypePerfNet,
@@ -974,6 +1239,8 @@ func (adm *AdminClient) ServerHealthInfo(ctx context.Context, types []HealthData
 		ctx, "GET", requestData{
 			relPath:     adminAPIPrefix + "/healthinfo",
 			queryValues: v,
+			category:    RequestCategoryStreaming,
+			minTimeout:  deadline,
 		},
 	)
 	if err != nil {
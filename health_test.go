@@ -0,0 +1,96 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHealthDataTypeSysNetInfo(t *testing.T) {
+	if HealthDataTypesMap[string(HealthDataTypeSysNetInfo)] != HealthDataTypeSysNetInfo {
+		t.Fatal("sysnetinfo missing in HealthDataTypesMap")
+	}
+	var listed bool
+	for _, typ := range HealthDataTypesList {
+		listed = listed || typ == HealthDataTypeSysNetInfo
+	}
+	if !listed {
+		t.Fatal("sysnetinfo missing in HealthDataTypesList")
+	}
+
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/healthinfo" || q.Get("sysnetinfo") != "true" || q.Get("syscpu") != "false" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(HealthInfoVersionStruct{Version: HealthInfoVersion})
+		w.(http.Flusher).Flush()
+		// Let the client read the version on its own.
+		time.Sleep(50 * time.Millisecond)
+		var info HealthInfo
+		info.Version = HealthInfoVersion
+		info.Sys.NetInfo = []NetInfo{{
+			NodeCommon: NodeCommon{Addr: "node1"},
+			Interfaces: []NetInterface{{Name: "eth0", Speed: 10000, Duplex: "full"}},
+		}}
+		enc.Encode(info)
+	})
+
+	resp, version, err := adm.ServerHealthInfo(context.Background(), []HealthDataType{HealthDataTypeSysNetInfo}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeResponse(resp)
+	if version != HealthInfoVersion {
+		t.Fatalf("unexpected version %q", version)
+	}
+	var info HealthInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Sys.NetInfo) != 1 || info.Sys.NetInfo[0].Interfaces[0].Speed != 10000 {
+		t.Errorf("unexpected network info %+v", info.Sys.NetInfo)
+	}
+}
+
+func TestSmartDevice(t *testing.T) {
+	testCases := []struct {
+		device string
+		drive  string
+		nvme   bool
+	}{
+		{"/dev/nvme0n1p2", "/dev/nvme0n1", true},
+		{"/dev/nvme1n1", "/dev/nvme1n1", true},
+		{"/dev/sda1", "/dev/sda", false},
+		{"/dev/sdb", "/dev/sdb", false},
+		{"/dev/mapper/root", "", false},
+		{"/dev/loop0", "", false},
+		{"tmpfs", "", false},
+	}
+	for _, tc := range testCases {
+		drive, nvme := smartDevice(tc.device)
+		if drive != tc.drive || nvme != tc.nvme {
+			t.Errorf("%s: expected %q %v, got %q %v", tc.device, tc.drive, tc.nvme, drive, nvme)
+		}
+	}
+}
@@ -18,18 +18,85 @@ package madmin
 
 import (
 	"context"
+	"encoding/json"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+// IAMScope selects the IAM entities to export or import,
+// when no entity type is selected all of them are included.
+type IAMScope struct {
+	Users           bool
+	Groups          bool
+	Policies        bool
+	ServiceAccounts bool
+	PolicyMappings  bool
+
+	// Prefix limits the scope to entities with
+	// a name starting with this prefix.
+	Prefix string
+}
+
+// addParams will add the scope parameters to url values.
+func (s IAMScope) addParams(u url.Values) {
+	if s.Users || s.Groups || s.Policies || s.ServiceAccounts || s.PolicyMappings {
+		u.Set("users", strconv.FormatBool(s.Users))
+		u.Set("groups", strconv.FormatBool(s.Groups))
+		u.Set("policies", strconv.FormatBool(s.Policies))
+		u.Set("svcaccts", strconv.FormatBool(s.ServiceAccounts))
+		u.Set("policymappings", strconv.FormatBool(s.PolicyMappings))
+	}
+	if s.Prefix != "" {
+		u.Set("prefix", s.Prefix)
+	}
+}
+
+// IAMExportOpts holds the options for ExportIAMWithOptions.
+type IAMExportOpts struct {
+	Scope IAMScope
+}
+
+// IAMImportOpts holds the options for ImportIAMWithOptions.
+type IAMImportOpts struct {
+	Scope IAMScope
+
+	// Merge only creates entities which do not exist yet,
+	// existing entities are left untouched instead of
+	// being overwritten.
+	Merge bool
+
+	// StreamingUpload uploads large seekable content, e.g. files,
+	// with a streaming signature instead of buffering it in memory.
+	// Only set it for servers accepting aws-chunked payloads.
+	StreamingUpload bool
+}
+
+// IAMEntity identifies a single IAM entity in an import report.
+type IAMEntity struct {
+	Type string `json:"type"` // One of "user", "group", "policy", "svcacct" or "policymapping"
+	Name string `json:"name"`
+	// Reason for skipping the entity or the conflict.
+	Reason string `json:"reason,omitempty"`
+}
+
+// IAMImportReport describes the changes made by an IAM import.
+type IAMImportReport struct {
+	Created   []IAMEntity `json:"created,omitempty"`
+	Updated   []IAMEntity `json:"updated,omitempty"`
+	Skipped   []IAMEntity `json:"skipped,omitempty"`
+	Conflicts []IAMEntity `json:"conflicts,omitempty"`
+}
+
 // ExportIAM makes an admin call to export IAM data
 func (adm *AdminClient) ExportIAM(ctx context.Context) (io.ReadCloser, error) {
 	path := adminAPIPrefix + "/export-iam"
 
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet, requestData{
-			relPath: path,
+			relPath:  path,
+			category: RequestCategoryTransfer,
 		},
 	)
 	if err != nil {
@@ -45,25 +112,83 @@ func (adm *AdminClient) ExportIAM(ctx context.Context) (io.ReadCloser, error) {
 
 // ImportIAM makes an admin call to setup IAM  from imported content
 func (adm *AdminClient) ImportIAM(ctx context.Context, contentReader io.ReadCloser) error {
-	content, err := ioutil.ReadAll(contentReader)
+	reqData := requestData{
+		relPath:  adminAPIPrefix + "/import-iam",
+		category: RequestCategoryTransfer,
+	}
+	if err := reqData.setContent(contentReader, false); err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
 	if err != nil {
 		return err
 	}
 
-	path := adminAPIPrefix + "/import-iam"
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// ExportIAMWithOptions makes an admin call to export the IAM data
+// within the scope of opts.
+func (adm *AdminClient) ExportIAMWithOptions(ctx context.Context, opts IAMExportOpts) (io.ReadCloser, error) {
+	queryValues := url.Values{}
+	opts.Scope.addParams(queryValues)
+
 	resp, err := adm.executeMethod(ctx,
-		http.MethodPut, requestData{
-			relPath: path,
-			content: content,
+		http.MethodGet, requestData{
+			relPath:     adminAPIPrefix + "/export-iam",
+			queryValues: queryValues,
+			category:    RequestCategoryTransfer,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+	return resp.Body, nil
+}
+
+// ImportIAMWithOptions makes an admin call to setup IAM from the imported
+// content within the scope of opts, returning a report of the entities
+// created, updated, skipped or conflicting.
+func (adm *AdminClient) ImportIAMWithOptions(ctx context.Context, contentReader io.ReadCloser, opts IAMImportOpts) (IAMImportReport, error) {
+	queryValues := url.Values{}
+	opts.Scope.addParams(queryValues)
+	if opts.Merge {
+		queryValues.Set("merge", "true")
+	}
+	queryValues.Set("report", "true")
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/import-iam",
+		queryValues: queryValues,
+		category:    RequestCategoryTransfer,
+	}
+	if err := reqData.setContent(contentReader, opts.StreamingUpload); err != nil {
+		return IAMImportReport{}, err
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
 	defer closeResponse(resp)
 	if err != nil {
-		return err
+		return IAMImportReport{}, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return httpRespToErrorResponse(resp)
+		return IAMImportReport{}, httpRespToErrorResponse(resp)
 	}
-	return nil
+
+	var report IAMImportReport
+	if err = json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return IAMImportReport{}, err
+	}
+	return report, nil
 }
@@ -0,0 +1,122 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIAMScopeParams(t *testing.T) {
+	testCases := []struct {
+		scope    IAMScope
+		expected url.Values
+	}{
+		{IAMScope{}, url.Values{}},
+		{IAMScope{Prefix: "app-"}, url.Values{"prefix": {"app-"}}},
+		{
+			IAMScope{Users: true, Policies: true},
+			url.Values{
+				"users":          {"true"},
+				"groups":         {"false"},
+				"policies":       {"true"},
+				"svcaccts":       {"false"},
+				"policymappings": {"false"},
+			},
+		},
+	}
+	for i, testCase := range testCases {
+		values := url.Values{}
+		testCase.scope.addParams(values)
+		if !reflect.DeepEqual(values, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, values)
+		}
+	}
+}
+
+func TestExportIAMWithOptions(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/export-iam" || q.Get("groups") != "true" || q.Get("users") != "false" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		w.Write([]byte("iam-archive"))
+	})
+
+	rc, err := adm.ExportIAMWithOptions(context.Background(), IAMExportOpts{Scope: IAMScope{Groups: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "iam-archive" {
+		t.Fatalf("unexpected export %q", data)
+	}
+
+	_, err = adm.ExportIAMWithOptions(context.Background(), IAMExportOpts{Scope: IAMScope{Users: true}})
+	if ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestImportIAMWithOptions(t *testing.T) {
+	expected := IAMImportReport{
+		Created:   []IAMEntity{{Type: "user", Name: "app-reader"}},
+		Skipped:   []IAMEntity{{Type: "policy", Name: "app-rw", Reason: "already exists"}},
+		Conflicts: []IAMEntity{{Type: "policymapping", Name: "app-writer", Reason: "policy not found"}},
+	}
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPut || r.URL.Path != "/minio/admin/v3/import-iam" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("report") != "true" || q.Get("merge") != "true" || q.Get("prefix") != "app-" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		if data, _ := ioutil.ReadAll(r.Body); string(data) != "iam-archive" {
+			writeTestError(w, http.StatusBadRequest, "XMinioAdminInvalidIAMImport")
+			return
+		}
+		json.NewEncoder(w).Encode(expected)
+	})
+
+	opts := IAMImportOpts{Scope: IAMScope{Prefix: "app-"}, Merge: true}
+	report, err := adm.ImportIAMWithOptions(context.Background(), ioutil.NopCloser(strings.NewReader("iam-archive")), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, report)
+	}
+
+	_, err = adm.ImportIAMWithOptions(context.Background(), ioutil.NopCloser(strings.NewReader("other")), opts)
+	if ToErrorResponse(err).Code != "XMinioAdminInvalidIAMImport" {
+		t.Fatalf("expected XMinioAdminInvalidIAMImport, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Headers of idempotent admin requests. A mutating request carrying an
+// idempotency key is applied once by the server, later requests with the
+// same key are answered with the response of the first one and marked
+// as replayed.
+const (
+	IdempotencyKeyHeader      = "X-Minio-Idempotency-Key"
+	IdempotencyReplayedHeader = "X-Minio-Idempotency-Replayed"
+)
+
+// isIdempotencyReplay returns true if resp was replayed
+// from an earlier request with the same idempotency key.
+func isIdempotencyReplay(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(IdempotencyReplayedHeader) == "true"
+}
+
+// autoIdempotencyKey returns a new random idempotency key if the client
+// generates them, see Options.AutoIdempotencyKeys, and "" otherwise.
+func (adm *AdminClient) autoIdempotencyKey() string {
+	if !adm.autoIdempotencyKeys {
+		return ""
+	}
+	var key [16]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(key[:])
+}
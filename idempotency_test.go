@@ -0,0 +1,91 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestAutoIdempotencyKeys(t *testing.T) {
+	keys := map[string][]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get(IdempotencyKeyHeader))
+		if len(keys[r.URL.Path])%2 == 1 {
+			// Fail the first attempt of every call.
+			writeTestError(w, http.StatusServiceUnavailable, "SlowDown")
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/start-job") {
+			w.Write([]byte(`{"id":"job1"}`))
+		}
+	}))
+	defer srv.Close()
+
+	newClient := func(auto bool) *AdminClient {
+		adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+			Creds:               credentials.NewStaticV4("minio", "minio123", ""),
+			RetryPolicy:         &RetryPolicy{MaxRetry: 2, Unit: time.Millisecond},
+			AutoIdempotencyKeys: auto,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return adm
+	}
+
+	ctx := context.Background()
+	adm := newClient(true)
+	if err := adm.AddUser(ctx, "alice", "secret123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.SetPolicy(ctx, "readwrite", "alice", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.SetPolicy(ctx, "readonly", "alice", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := adm.StartBatchJob(ctx, "replicate: {}"); err != nil {
+		t.Fatal(err)
+	}
+	for path, k := range keys {
+		for i := 0; i < len(k); i += 2 {
+			if k[i] == "" || k[i] != k[i+1] {
+				t.Errorf("%s: expected the same key on retry, got %q and %q", path, k[i], k[i+1])
+			}
+		}
+	}
+	if k := keys["/minio/admin/v3/set-user-or-group-policy"]; len(k) != 4 || k[0] == k[2] {
+		t.Errorf("expected a new key per call, got %v", k)
+	}
+
+	keys = map[string][]string{}
+	if err := newClient(false).AddUser(ctx, "alice", "secret123"); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys["/minio/admin/v3/add-user"] {
+		if key != "" {
+			t.Errorf("expected no idempotency key, got %q", key)
+		}
+	}
+}
@@ -22,17 +22,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/set"
 )
 
 // SetIDPConfig - set idp config to server.
 func (adm *AdminClient) SetIDPConfig(ctx context.Context, cfgType, cfgName, cfgData string) (restart bool, err error) {
-	encBytes, err := EncryptData(adm.getSecretKey(), []byte(cfgData))
-	if err != nil {
-		return false, err
-	}
-
 	queryParams := make(url.Values, 2)
 	queryParams.Set("type", cfgType)
 	queryParams.Set("name", cfgName)
@@ -43,7 +39,8 @@ func (adm *AdminClient) SetIDPConfig(ctx context.Context, cfgType, cfgName, cfgD
 		customHeaders: h,
 		relPath:       adminAPIPrefix + "/idp-config",
 		queryValues:   queryParams,
-		content:       encBytes,
+		content:       []byte(cfgData),
+		encrypt:       true,
 	}
 
 	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
@@ -179,3 +176,85 @@ func (adm *AdminClient) DeleteIDPConfig(ctx context.Context, cfgType, cfgName st
 
 	return resp.Header.Get(ConfigAppliedHeader) != ConfigAppliedTrue, nil
 }
+
+// PolicyEntitiesQuery - selects the LDAP users, groups and policies to
+// return the policy mappings for, an empty query returns all mappings.
+type PolicyEntitiesQuery struct {
+	Users  []string // LDAP user DNs
+	Groups []string // LDAP group DNs
+	Policy []string // Policy names
+}
+
+// LDAPPolicyMapping - the policies mapped to a single LDAP DN.
+type LDAPPolicyMapping struct {
+	DN       string   `json:"dn"`
+	IsGroup  bool     `json:"isGroup"`
+	Policies []string `json:"policies"`
+}
+
+// LDAPPolicyEntities - the LDAP DNs mapped to a single policy.
+type LDAPPolicyEntities struct {
+	Policy string   `json:"policy"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LDAPPolicyEntitiesResult - result of GetLDAPPolicyEntities.
+type LDAPPolicyEntitiesResult struct {
+	Timestamp      time.Time            `json:"timestamp"`
+	UserMappings   []LDAPPolicyMapping  `json:"userMappings,omitempty"`
+	GroupMappings  []LDAPPolicyMapping  `json:"groupMappings,omitempty"`
+	PolicyMappings []LDAPPolicyEntities `json:"policyMappings,omitempty"`
+}
+
+// GetLDAPPolicyEntities - returns the policy mappings of the LDAP users,
+// groups and policies selected by query.
+func (adm *AdminClient) GetLDAPPolicyEntities(ctx context.Context, query PolicyEntitiesQuery) (r LDAPPolicyEntitiesResult, err error) {
+	queryParams := make(url.Values)
+	queryParams["user"] = query.Users
+	queryParams["group"] = query.Groups
+	queryParams["policy"] = query.Policy
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/idp/ldap/policy-entities",
+		queryValues: queryParams,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	content, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal(content, &r)
+	return r, err
+}
+
+// ListLDAPPolicyMappings - returns the policies mapped to every
+// LDAP user and group DN, users are listed before groups.
+func (adm *AdminClient) ListLDAPPolicyMappings(ctx context.Context) ([]LDAPPolicyMapping, error) {
+	r, err := adm.GetLDAPPolicyEntities(ctx, PolicyEntitiesQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]LDAPPolicyMapping, 0, len(r.UserMappings)+len(r.GroupMappings))
+	for _, m := range r.UserMappings {
+		m.IsGroup = false
+		mappings = append(mappings, m)
+	}
+	for _, m := range r.GroupMappings {
+		m.IsGroup = true
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
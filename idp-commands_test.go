@@ -0,0 +1,94 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetLDAPPolicyEntities(t *testing.T) {
+	expected := LDAPPolicyEntitiesResult{
+		PolicyMappings: []LDAPPolicyEntities{{
+			Policy: "readwrite",
+			Users:  []string{"uid=alice,dc=example,dc=org"},
+			Groups: []string{"cn=devs,dc=example,dc=org"},
+		}},
+	}
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/idp/ldap/policy-entities" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if !reflect.DeepEqual(q["policy"], []string{"readwrite"}) || !reflect.DeepEqual(q["group"], []string{"cn=devs,dc=example,dc=org"}) || q.Has("user") {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		writeEncryptedJSON(t, w, expected)
+	})
+
+	query := PolicyEntitiesQuery{
+		Groups: []string{"cn=devs,dc=example,dc=org"},
+		Policy: []string{"readwrite"},
+	}
+	r, err := adm.GetLDAPPolicyEntities(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, r)
+	}
+
+	_, err = adm.GetLDAPPolicyEntities(context.Background(), PolicyEntitiesQuery{Users: []string{"uid=bob"}})
+	if ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestListLDAPPolicyMappings(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()) != 0 {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		// isGroup is not set by the server, it is derived
+		// from the list the mapping is returned in.
+		writeEncryptedJSON(t, w, LDAPPolicyEntitiesResult{
+			UserMappings: []LDAPPolicyMapping{
+				{DN: "uid=alice,dc=example,dc=org", IsGroup: true, Policies: []string{"readwrite"}},
+			},
+			GroupMappings: []LDAPPolicyMapping{
+				{DN: "cn=devs,dc=example,dc=org", Policies: []string{"readonly", "diagnostics"}},
+			},
+		})
+	})
+
+	mappings, err := adm.ListLDAPPolicyMappings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []LDAPPolicyMapping{
+		{DN: "uid=alice,dc=example,dc=org", Policies: []string{"readwrite"}},
+		{DN: "cn=devs,dc=example,dc=org", IsGroup: true, Policies: []string{"readonly", "diagnostics"}},
+	}
+	if !reflect.DeepEqual(mappings, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, mappings)
+	}
+}
@@ -0,0 +1,215 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OpenID provider config keys.
+const (
+	OpenIDConfigURL    = "config_url"
+	OpenIDClientID     = "client_id"
+	OpenIDClientSecret = "client_secret"
+	OpenIDClaimName    = "claim_name"
+	OpenIDClaimPrefix  = "claim_prefix"
+	OpenIDScopes       = "scopes"
+	OpenIDRedirectURI  = "redirect_uri"
+	OpenIDRolePolicy   = "role_policy"
+	OpenIDDisplayName  = "display_name"
+
+	openIDCfgType = "openid"
+)
+
+// ErrOpenIDProviderExists and ErrOpenIDProviderNotFound are returned when
+// adding an already configured or updating an unknown OpenID provider.
+var (
+	ErrOpenIDProviderExists   = errors.New("madmin: openid provider already exists")
+	ErrOpenIDProviderNotFound = errors.New("madmin: openid provider not found")
+)
+
+// OpenIDProviderConfig - typed configuration of an OpenID provider.
+type OpenIDProviderConfig struct {
+	// Name of the provider, empty for the default provider.
+	Name string `json:"name,omitempty"`
+
+	ConfigURL    string   `json:"configURL"`
+	ClientID     string   `json:"clientID"`
+	ClientSecret string   `json:"clientSecret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	ClaimName    string   `json:"claimName,omitempty"`
+	ClaimPrefix  string   `json:"claimPrefix,omitempty"`
+	RedirectURI  string   `json:"redirectURI,omitempty"`
+	RolePolicy   string   `json:"rolePolicy,omitempty"`
+	DisplayName  string   `json:"displayName,omitempty"`
+}
+
+// kvString returns the provider configuration as space separated
+// key=value pairs, including empty values so that an update resets them.
+func (c OpenIDProviderConfig) kvString() string {
+	return configKVString([]ConfigKV{
+		{Key: OpenIDConfigURL, Value: c.ConfigURL},
+		{Key: OpenIDClientID, Value: c.ClientID},
+		{Key: OpenIDClientSecret, Value: c.ClientSecret},
+		{Key: OpenIDScopes, Value: strings.Join(c.Scopes, ",")},
+		{Key: OpenIDClaimName, Value: c.ClaimName},
+		{Key: OpenIDClaimPrefix, Value: c.ClaimPrefix},
+		{Key: OpenIDRedirectURI, Value: c.RedirectURI},
+		{Key: OpenIDRolePolicy, Value: c.RolePolicy},
+		{Key: OpenIDDisplayName, Value: c.DisplayName},
+	})
+}
+
+func (c OpenIDProviderConfig) validate() error {
+	if c.ConfigURL == "" {
+		return ErrInvalidArgument("openid config URL cannot be empty")
+	}
+	if c.ClientID == "" {
+		return ErrInvalidArgument("openid client ID cannot be empty")
+	}
+	if c.ClaimName != "" && c.RolePolicy != "" {
+		return ErrInvalidArgument("openid claim name and role policy are mutually exclusive")
+	}
+	return nil
+}
+
+// openIDProviderFromIDPConfig converts the generic IDP config
+// returned by the server into a typed OpenID provider config.
+func openIDProviderFromIDPConfig(cfg IDPConfig) OpenIDProviderConfig {
+	c := OpenIDProviderConfig{Name: cfg.Name}
+	if c.Name == Default {
+		c.Name = ""
+	}
+	for _, info := range cfg.Info {
+		if !info.IsCfg {
+			continue
+		}
+		v := SanitizeValue(info.Value)
+		switch info.Key {
+		case OpenIDConfigURL:
+			c.ConfigURL = v
+		case OpenIDClientID:
+			c.ClientID = v
+		case OpenIDClientSecret:
+			c.ClientSecret = v
+		case OpenIDScopes:
+			if v != "" {
+				c.Scopes = strings.Split(v, ",")
+			}
+		case OpenIDClaimName:
+			c.ClaimName = v
+		case OpenIDClaimPrefix:
+			c.ClaimPrefix = v
+		case OpenIDRedirectURI:
+			c.RedirectURI = v
+		case OpenIDRolePolicy:
+			c.RolePolicy = v
+		case OpenIDDisplayName:
+			c.DisplayName = v
+		}
+	}
+	return c
+}
+
+// openIDProviderExists - returns whether the named OpenID provider is configured.
+func (adm *AdminClient) openIDProviderExists(ctx context.Context, name string) (bool, error) {
+	if name == "" {
+		name = Default
+	}
+	items, err := adm.ListIDPConfig(ctx, openIDCfgType)
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if item.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddOpenIDProvider - adds a new OpenID provider, fails with
+// ErrOpenIDProviderExists if a provider with the same name exists.
+func (adm *AdminClient) AddOpenIDProvider(ctx context.Context, cfg OpenIDProviderConfig) (restart bool, err error) {
+	if err = cfg.validate(); err != nil {
+		return false, err
+	}
+	exists, err := adm.openIDProviderExists(ctx, cfg.Name)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, fmt.Errorf("%w: %q", ErrOpenIDProviderExists, cfg.Name)
+	}
+	return adm.SetIDPConfig(ctx, openIDCfgType, cfg.Name, cfg.kvString())
+}
+
+// UpdateOpenIDProvider - replaces the configuration of an existing OpenID
+// provider, fails with ErrOpenIDProviderNotFound if it does not exist.
+func (adm *AdminClient) UpdateOpenIDProvider(ctx context.Context, cfg OpenIDProviderConfig) (restart bool, err error) {
+	if err = cfg.validate(); err != nil {
+		return false, err
+	}
+	exists, err := adm.openIDProviderExists(ctx, cfg.Name)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, fmt.Errorf("%w: %q", ErrOpenIDProviderNotFound, cfg.Name)
+	}
+	return adm.SetIDPConfig(ctx, openIDCfgType, cfg.Name, cfg.kvString())
+}
+
+// RemoveOpenIDProvider - removes the named OpenID provider,
+// an empty name removes the default provider.
+func (adm *AdminClient) RemoveOpenIDProvider(ctx context.Context, name string) (restart bool, err error) {
+	if name == "" {
+		name = Default
+	}
+	return adm.DeleteIDPConfig(ctx, openIDCfgType, name)
+}
+
+// GetOpenIDProvider - returns the configuration of the named OpenID
+// provider, an empty name returns the default provider.
+func (adm *AdminClient) GetOpenIDProvider(ctx context.Context, name string) (OpenIDProviderConfig, error) {
+	cfg, err := adm.GetIDPConfig(ctx, openIDCfgType, name)
+	if err != nil {
+		return OpenIDProviderConfig{}, err
+	}
+	return openIDProviderFromIDPConfig(cfg), nil
+}
+
+// ListOpenIDProviders - returns the configuration of all OpenID providers.
+func (adm *AdminClient) ListOpenIDProviders(ctx context.Context) ([]OpenIDProviderConfig, error) {
+	items, err := adm.ListIDPConfig(ctx, openIDCfgType)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]OpenIDProviderConfig, 0, len(items))
+	for _, item := range items {
+		cfg, err := adm.GetIDPConfig(ctx, openIDCfgType, item.Name)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, openIDProviderFromIDPConfig(cfg))
+	}
+	return providers, nil
+}
@@ -0,0 +1,55 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpenIDProviderConfigKV(t *testing.T) {
+	cfg := OpenIDProviderConfig{
+		Name:        "okta",
+		ConfigURL:   "https://okta.example.com/.well-known/openid-configuration",
+		ClientID:    "minio",
+		Scopes:      []string{"openid", "groups"},
+		ClaimName:   "policy",
+		DisplayName: "Okta SSO",
+	}
+
+	expected := `config_url=https://okta.example.com/.well-known/openid-configuration client_id=minio client_secret="" ` +
+		`scopes=openid,groups claim_name=policy claim_prefix="" redirect_uri="" role_policy="" display_name="Okta SSO"`
+	if kv := cfg.kvString(); kv != expected {
+		t.Fatalf("expected %s, got %s", expected, kv)
+	}
+
+	idpCfg := IDPConfig{
+		Type: "openid",
+		Name: "okta",
+		Info: []IDPCfgInfo{
+			{Key: OpenIDConfigURL, Value: cfg.ConfigURL, IsCfg: true},
+			{Key: OpenIDClientID, Value: cfg.ClientID, IsCfg: true},
+			{Key: OpenIDScopes, Value: "openid,groups", IsCfg: true},
+			{Key: OpenIDClaimName, Value: cfg.ClaimName, IsCfg: true},
+			{Key: OpenIDDisplayName, Value: `"Okta SSO"`, IsCfg: true},
+			{Key: "enable", Value: "on"},
+		},
+	}
+	if got := openIDProviderFromIDPConfig(idpCfg); !reflect.DeepEqual(got, cfg) {
+		t.Fatalf("expected %#v, got %#v", cfg, got)
+	}
+}
@@ -0,0 +1,97 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// InFlightRequest - an S3 request currently executing on a node.
+type InFlightRequest struct {
+	API       string        `json:"api"`
+	Bucket    string        `json:"bucket,omitempty"`
+	Object    string        `json:"object,omitempty"`
+	AccessKey string        `json:"accessKey,omitempty"`
+	ClientIP  string        `json:"clientIP"`
+	Started   time.Time     `json:"started"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// InFlightRequests - in-flight S3 requests of a node.
+type InFlightRequests struct {
+	Node     string            `json:"node"`
+	Time     time.Time         `json:"time"`
+	Requests []InFlightRequest `json:"requests"`
+	Err      error             `json:"-"`
+}
+
+// Longest returns the n longest running requests, longest first.
+func (r InFlightRequests) Longest(n int) []InFlightRequest {
+	reqs := make([]InFlightRequest, len(r.Requests))
+	copy(reqs, r.Requests)
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Duration > reqs[j].Duration
+	})
+	if n >= 0 && n < len(reqs) {
+		reqs = reqs[:n]
+	}
+	return reqs
+}
+
+// InFlightRequests - returns a snapshot of the S3 requests currently
+// executing on node, or on all nodes if node is empty.
+func (adm *AdminClient) InFlightRequests(ctx context.Context, node string) ([]InFlightRequests, error) {
+	queryValues := url.Values{}
+	if node != "" {
+		queryValues.Set("node", node)
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/inflight-requests",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var results []struct {
+		InFlightRequests
+		Err string `json:"error,omitempty"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	nodes := make([]InFlightRequests, 0, len(results))
+	for _, r := range results {
+		if r.Err != "" {
+			r.InFlightRequests.Err = errors.New(r.Err)
+		}
+		nodes = append(nodes, r.InFlightRequests)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,79 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInFlightRequestsNodeError(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"node":"node1","requests":[{"api":"GetObject","duration":5}]},` +
+			`{"node":"node2","error":"node offline"}]`))
+	})
+
+	nodes, err := adm.InFlightRequests(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].Err != nil || len(nodes[0].Requests) != 1 {
+		t.Fatalf("unexpected nodes %+v", nodes)
+	}
+	if nodes[1].Err == nil || nodes[1].Err.Error() != "node offline" {
+		t.Fatalf("expected node error, got %v", nodes[1].Err)
+	}
+}
+
+func TestInFlightRequests(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/inflight-requests" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if r.URL.Query().Get("node") != "node1" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchNode")
+			return
+		}
+		json.NewEncoder(w).Encode([]InFlightRequests{{
+			Node: "node1",
+			Requests: []InFlightRequest{
+				{API: "GetObject", Bucket: "photos", Duration: time.Second},
+				{API: "PutObject", Bucket: "photos", Duration: time.Minute},
+			},
+		}})
+	})
+
+	ctx := context.Background()
+	nodes, err := adm.InFlightRequests(ctx, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Node != "node1" || nodes[0].Err != nil {
+		t.Fatalf("unexpected nodes %+v", nodes)
+	}
+	if longest := nodes[0].Longest(1); len(longest) != 1 || longest[0].API != "PutObject" {
+		t.Fatalf("unexpected longest requests %+v", longest)
+	}
+
+	if _, err = adm.InFlightRequests(ctx, "node9"); ToErrorResponse(err).Code != "XMinioAdminNoSuchNode" {
+		t.Errorf("expected XMinioAdminNoSuchNode, got %v", err)
+	}
+}
@@ -19,7 +19,11 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"time"
 )
 
@@ -219,6 +223,66 @@ func (adm *AdminClient) DataUsageInfo(ctx context.Context) (DataUsageInfo, error
 	return dataUsageInfo, nil
 }
 
+// DataUsageHistory - data usage snapshots of past scanner
+// cycles, ordered from the oldest to the latest.
+type DataUsageHistory []DataUsageInfo
+
+// GrowthRate - returns the growth of the total object size in
+// bytes per day between the oldest and the latest snapshot.
+func (h DataUsageHistory) GrowthRate() float64 {
+	return h.growthRate(func(u DataUsageInfo) uint64 { return u.ObjectsTotalSize })
+}
+
+// BucketGrowthRate - returns the growth of the size of bucket in
+// bytes per day between the oldest and the latest snapshot.
+func (h DataUsageHistory) BucketGrowthRate(bucket string) float64 {
+	return h.growthRate(func(u DataUsageInfo) uint64 { return u.BucketsUsage[bucket].Size })
+}
+
+func (h DataUsageHistory) growthRate(size func(DataUsageInfo) uint64) float64 {
+	if len(h) < 2 {
+		return 0
+	}
+	first, last := h[0], h[len(h)-1]
+	days := last.LastUpdate.Sub(first.LastUpdate).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return (float64(size(last)) - float64(size(first))) / days
+}
+
+// DataUsageHistory - returns up to n of the latest data usage
+// snapshots recorded by the scanner, oldest first.
+func (adm *AdminClient) DataUsageHistory(ctx context.Context, n int) (DataUsageHistory, error) {
+	if n <= 0 {
+		return nil, ErrInvalidArgument("number of snapshots must be positive")
+	}
+	queryValues := url.Values{}
+	queryValues.Set("history", strconv.Itoa(n))
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/datausageinfo",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var history DataUsageHistory
+	if err = json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, err
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].LastUpdate.Before(history[j].LastUpdate)
+	})
+	return history, nil
+}
+
 // InfoMessage container to hold server admin related information.
 type InfoMessage struct {
 	Mode         string             `json:"mode,omitempty"`
@@ -408,3 +472,71 @@ func (adm *AdminClient) ServerInfo(ctx context.Context) (InfoMessage, error) {
 
 	return message, nil
 }
+
+// ServerInfoStreamResult holds the properties of a single server as
+// returned by ServerInfoStream, or the error encountered.
+type ServerInfoStreamResult struct {
+	Server ServerProperties `json:"server"`
+	Err    error            `json:"error,omitempty"`
+}
+
+// ServerInfoStream - Connect to a minio server and call Server Admin Info
+// Management API in streaming mode. Properties of each server are sent on
+// the returned channel as soon as that server responds, instead of waiting
+// for the entire cluster information to be aggregated.
+func (adm *AdminClient) ServerInfoStream(ctx context.Context) <-chan ServerInfoStreamResult {
+	infoCh := make(chan ServerInfoStreamResult)
+
+	go func(infoCh chan<- ServerInfoStreamResult) {
+		defer close(infoCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case infoCh <- ServerInfoStreamResult{Err: err}:
+			}
+		}
+
+		queryValues := url.Values{}
+		queryValues.Set("stream", "true")
+
+		resp, err := adm.executeMethod(ctx,
+			http.MethodGet,
+			requestData{
+				relPath:     adminAPIPrefix + "/info",
+				queryValues: queryValues,
+				category:    RequestCategoryStreaming,
+			},
+		)
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		// Check response http status code
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var server ServerProperties
+			if err = dec.Decode(&server); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case infoCh <- ServerInfoStreamResult{Server: server}:
+			}
+		}
+	}(infoCh)
+
+	// Returns the info channel, for caller to start reading from.
+	return infoCh
+}
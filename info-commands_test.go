@@ -0,0 +1,80 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDataUsageHistoryGrowthRate(t *testing.T) {
+	now := time.Now()
+	history := DataUsageHistory{
+		{LastUpdate: now.Add(-48 * time.Hour), ObjectsTotalSize: 1000, BucketsUsage: map[string]BucketUsageInfo{"b": {Size: 100}}},
+		{LastUpdate: now.Add(-24 * time.Hour), ObjectsTotalSize: 1500},
+		{LastUpdate: now, ObjectsTotalSize: 3000, BucketsUsage: map[string]BucketUsageInfo{"b": {Size: 50}}},
+	}
+	if rate := history.GrowthRate(); rate != 1000 {
+		t.Errorf("expected growth of 1000 bytes per day, got %f", rate)
+	}
+	if rate := history.BucketGrowthRate("b"); rate != -25 {
+		t.Errorf("expected bucket growth of -25 bytes per day, got %f", rate)
+	}
+	if rate := history[:1].GrowthRate(); rate != 0 {
+		t.Errorf("expected no growth for a single snapshot, got %f", rate)
+	}
+}
+
+func TestServerInfoStream(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/info" || r.URL.Query().Get("stream") != "true" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(ServerProperties{Endpoint: "node1:9000", State: "online"})
+		enc.Encode(ServerProperties{Endpoint: "node2:9000", State: "offline"})
+	})
+
+	var servers []ServerProperties
+	for res := range adm.ServerInfoStream(context.Background()) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		servers = append(servers, res.Server)
+	}
+	if len(servers) != 2 || servers[0].Endpoint != "node1:9000" || servers[1].State != "offline" {
+		t.Fatalf("unexpected servers %+v", servers)
+	}
+}
+
+func TestServerInfoStreamError(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+
+	var results []ServerInfoStreamResult
+	for res := range adm.ServerInfoStream(context.Background()) {
+		results = append(results, res)
+	}
+	if len(results) != 1 || ToErrorResponse(results[0].Err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %+v", results)
+	}
+}
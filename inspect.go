@@ -17,29 +17,54 @@
 package madmin
 
 import (
+	"archive/zip"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/secure-io/sio-go"
 )
 
 // InspectOptions provides options to Inspect.
 type InspectOptions struct {
 	Volume, File string
+	// PublicKey is an optional PEM encoded RSA public key the
+	// data key is encrypted with, so the data can only be
+	// decrypted with the matching private key.
+	PublicKey []byte
 }
 
 // Inspect makes an admin call to download a raw files from disk.
+// The returned data is encrypted, use OpenInspectArchive with the
+// returned key to read it. When d.PublicKey is set the returned key
+// is zero and the data starts with the data key encrypted with the
+// public key, which DecryptInspectKey recovers.
 func (adm *AdminClient) Inspect(ctx context.Context, d InspectOptions) (key [32]byte, c io.ReadCloser, err error) {
 	path := fmt.Sprintf(adminAPIPrefix + "/inspect-data")
 	q := make(url.Values)
 	q.Set("volume", d.Volume)
 	q.Set("file", d.File)
+	if len(d.PublicKey) > 0 {
+		q.Set("public-key", base64.StdEncoding.EncodeToString(d.PublicKey))
+	}
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet, requestData{
 			relPath:     path,
 			queryValues: q,
+			category:    RequestCategoryTransfer,
 		},
 	)
 	if err != nil {
@@ -55,8 +80,12 @@ func (adm *AdminClient) Inspect(ctx context.Context, d InspectOptions) (key [32]
 		closeResponse(resp)
 		return key, nil, err
 	}
-	// This is the only version we know.
-	if key[0] != 1 {
+	switch key[0] {
+	case inspectVersionKey:
+	case inspectVersionPublicKey:
+		// The encrypted data key is returned along with the data.
+		return [32]byte{}, resp.Body, nil
+	default:
 		closeResponse(resp)
 		return key, nil, errors.New("unknown data version")
 	}
@@ -70,3 +99,200 @@ func (adm *AdminClient) Inspect(ctx context.Context, d InspectOptions) (key [32]
 	// Return body
 	return key, resp.Body, nil
 }
+
+// Versions of the data returned by Inspect.
+const (
+	inspectVersionKey       = 1
+	inspectVersionPublicKey = 2
+)
+
+// ParseInspectPrivateKey parses a PEM encoded PKCS#1 or
+// PKCS#8 RSA private key to be used with DecryptInspectKey.
+func ParseInspectPrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// DecryptInspectKey reads the data key encrypted with the public key
+// of InspectOptions from r and decrypts it with privateKey, either an
+// *rsa.PrivateKey or an RSA key held in an HSM or hardware token, e.g.
+// accessed through PKCS#11. r is left positioned at the encrypted data.
+func DecryptInspectKey(r io.Reader, privateKey crypto.Decrypter) (key [32]byte, err error) {
+	pub, ok := privateKey.Public().(*rsa.PublicKey)
+	if !ok {
+		return key, errors.New("private key is not an RSA key")
+	}
+	encKey := make([]byte, pub.Size())
+	if _, err = io.ReadFull(r, encKey); err != nil {
+		return key, err
+	}
+	plainKey, err := privateKey.Decrypt(rand.Reader, encKey, &rsa.OAEPOptions{Hash: crypto.SHA256})
+	if err != nil {
+		return key, err
+	}
+	if len(plainKey) != len(key) {
+		return key, errors.New("invalid data key size")
+	}
+	copy(key[:], plainKey)
+	return key, nil
+}
+
+// DecryptInspect returns a reader of the decrypted zip archive
+// of the data r returned by Inspect.
+func DecryptInspect(key [32]byte, r io.Reader) (io.Reader, error) {
+	stream, err := sio.AES_256_GCM.Stream(key[:])
+	if err != nil {
+		return nil, err
+	}
+	// Each data key is used once, so the nonce is zero.
+	nonce := make([]byte, stream.NonceSize())
+	return stream.DecryptReader(r, nonce, nil), nil
+}
+
+// DecryptInspectAt is like DecryptInspect but provides random access to
+// the decrypted zip archive of the size bytes of r, which start after
+// the encrypted data key if any. Reading from an offset only fetches and
+// decrypts the chunks containing it, so partially downloaded archives
+// can be resumed without reading them again.
+func DecryptInspectAt(key [32]byte, r io.ReaderAt, size int64) (*io.SectionReader, error) {
+	stream, err := sio.AES_256_GCM.Stream(key[:])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, stream.NonceSize())
+	plaintext := stream.DecryptReaderAt(io.NewSectionReader(r, 0, size), nonce, nil)
+	return io.NewSectionReader(plaintext, 0, recipientPlaintextSize(size)), nil
+}
+
+// InspectArchive is a decrypted zip archive returned by Inspect,
+// spooled to a temporary file so its files can be read, or read
+// in place when opened with OpenInspectArchiveAt.
+type InspectArchive struct {
+	f  *os.File
+	zr *zip.Reader
+}
+
+// OpenInspectArchive decrypts the data r returned by Inspect with key.
+// The archive must be closed to remove its temporary file.
+func OpenInspectArchive(key [32]byte, r io.Reader) (*InspectArchive, error) {
+	dr, err := DecryptInspect(key, r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ioutil.TempFile("", "inspect-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	a := &InspectArchive{f: f}
+	size, err := io.Copy(f, dr)
+	if err == nil {
+		a.zr, err = zip.NewReader(f, size)
+	}
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// OpenInspectArchiveAt is like OpenInspectArchive but reads the
+// size bytes of r in place, using DecryptInspectAt, instead of
+// spooling the decrypted archive to a temporary file.
+func OpenInspectArchiveAt(key [32]byte, r io.ReaderAt, size int64) (*InspectArchive, error) {
+	dr, err := DecryptInspectAt(key, r, size)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(dr, dr.Size())
+	if err != nil {
+		return nil, err
+	}
+	return &InspectArchive{zr: zr}, nil
+}
+
+// InspectFile is a file of an inspect archive.
+type InspectFile struct {
+	Name string
+	Size int64
+	// Open returns the content of the file.
+	Open func() (io.ReadCloser, error)
+}
+
+// Files returns the files of the archive in archive order.
+func (a *InspectArchive) Files() []InspectFile {
+	files := make([]InspectFile, 0, len(a.zr.File))
+	for _, f := range a.zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, InspectFile{
+			Name: f.Name,
+			Size: int64(f.UncompressedSize64),
+			Open: f.Open,
+		})
+	}
+	return files
+}
+
+// Extract writes the files of the archive below dir, or the
+// current directory if empty, rejecting names escaping it.
+func (a *InspectArchive) Extract(dir string) error {
+	if dir == "" {
+		dir = "."
+	}
+	base := filepath.Clean(dir)
+	for _, file := range a.Files() {
+		name := filepath.Join(base, filepath.FromSlash(file.Name))
+		rel, err := filepath.Rel(base, name)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file name %q in archive", file.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+			return err
+		}
+		if err := extractInspectFile(file, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractInspectFile(file InspectFile, name string) error {
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Close removes the temporary file of the archive.
+func (a *InspectArchive) Close() error {
+	if a.f == nil {
+		return nil
+	}
+	a.f.Close()
+	return os.Remove(a.f.Name())
+}
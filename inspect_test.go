@@ -0,0 +1,192 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secure-io/sio-go"
+)
+
+func TestOpenInspectArchive(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create("node1/xl.meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("metadata"))
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key [32]byte
+	rand.Read(key[:])
+	encKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &privateKey.PublicKey, key[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The data key encrypted with the public key precedes the data.
+	data := bytes.NewBuffer(encKey)
+	stream, err := sio.AES_256_GCM.Stream(key[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ew := stream.EncryptWriter(data, make([]byte, stream.NonceSize()), nil)
+	ew.Write(archive.Bytes())
+	if err = ew.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	parsedKey, err := ParseInspectPrivateKey(pemBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataKey, err := DecryptInspectKey(data, parsedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataKey != key {
+		t.Fatal("decrypted data key does not match")
+	}
+
+	// Keys held in an HSM decrypt the data key too.
+	hsmDataKey, err := DecryptInspectKey(bytes.NewReader(encKey), hsmKey{privateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hsmDataKey != key {
+		t.Fatal("data key decrypted with the HSM key does not match")
+	}
+
+	// Random access to the archive in place.
+	encrypted := data.Bytes()
+	at, err := OpenInspectArchiveAt(dataKey, bytes.NewReader(encrypted), int64(len(encrypted)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := at.Files()
+	if len(files) != 1 || files[0].Name != "node1/xl.meta" {
+		t.Fatalf("unexpected files %+v", files)
+	}
+	rc, err := files[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(content) != "metadata" {
+		t.Fatalf("unexpected content %q, %v", content, err)
+	}
+	if err = at.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := OpenInspectArchive(dataKey, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+
+	files = a.Files()
+	if len(files) != 1 || files[0].Name != "node1/xl.meta" || files[0].Size != 8 {
+		t.Fatalf("unexpected files %+v", files)
+	}
+	dir := t.TempDir()
+	if err = a.Extract(dir); err != nil {
+		t.Fatal(err)
+	}
+	content, err = ioutil.ReadFile(filepath.Join(dir, "node1", "xl.meta"))
+	if err != nil || string(content) != "metadata" {
+		t.Fatalf("unexpected extracted content %q, %v", content, err)
+	}
+}
+
+func TestInspectArchiveExtract(t *testing.T) {
+	newArchive := func(name string) *InspectArchive {
+		var archive bytes.Buffer
+		zw := zip.NewWriter(&archive)
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte("metadata"))
+		if err = zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &InspectArchive{zr: zr}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	testCases := []struct {
+		dir, name string
+		valid     bool
+	}{
+		{"", "node1/xl.meta", true},
+		{".", "node1/xl.meta", true},
+		{"out/", "node1/xl.meta", true},
+		{"out/../out", "xl.meta", true},
+		{"", "../xl.meta", false},
+		{"out", "node1/../../xl.meta", false},
+		{"out", "node1/..", false},
+	}
+	for i, testCase := range testCases {
+		if err = os.Chdir(t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+		err = newArchive(testCase.name).Extract(testCase.dir)
+		if !testCase.valid {
+			if err == nil {
+				t.Errorf("Test %d: expected an error for %q", i+1, testCase.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: %v", i+1, err)
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(testCase.dir, testCase.name))
+		if err != nil || string(content) != "metadata" {
+			t.Errorf("Test %d: unexpected extracted content %q, %v", i+1, content, err)
+		}
+	}
+}
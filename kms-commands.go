@@ -17,10 +17,14 @@
 package madmin
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"time"
 )
 
 // KMSStatus contains various informations about
@@ -30,13 +34,65 @@ type KMSStatus struct {
 	Name         string               `json:"name"`           // Name or type of the KMS
 	DefaultKeyID string               `json:"default-key-id"` // The key ID used when no explicit key is specified
 	Endpoints    map[string]ItemState `json:"endpoints"`      // List of KMS endpoints and their status (online/offline)
+
+	// EndpointDetails and KeyStore are only set by
+	// servers supporting detailed KMS status.
+	EndpointDetails map[string]KMSEndpointStatus `json:"endpoint-details,omitempty"`
+	KeyStore        *KMSKeyStoreStatus           `json:"key-store,omitempty"`
+}
+
+// KMSEndpointStatus contains reachability information
+// about a single KMS endpoint.
+type KMSEndpointStatus struct {
+	State      ItemState     `json:"state"`
+	Latency    time.Duration `json:"latency"`               // Round-trip latency of the status request
+	CertExpiry time.Time     `json:"cert-expiry,omitempty"` // Expiry of the endpoint TLS certificate
+	Error      string        `json:"error,omitempty"`
+}
+
+// KMSKeyStoreStatus contains information about the
+// backend storing the keys of the KMS, e.g. Vault.
+type KMSKeyStoreStatus struct {
+	Type  string    `json:"type"`
+	State ItemState `json:"state"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Degraded returns the reasons why the KMS is degraded: offline
+// endpoints or key store and endpoint certificates expiring within
+// certExpiry. It returns nothing for a healthy KMS.
+func (s KMSStatus) Degraded(certExpiry time.Duration) (reasons []string) {
+	deadline := time.Now().Add(certExpiry)
+	for endpoint, state := range s.Endpoints {
+		if _, ok := s.EndpointDetails[endpoint]; !ok && state != ItemOnline {
+			reasons = append(reasons, fmt.Sprintf("endpoint %s is %s", endpoint, state))
+		}
+	}
+	for endpoint, details := range s.EndpointDetails {
+		if details.State != ItemOnline {
+			reasons = append(reasons, fmt.Sprintf("endpoint %s is %s", endpoint, details.State))
+		}
+		if !details.CertExpiry.IsZero() && details.CertExpiry.Before(deadline) {
+			reasons = append(reasons, fmt.Sprintf("certificate of endpoint %s expires at %s",
+				endpoint, details.CertExpiry.Format(time.RFC3339)))
+		}
+	}
+	if s.KeyStore != nil && s.KeyStore.State != ItemOnline {
+		reasons = append(reasons, fmt.Sprintf("key store %s is %s", s.KeyStore.Type, s.KeyStore.State))
+	}
+	sort.Strings(reasons)
+	return reasons
 }
 
 // KMSStatus returns status information about the KMS connected
 // to the MinIO server, if configured.
 func (adm *AdminClient) KMSStatus(ctx context.Context) (KMSStatus, error) {
+	// GET <endpoint>/<admin-API>/kms/status?details=true
+	qv := url.Values{}
+	qv.Set("details", "true")
 	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
-		relPath: adminAPIPrefix + "/kms/status", // GET <endpoint>/<admin-API>/kms/status
+		relPath:     adminAPIPrefix + "/kms/status",
+		queryValues: qv,
 	})
 	if err != nil {
 		return KMSStatus{}, err
@@ -101,6 +157,159 @@ func (adm *AdminClient) GetKeyStatus(ctx context.Context, keyID string) (*KMSKey
 	return &keyInfo, nil
 }
 
+// ImportKMSKey imports a master key, previously exported with ExportKMSKey,
+// as keyID into the KMS connected to a MinIO server. The wrappedKey is
+// unwrapped with password on the client and sent encrypted with the
+// admin credentials, so the plain key material never leaves the client
+// unprotected.
+func (adm *AdminClient) ImportKMSKey(ctx context.Context, keyID string, wrappedKey []byte, password string) error {
+	if password == "" {
+		return ErrInvalidArgument("password must not be empty")
+	}
+	key, err := DecryptData(password, bytes.NewReader(wrappedKey))
+	if err != nil {
+		return err
+	}
+	defer Wipe(key)
+
+	// POST /minio/admin/v3/kms/key/import?key-id=<keyID>
+	qv := url.Values{}
+	qv.Set("key-id", keyID)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/kms/key/import",
+		queryValues: qv,
+		content:     key,
+		encrypt:     true,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// ExportKMSKey exports the master key referenced by keyID from the KMS
+// connected to a MinIO server. The returned key is wrapped with password
+// and can be imported into another deployment using ImportKMSKey.
+func (adm *AdminClient) ExportKMSKey(ctx context.Context, keyID, password string) ([]byte, error) {
+	if password == "" {
+		return nil, ErrInvalidArgument("password must not be empty")
+	}
+
+	// GET /minio/admin/v3/kms/key/export?key-id=<keyID>
+	qv := url.Values{}
+	qv.Set("key-id", keyID)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/kms/key/export",
+		queryValues: qv,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+	key, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(key)
+	return EncryptData(password, key)
+}
+
+// RotateKMSKey creates a new version of the master key referenced by keyID
+// at the KMS connected to a MinIO server. New objects are encrypted with the
+// new key version while existing objects get re-wrapped in the background.
+// Use KMSRewrapStatus to track the progress of re-wrapping.
+func (adm *AdminClient) RotateKMSKey(ctx context.Context, keyID string) error {
+	// POST /minio/admin/v3/kms/key/rotate?key-id=<keyID>
+	qv := url.Values{}
+	qv.Set("key-id", keyID)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/kms/key/rotate",
+		queryValues: qv,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// KMSRewrapStatus contains information about re-wrapping object
+// keys after the master key referenced by KeyID was rotated.
+type KMSRewrapStatus struct {
+	KeyID          string    `json:"key-id"`
+	CurrentVersion int       `json:"current-version"`
+	StartedAt      time.Time `json:"started-at,omitempty"`
+
+	ObjectsRewrapped uint64 `json:"objects-rewrapped"`
+	ObjectsPending   uint64 `json:"objects-pending"` // Objects still referencing an old key version
+	ObjectsFailed    uint64 `json:"objects-failed"`
+}
+
+// Total returns the number of objects encrypted with the master key.
+func (s KMSRewrapStatus) Total() uint64 {
+	return s.ObjectsRewrapped + s.ObjectsPending + s.ObjectsFailed
+}
+
+// Complete returns true if no object references an old version of the
+// master key anymore, including keys not used by any object. Objects
+// that failed to re-wrap are done as well, check Failed or Succeeded.
+func (s KMSRewrapStatus) Complete() bool {
+	return s.ObjectsPending == 0
+}
+
+// Failed returns true if some objects could not be re-wrapped.
+func (s KMSRewrapStatus) Failed() bool {
+	return s.ObjectsFailed > 0
+}
+
+// Succeeded returns true if re-wrapping completed without failures.
+func (s KMSRewrapStatus) Succeeded() bool {
+	return s.Complete() && !s.Failed()
+}
+
+// KMSRewrapStatus requests the re-wrap status of the master key referenced
+// by keyID from a MinIO server, i.e. how many objects still reference an
+// old version of the key after it was rotated with RotateKMSKey.
+func (adm *AdminClient) KMSRewrapStatus(ctx context.Context, keyID string) (KMSRewrapStatus, error) {
+	// GET /minio/admin/v3/kms/key/rewrap-status?key-id=<keyID>
+	qv := url.Values{}
+	qv.Set("key-id", keyID)
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/kms/key/rewrap-status",
+		queryValues: qv,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	if err != nil {
+		return KMSRewrapStatus{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return KMSRewrapStatus{}, httpRespToErrorResponse(resp)
+	}
+	var status KMSRewrapStatus
+	if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return KMSRewrapStatus{}, err
+	}
+	return status, nil
+}
+
 // KMSKeyStatus contains some status information about a KMS master key.
 // The MinIO server tries to access the KMS and perform encryption and
 // decryption operations. If the MinIO server can access the KMS and
@@ -0,0 +1,170 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestKMSStatusDegraded(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		status  KMSStatus
+		reasons int
+	}{
+		// Healthy KMS without details.
+		{KMSStatus{Endpoints: map[string]ItemState{"kes-1": ItemOnline}}, 0},
+		// Offline endpoint without details.
+		{KMSStatus{Endpoints: map[string]ItemState{"kes-1": ItemOnline, "kes-2": ItemOffline}}, 1},
+		// Details take precedence over the endpoint state.
+		{KMSStatus{
+			Endpoints: map[string]ItemState{"kes-1": ItemOffline},
+			EndpointDetails: map[string]KMSEndpointStatus{
+				"kes-1": {State: ItemOnline, CertExpiry: now.Add(30 * 24 * time.Hour)},
+			},
+		}, 0},
+		// Expiring certificate and offline key store.
+		{KMSStatus{
+			EndpointDetails: map[string]KMSEndpointStatus{
+				"kes-1": {State: ItemOnline, CertExpiry: now.Add(time.Hour)},
+			},
+			KeyStore: &KMSKeyStoreStatus{Type: "vault", State: ItemOffline},
+		}, 2},
+	}
+	for i, tc := range testCases {
+		if reasons := tc.status.Degraded(7 * 24 * time.Hour); len(reasons) != tc.reasons {
+			t.Errorf("Test %d: expected %d reasons, got %v", i+1, tc.reasons, reasons)
+		}
+	}
+}
+
+func TestKMSRewrapStatusComplete(t *testing.T) {
+	testCases := []struct {
+		status    KMSRewrapStatus
+		complete  bool
+		succeeded bool
+	}{
+		{KMSRewrapStatus{}, true, true}, // Key not used by any object
+		{KMSRewrapStatus{ObjectsRewrapped: 10, ObjectsPending: 2}, false, false},
+		{KMSRewrapStatus{ObjectsRewrapped: 10}, true, true},
+		{KMSRewrapStatus{ObjectsRewrapped: 10, ObjectsFailed: 1}, true, false},
+		{KMSRewrapStatus{ObjectsPending: 2, ObjectsFailed: 1}, false, false},
+	}
+	for i, tc := range testCases {
+		if got := tc.status.Complete(); got != tc.complete {
+			t.Errorf("Test %d: expected complete %t, got %t", i+1, tc.complete, got)
+		}
+		if got := tc.status.Succeeded(); got != tc.succeeded {
+			t.Errorf("Test %d: expected succeeded %t, got %t", i+1, tc.succeeded, got)
+		}
+		if got := tc.status.Failed(); got != (tc.status.ObjectsFailed > 0) {
+			t.Errorf("Test %d: expected failed %t, got %t", i+1, !got, got)
+		}
+	}
+}
+
+func TestExportImportKMSKey(t *testing.T) {
+	masterKey := []byte("0123456789abcdef0123456789abcdef")
+	var imported []byte
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key-id") != "my-key" {
+			writeTestError(w, http.StatusNotFound, "XMinioKMSKeyNotFound")
+			return
+		}
+		switch r.URL.Path {
+		case "/minio/admin/v3/kms/key/export":
+			data, _ := EncryptData("minio123", masterKey)
+			w.Write(data)
+		case "/minio/admin/v3/kms/key/import":
+			data, _ := ioutil.ReadAll(r.Body)
+			imported, _ = DecryptData("minio123", bytes.NewReader(data))
+		default:
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+		}
+	})
+
+	ctx := context.Background()
+	wrapped, err := adm.ExportKMSKey(ctx, "my-key", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(wrapped, masterKey) {
+		t.Fatal("exported key is not wrapped")
+	}
+	if err = adm.ImportKMSKey(ctx, "my-key", wrapped, "password"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(imported, masterKey) {
+		t.Fatalf("expected imported key %q, got %q", masterKey, imported)
+	}
+
+	if err = adm.ImportKMSKey(ctx, "my-key", wrapped, "wrong"); err == nil {
+		t.Error("expected error for wrong password")
+	}
+	if err = adm.ImportKMSKey(ctx, "my-key", wrapped, ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if _, err = adm.ExportKMSKey(ctx, "my-key", ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if _, err = adm.ExportKMSKey(ctx, "other", "password"); ToErrorResponse(err).Code != "XMinioKMSKeyNotFound" {
+		t.Errorf("expected XMinioKMSKeyNotFound, got %v", err)
+	}
+}
+
+func TestRotateKMSKey(t *testing.T) {
+	var rotated bool
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key-id") != "my-key" {
+			writeTestError(w, http.StatusNotFound, "XMinioKMSKeyNotFound")
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/minio/admin/v3/kms/key/rotate":
+			rotated = true
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/kms/key/rewrap-status":
+			json.NewEncoder(w).Encode(KMSRewrapStatus{KeyID: "my-key", CurrentVersion: 2, ObjectsRewrapped: 8, ObjectsPending: 2})
+		default:
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+		}
+	})
+
+	ctx := context.Background()
+	if err := adm.RotateKMSKey(ctx, "my-key"); err != nil || !rotated {
+		t.Fatalf("expected key to be rotated, got %v", err)
+	}
+	status, err := adm.KMSRewrapStatus(ctx, "my-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.CurrentVersion != 2 || status.Total() != 10 || status.Complete() {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if err = adm.RotateKMSKey(ctx, "other"); ToErrorResponse(err).Code != "XMinioKMSKeyNotFound" {
+		t.Errorf("expected XMinioKMSKeyNotFound, got %v", err)
+	}
+	if _, err = adm.KMSRewrapStatus(ctx, "other"); ToErrorResponse(err).Code != "XMinioKMSKeyNotFound" {
+		t.Errorf("expected XMinioKMSKeyNotFound, got %v", err)
+	}
+}
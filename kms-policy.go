@@ -0,0 +1,158 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// KMSPolicy is a policy of the KMS, i.e. the KMS API
+// paths an identity is allowed or denied to access.
+type KMSPolicy struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// KMSPolicyInfo contains information about a KMS policy.
+type KMSPolicyInfo struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created-at,omitempty"`
+	CreatedBy string    `json:"created-by,omitempty"`
+}
+
+// KMSIdentityInfo contains information about a KMS identity,
+// i.e. the hash of a client certificate, and its policy.
+type KMSIdentityInfo struct {
+	Identity  string    `json:"identity"`
+	Policy    string    `json:"policy,omitempty"`
+	IsAdmin   bool      `json:"is-admin,omitempty"`
+	CreatedAt time.Time `json:"created-at,omitempty"`
+	CreatedBy string    `json:"created-by,omitempty"`
+}
+
+// ListKMSPolicies lists the policies of the KMS connected to a MinIO
+// server matching pattern, e.g. "*" or "minio-*".
+func (adm *AdminClient) ListKMSPolicies(ctx context.Context, pattern string) ([]KMSPolicyInfo, error) {
+	// GET /minio/admin/v3/kms/policy/list?pattern=<pattern>
+	qv := url.Values{}
+	qv.Set("pattern", pattern)
+
+	var policies []KMSPolicyInfo
+	err := adm.kmsRequest(ctx, http.MethodGet, "/kms/policy/list", qv, nil, &policies)
+	return policies, err
+}
+
+// GetKMSPolicy returns the KMS policy referenced by name.
+func (adm *AdminClient) GetKMSPolicy(ctx context.Context, name string) (KMSPolicy, error) {
+	// GET /minio/admin/v3/kms/policy/get?policy=<name>
+	qv := url.Values{}
+	qv.Set("policy", name)
+
+	var policy KMSPolicy
+	err := adm.kmsRequest(ctx, http.MethodGet, "/kms/policy/get", qv, nil, &policy)
+	return policy, err
+}
+
+// SetKMSPolicy creates or replaces the KMS policy referenced by name.
+func (adm *AdminClient) SetKMSPolicy(ctx context.Context, name string, policy KMSPolicy) error {
+	if name == "" {
+		return ErrInvalidArgument("policy name must not be empty")
+	}
+	content, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	// POST /minio/admin/v3/kms/policy/set?policy=<name>
+	qv := url.Values{}
+	qv.Set("policy", name)
+	return adm.kmsRequest(ctx, http.MethodPost, "/kms/policy/set", qv, content, nil)
+}
+
+// DeleteKMSPolicy deletes the KMS policy referenced by name.
+func (adm *AdminClient) DeleteKMSPolicy(ctx context.Context, name string) error {
+	// DELETE /minio/admin/v3/kms/policy/delete?policy=<name>
+	qv := url.Values{}
+	qv.Set("policy", name)
+	return adm.kmsRequest(ctx, http.MethodDelete, "/kms/policy/delete", qv, nil, nil)
+}
+
+// AssignKMSPolicy assigns the KMS policy referenced by
+// name to the KMS identity, replacing its current policy.
+func (adm *AdminClient) AssignKMSPolicy(ctx context.Context, name, identity string) error {
+	// POST /minio/admin/v3/kms/policy/assign?policy=<name>&identity=<identity>
+	qv := url.Values{}
+	qv.Set("policy", name)
+	qv.Set("identity", identity)
+	return adm.kmsRequest(ctx, http.MethodPost, "/kms/policy/assign", qv, nil, nil)
+}
+
+// ListKMSIdentities lists the identities of the KMS connected
+// to a MinIO server matching pattern.
+func (adm *AdminClient) ListKMSIdentities(ctx context.Context, pattern string) ([]KMSIdentityInfo, error) {
+	// GET /minio/admin/v3/kms/identity/list?pattern=<pattern>
+	qv := url.Values{}
+	qv.Set("pattern", pattern)
+
+	var identities []KMSIdentityInfo
+	err := adm.kmsRequest(ctx, http.MethodGet, "/kms/identity/list", qv, nil, &identities)
+	return identities, err
+}
+
+// DescribeKMSIdentity returns information about the KMS identity.
+func (adm *AdminClient) DescribeKMSIdentity(ctx context.Context, identity string) (KMSIdentityInfo, error) {
+	// GET /minio/admin/v3/kms/identity/describe?identity=<identity>
+	qv := url.Values{}
+	qv.Set("identity", identity)
+
+	var info KMSIdentityInfo
+	err := adm.kmsRequest(ctx, http.MethodGet, "/kms/identity/describe", qv, nil, &info)
+	return info, err
+}
+
+// DeleteKMSIdentity deletes the KMS identity, revoking its access to the KMS.
+func (adm *AdminClient) DeleteKMSIdentity(ctx context.Context, identity string) error {
+	// DELETE /minio/admin/v3/kms/identity/delete?identity=<identity>
+	qv := url.Values{}
+	qv.Set("identity", identity)
+	return adm.kmsRequest(ctx, http.MethodDelete, "/kms/identity/delete", qv, nil, nil)
+}
+
+// kmsRequest performs a KMS admin API call and decodes
+// the JSON response into v, if not nil.
+func (adm *AdminClient) kmsRequest(ctx context.Context, method, path string, qv url.Values, content []byte, v interface{}) error {
+	resp, err := adm.executeMethod(ctx, method, requestData{
+		relPath:     adminAPIPrefix + path,
+		queryValues: qv,
+		content:     content,
+	})
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,155 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestKMSPolicy(t *testing.T) {
+	policies := map[string]KMSPolicy{}
+	identities := map[string]KMSIdentityInfo{
+		"3ecfcdf3": {Identity: "3ecfcdf3", IsAdmin: true},
+	}
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		name, identity := q.Get("policy"), q.Get("identity")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /minio/admin/v3/kms/policy/list":
+			list := []KMSPolicyInfo{}
+			for name := range policies {
+				if ok, _ := path.Match(q.Get("pattern"), name); ok {
+					list = append(list, KMSPolicyInfo{Name: name, CreatedBy: "3ecfcdf3"})
+				}
+			}
+			sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+			json.NewEncoder(w).Encode(list)
+		case "GET /minio/admin/v3/kms/policy/get":
+			policy, ok := policies[name]
+			if !ok {
+				writeTestError(w, http.StatusNotFound, "XMinioKMSPolicyNotFound")
+				return
+			}
+			json.NewEncoder(w).Encode(policy)
+		case "POST /minio/admin/v3/kms/policy/set":
+			var policy KMSPolicy
+			if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+				writeTestError(w, http.StatusBadRequest, "XMinioKMSInvalidPolicy")
+				return
+			}
+			policies[name] = policy
+		case "DELETE /minio/admin/v3/kms/policy/delete":
+			delete(policies, name)
+		case "POST /minio/admin/v3/kms/policy/assign":
+			info, ok := identities[identity]
+			if _, exists := policies[name]; !ok || !exists {
+				writeTestError(w, http.StatusNotFound, "XMinioKMSPolicyNotFound")
+				return
+			}
+			info.Policy = name
+			identities[identity] = info
+		case "GET /minio/admin/v3/kms/identity/list":
+			list := []KMSIdentityInfo{}
+			for _, info := range identities {
+				list = append(list, info)
+			}
+			json.NewEncoder(w).Encode(list)
+		case "GET /minio/admin/v3/kms/identity/describe":
+			info, ok := identities[identity]
+			if !ok {
+				writeTestError(w, http.StatusNotFound, "XMinioKMSIdentityNotFound")
+				return
+			}
+			json.NewEncoder(w).Encode(info)
+		case "DELETE /minio/admin/v3/kms/identity/delete":
+			delete(identities, identity)
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+	ctx := context.Background()
+
+	policy := KMSPolicy{
+		Allow: []string{"/v1/key/create/minio-*", "/v1/key/generate/minio-*"},
+		Deny:  []string{"/v1/key/delete/*"},
+	}
+	if err := adm.SetKMSPolicy(ctx, "minio-app", policy); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.SetKMSPolicy(ctx, "other", KMSPolicy{Allow: []string{"/v1/status"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.SetKMSPolicy(ctx, "", policy); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+
+	list, err := adm.ListKMSPolicies(ctx, "minio-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "minio-app" {
+		t.Fatalf("unexpected policies %+v", list)
+	}
+	got, err := adm.GetKMSPolicy(ctx, "minio-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, policy) {
+		t.Fatalf("expected %+v, got %+v", policy, got)
+	}
+
+	if err = adm.AssignKMSPolicy(ctx, "minio-app", "3ecfcdf3"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := adm.DescribeKMSIdentity(ctx, "3ecfcdf3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Policy != "minio-app" || !info.IsAdmin {
+		t.Fatalf("unexpected identity %+v", info)
+	}
+	if err = adm.AssignKMSPolicy(ctx, "unknown", "3ecfcdf3"); ToErrorResponse(err).Code != "XMinioKMSPolicyNotFound" {
+		t.Fatalf("expected XMinioKMSPolicyNotFound, got %v", err)
+	}
+
+	if err = adm.DeleteKMSPolicy(ctx, "minio-app"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = adm.GetKMSPolicy(ctx, "minio-app"); ToErrorResponse(err).Code != "XMinioKMSPolicyNotFound" {
+		t.Fatalf("expected XMinioKMSPolicyNotFound, got %v", err)
+	}
+
+	if err = adm.DeleteKMSIdentity(ctx, "3ecfcdf3"); err != nil {
+		t.Fatal(err)
+	}
+	ids, err := adm.ListKMSIdentities(ctx, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("expected no identities, got %+v", ids)
+	}
+	if _, err = adm.DescribeKMSIdentity(ctx, "3ecfcdf3"); ToErrorResponse(err).Code != "XMinioKMSIdentityNotFound" {
+		t.Fatalf("expected XMinioKMSIdentityNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// LicenseInfo contains the details of the SUBNET
+// license the cluster is registered with.
+type LicenseInfo struct {
+	ID              string    `json:"id"`
+	Organization    string    `json:"organization"`
+	Plan            string    `json:"plan"` // e.g. STANDARD or ENTERPRISE
+	DeploymentID    string    `json:"deploymentId,omitempty"`
+	StorageCapacity uint64    `json:"storageCapacity"` // Licensed capacity in TiB
+	Entitlements    []string  `json:"entitlements,omitempty"`
+	IssuedAt        time.Time `json:"issuedAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	Trial           bool      `json:"trial,omitempty"`
+}
+
+// Expired returns true if the license is expired.
+func (l LicenseInfo) Expired() bool {
+	return !l.ExpiresAt.IsZero() && time.Now().After(l.ExpiresAt)
+}
+
+// ExpiresWithin returns true if the license expires within d.
+func (l LicenseInfo) ExpiresWithin(d time.Duration) bool {
+	return !l.ExpiresAt.IsZero() && time.Now().Add(d).After(l.ExpiresAt)
+}
+
+// Entitled returns true if the license includes the entitlement.
+func (l LicenseInfo) Entitled(entitlement string) bool {
+	for _, e := range l.Entitlements {
+		if e == entitlement {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLicenseInfo returns the details of the license
+// configured on the cluster.
+func (adm *AdminClient) GetLicenseInfo(ctx context.Context) (LicenseInfo, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: adminAPIPrefix + "/license-info",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return LicenseInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return LicenseInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	var info LicenseInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return LicenseInfo{}, err
+	}
+	return info, nil
+}
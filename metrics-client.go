@@ -0,0 +1,350 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	prometheusMetricsPrefix = "/minio/v2/metrics/"
+
+	prometheusIssuer        = "prometheus"
+	defaultPrometheusExpiry = time.Hour
+)
+
+// MetricsScope selects the prometheus metrics endpoint to scrape.
+type MetricsScope string
+
+// Prometheus metrics endpoints served by MinIO.
+const (
+	MetricsScopeCluster  MetricsScope = "cluster"
+	MetricsScopeNode     MetricsScope = "node"
+	MetricsScopeBucket   MetricsScope = "bucket"
+	MetricsScopeResource MetricsScope = "resource"
+)
+
+// MetricsScrapeOptions are options provided to MetricsClient.Fetch.
+type MetricsScrapeOptions struct {
+	Scope MetricsScope // Endpoint to scrape, defaults to MetricsScopeCluster.
+	// Only return metric families with these names, leave empty for all.
+	Families []string
+}
+
+// MetricSample is a single sample of a prometheus metric family.
+type MetricSample struct {
+	// Name of the sample, e.g. the family name with a
+	// "_bucket", "_sum" or "_count" suffix for histograms.
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Value     float64           `json:"value"`
+	Timestamp time.Time         `json:"timestamp,omitempty"`
+}
+
+// MetricFamily is a prometheus metric family with all its samples.
+type MetricFamily struct {
+	Name    string         `json:"name"`
+	Help    string         `json:"help,omitempty"`
+	Type    string         `json:"type"` // counter, gauge, histogram, summary or untyped
+	Samples []MetricSample `json:"samples"`
+}
+
+// MetricsClient scrapes the prometheus metrics endpoints of
+// a MinIO server, authenticating with a bearer token derived
+// from the credentials of the admin client.
+type MetricsClient struct {
+	adm *AdminClient
+
+	// Validity of the generated bearer tokens, defaults to one hour.
+	TokenExpiry time.Duration
+}
+
+// NewMetricsClient returns a new prometheus metrics client using
+// the endpoint, credentials and transport of adm.
+func NewMetricsClient(adm *AdminClient) *MetricsClient {
+	return &MetricsClient{adm: adm, TokenExpiry: defaultPrometheusExpiry}
+}
+
+// BearerToken returns a new bearer token accepted by the prometheus
+// metrics endpoints, e.g. for use in a prometheus scrape config.
+func (c *MetricsClient) BearerToken() (string, error) {
+	value, err := c.adm.credsProvider.Get()
+	if err != nil {
+		return "", err
+	}
+	expiry := c.TokenExpiry
+	if expiry <= 0 {
+		expiry = defaultPrometheusExpiry
+	}
+	return prometheusToken(value.AccessKeyID, value.SecretAccessKey, time.Now().Add(expiry))
+}
+
+// prometheusToken returns a HS512 signed JWT for accessKey.
+func prometheusToken(accessKey, secretKey string, expiresAt time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS512", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"exp": expiresAt.Unix(),
+		"sub": accessKey,
+		"iss": prometheusIssuer,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Fetch scrapes the prometheus metrics endpoint selected by opts
+// and returns the parsed metric families in the order served.
+func (c *MetricsClient) Fetch(ctx context.Context, opts MetricsScrapeOptions) ([]MetricFamily, error) {
+	scope := opts.Scope
+	if scope == "" {
+		scope = MetricsScopeCluster
+	}
+
+	token, err := c.BearerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	u := *c.adm.endpointURL
+	u.Path = prometheusMetricsPrefix + string(scope)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.adm.setUserAgent(req)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := c.adm.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	families, err := parsePrometheusText(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Families) == 0 {
+		return families, nil
+	}
+
+	want := make(map[string]bool, len(opts.Families))
+	for _, name := range opts.Families {
+		want[name] = true
+	}
+	filtered := families[:0]
+	for _, f := range families {
+		if want[f.Name] {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered, nil
+}
+
+// parsePrometheusText parses the prometheus text exposition format.
+func parsePrometheusText(r io.Reader) ([]MetricFamily, error) {
+	var families []MetricFamily
+	index := make(map[string]int)
+
+	family := func(name string) *MetricFamily {
+		if i, ok := index[name]; ok {
+			return &families[i]
+		}
+		families = append(families, MetricFamily{Name: name, Type: "untyped"})
+		index[name] = len(families) - 1
+		return &families[len(families)-1]
+	}
+
+	// Samples of histograms and summaries carry a suffix.
+	familyOf := func(sample string) *MetricFamily {
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			name := strings.TrimSuffix(sample, suffix)
+			if i, ok := index[name]; ok && name != sample {
+				if t := families[i].Type; t == "histogram" || t == "summary" {
+					return &families[i]
+				}
+			}
+		}
+		return family(sample)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(strings.TrimSpace(line[1:]), " ", 3)
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				family(fields[1]).Help = unescapePrometheus(fields[2], false)
+			case "TYPE":
+				family(fields[1]).Type = fields[2]
+			}
+			continue
+		}
+
+		sample, err := parsePrometheusSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		f := familyOf(sample.Name)
+		f.Samples = append(f.Samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return families, nil
+}
+
+var errInvalidPrometheusSample = errors.New("invalid prometheus sample")
+
+// parsePrometheusSample parses a single `name{labels} value [timestamp]` line.
+func parsePrometheusSample(line string) (s MetricSample, err error) {
+	end := strings.IndexAny(line, "{ ")
+	if end <= 0 {
+		return s, errInvalidPrometheusSample
+	}
+	s.Name = line[:end]
+	rest := line[end:]
+
+	if rest[0] == '{' {
+		s.Labels = make(map[string]string)
+		rest = rest[1:]
+		for {
+			rest = strings.TrimLeft(rest, ", ")
+			if rest == "" {
+				return s, errInvalidPrometheusSample
+			}
+			if rest[0] == '}' {
+				rest = rest[1:]
+				break
+			}
+			eq := strings.IndexByte(rest, '=')
+			if eq <= 0 || len(rest) < eq+2 || rest[eq+1] != '"' {
+				return s, errInvalidPrometheusSample
+			}
+			key := strings.TrimSpace(rest[:eq])
+			rest = rest[eq+2:]
+
+			// Find the closing quote, skipping escaped characters.
+			i := 0
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(rest) {
+				return s, errInvalidPrometheusSample
+			}
+			s.Labels[key] = unescapePrometheus(rest[:i], true)
+			rest = rest[i+1:]
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, errInvalidPrometheusSample
+	}
+	if s.Value, err = parsePrometheusFloat(fields[0]); err != nil {
+		return s, err
+	}
+	if len(fields) == 2 {
+		ms, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return s, err
+		}
+		s.Timestamp = time.UnixMilli(ms)
+	}
+	return s, nil
+}
+
+func parsePrometheusFloat(v string) (float64, error) {
+	switch v {
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// unescapePrometheus unescapes help texts and, if quoted
+// is set, label values of the text exposition format.
+func unescapePrometheus(s string, quoted bool) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case '\\':
+			b.WriteByte('\\')
+		case '"':
+			if quoted {
+				b.WriteByte('"')
+			} else {
+				b.WriteString(`\"`)
+			}
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const testPrometheusText = `# HELP minio_bucket_usage_total_bytes Total bucket size in bytes
+# TYPE minio_bucket_usage_total_bytes gauge
+minio_bucket_usage_total_bytes{bucket="photos",server="127.0.0.1:9000"} 1.048576e+06
+minio_bucket_usage_total_bytes{bucket="say \"hi\"",server="127.0.0.1:9000"} 0
+# HELP minio_bucket_requests_ttfb_seconds_distribution Distribution of time to first byte
+# TYPE minio_bucket_requests_ttfb_seconds_distribution histogram
+minio_bucket_requests_ttfb_seconds_distribution_bucket{api="GetObject",le="0.05"} 10
+minio_bucket_requests_ttfb_seconds_distribution_bucket{api="GetObject",le="+Inf"} 12
+minio_bucket_requests_ttfb_seconds_distribution_sum{api="GetObject"} 0.3
+minio_bucket_requests_ttfb_seconds_distribution_count{api="GetObject"} 12
+minio_node_process_uptime_seconds +Inf 1660000000000
+`
+
+func TestParsePrometheusText(t *testing.T) {
+	families, err := parsePrometheusText(strings.NewReader(testPrometheusText))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 3 {
+		t.Fatalf("expected 3 families, got %d", len(families))
+	}
+
+	usage := families[0]
+	if usage.Type != "gauge" || usage.Help != "Total bucket size in bytes" || len(usage.Samples) != 2 {
+		t.Fatalf("unexpected family %#v", usage)
+	}
+	if usage.Samples[0].Value != 1048576 || usage.Samples[0].Labels["bucket"] != "photos" {
+		t.Fatalf("unexpected sample %#v", usage.Samples[0])
+	}
+	if usage.Samples[1].Labels["bucket"] != `say "hi"` {
+		t.Fatalf("unexpected label %q", usage.Samples[1].Labels["bucket"])
+	}
+
+	ttfb := families[1]
+	if ttfb.Type != "histogram" || len(ttfb.Samples) != 4 {
+		t.Fatalf("unexpected family %#v", ttfb)
+	}
+	if ttfb.Samples[1].Labels["le"] != "+Inf" || ttfb.Samples[3].Name != ttfb.Name+"_count" {
+		t.Fatalf("unexpected samples %#v", ttfb.Samples)
+	}
+
+	uptime := families[2]
+	if uptime.Type != "untyped" || !math.IsInf(uptime.Samples[0].Value, 1) || uptime.Samples[0].Timestamp.UnixMilli() != 1660000000000 {
+		t.Fatalf("unexpected family %#v", uptime)
+	}
+
+	if _, err = parsePrometheusText(strings.NewReader(`minio_broken{bucket="x} 1`)); err == nil {
+		t.Fatal("expected error for unterminated label value")
+	}
+}
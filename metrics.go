@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"sort"
@@ -40,6 +41,8 @@ const (
 	MetricsScanner MetricType = 1 << (iota)
 	MetricsDisk
 	MetricsOS
+	MetricsBucketLatency
+	MetricsRuntime
 
 	// MetricsAll must be last.
 	// Enables all metrics.
@@ -55,6 +58,34 @@ type MetricsOptions struct {
 	ByHost   bool          // Return metrics by host.
 	Disks    []string
 	ByDisk   bool
+	Buckets  []string // Only report bucket latency for these buckets, leave empty for all.
+
+	// Aggregation applied by the server when combining the metrics of
+	// all hosts into RealtimeMetrics.Aggregated. Defaults to summing.
+	Aggregation MetricsAggregation
+	// DownsampleInterval makes the server combine all samples collected
+	// during the interval into a single one using Aggregation. Must be a
+	// multiple of Interval, leave at 0 to receive every sample.
+	DownsampleInterval time.Duration
+}
+
+// MetricsAggregation selects how metrics are combined across hosts and samples.
+type MetricsAggregation string
+
+// Supported metrics aggregations.
+const (
+	MetricsAggregateSum MetricsAggregation = "sum"
+	MetricsAggregateAvg MetricsAggregation = "avg"
+	MetricsAggregateMax MetricsAggregation = "max"
+)
+
+// IsValid returns whether a is a supported aggregation, the zero value is valid.
+func (a MetricsAggregation) IsValid() bool {
+	switch a {
+	case "", MetricsAggregateSum, MetricsAggregateAvg, MetricsAggregateMax:
+		return true
+	}
+	return false
 }
 
 // Metrics makes an admin call to retrieve metrics.
@@ -73,22 +104,42 @@ func (adm *AdminClient) Metrics(ctx context.Context, o MetricsOptions, out func(
 	if o.ByDisk {
 		q.Set("by-disk", "true")
 	}
+	if len(o.Buckets) > 0 {
+		q.Set("buckets", strings.Join(o.Buckets, ","))
+	}
+	if !o.Aggregation.IsValid() {
+		return ErrInvalidArgument(fmt.Sprintf("unsupported metrics aggregation %q", o.Aggregation))
+	}
+	if o.Aggregation != "" {
+		q.Set("aggregation", string(o.Aggregation))
+	}
+	if o.DownsampleInterval > 0 {
+		// Servers sample at least every second.
+		interval := o.Interval
+		if interval < time.Second {
+			interval = time.Second
+		}
+		if o.DownsampleInterval%interval != 0 {
+			return ErrInvalidArgument("downsample interval must be a multiple of the sample interval")
+		}
+		q.Set("downsample", o.DownsampleInterval.String())
+	}
 
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet, requestData{
 			relPath:     path,
 			queryValues: q,
+			category:    RequestCategoryStreaming,
 		},
 	)
 	if err != nil {
 		return err
 	}
 
+	defer closeResponse(resp)
 	if resp.StatusCode != http.StatusOK {
-		closeResponse(resp)
 		return httpRespToErrorResponse(resp)
 	}
-	defer closeResponse(resp)
 	dec := json.NewDecoder(resp.Body)
 	for {
 		var m RealtimeMetrics
@@ -122,6 +173,11 @@ type RealtimeMetrics struct {
 	Aggregated Metrics               `json:"aggregated"`
 	ByHost     map[string]Metrics    `json:"by_host,omitempty"`
 	ByDisk     map[string]DiskMetric `json:"by_disk,omitempty"`
+	// Aggregation used for Aggregated, empty for servers only summing.
+	Aggregation MetricsAggregation `json:"aggregation,omitempty"`
+	// Samples is the number of samples combined into this one
+	// when downsampling, 0 if not downsampled.
+	Samples int `json:"samples,omitempty"`
 	// Final indicates whether this is the final packet and the receiver can exit.
 	Final bool `json:"final"`
 }
@@ -131,6 +187,9 @@ type Metrics struct {
 	Scanner *ScannerMetrics `json:"scanner,omitempty"`
 	Disk    *DiskMetric     `json:"disk,omitempty"`
 	OS      *OSMetrics      `json:"os,omitempty"`
+
+	BucketLatency *BucketLatencyMetrics `json:"bucket_latency,omitempty"`
+	Runtime       *RuntimeMetrics       `json:"runtime,omitempty"`
 }
 
 // Merge other into r.
@@ -152,6 +211,16 @@ func (r *Metrics) Merge(other *Metrics) {
 		r.OS = &OSMetrics{}
 	}
 	r.OS.Merge(other.OS)
+
+	if r.BucketLatency == nil && other.BucketLatency != nil {
+		r.BucketLatency = &BucketLatencyMetrics{}
+	}
+	r.BucketLatency.Merge(other.BucketLatency)
+
+	if r.Runtime == nil && other.Runtime != nil {
+		r.Runtime = &RuntimeMetrics{}
+	}
+	r.Runtime.Merge(other.Runtime)
 }
 
 // Merge will merge other into r.
@@ -345,6 +414,74 @@ type DiskMetric struct {
 	} `json:"last_minute"`
 
 	IOStats DiskIOStats `json:"iostats,omitempty"`
+
+	// IO metrics computed from the diskstats of the
+	// interval preceding CollectedAt.
+	IOMetrics *DiskIOMetrics `json:"iometrics,omitempty"`
+}
+
+// DiskIOMetrics are the IO latency, utilization and queue depth of a
+// drive over an interval, like reported by iostat. When merged, the
+// slowest drive is kept for latencies and utilization while queue
+// depths and operations are summed.
+type DiskIOMetrics struct {
+	// Average time read and write requests took, including queueing.
+	ReadAwait  time.Duration `json:"read_await"`
+	WriteAwait time.Duration `json:"write_await"`
+	// Percentage of the interval the drive was busy.
+	Utilization float64 `json:"util"`
+	// Average number of requests queued or in flight.
+	QueueDepth float64 `json:"queue_depth"`
+	// Operations per second.
+	ReadIOPS  float64 `json:"read_iops"`
+	WriteIOPS float64 `json:"write_iops"`
+}
+
+// ComputeDiskIOMetrics computes the IO metrics of a drive from two
+// diskstats samples taken interval apart. Zero metrics are returned
+// when a counter decreased, e.g. because it wrapped or was reset.
+func ComputeDiskIOMetrics(prev, cur DiskIOStats, interval time.Duration) DiskIOMetrics {
+	var m DiskIOMetrics
+	if interval <= 0 {
+		return m
+	}
+	if cur.ReadIOs < prev.ReadIOs || cur.WriteIOs < prev.WriteIOs ||
+		cur.ReadTicks < prev.ReadTicks || cur.WriteTicks < prev.WriteTicks ||
+		cur.TotalTicks < prev.TotalTicks || cur.ReqTicks < prev.ReqTicks {
+		return m
+	}
+	ms := float64(interval) / float64(time.Millisecond)
+
+	reads := cur.ReadIOs - prev.ReadIOs
+	writes := cur.WriteIOs - prev.WriteIOs
+	if reads > 0 {
+		m.ReadAwait = time.Duration(cur.ReadTicks-prev.ReadTicks) * time.Millisecond / time.Duration(reads)
+	}
+	if writes > 0 {
+		m.WriteAwait = time.Duration(cur.WriteTicks-prev.WriteTicks) * time.Millisecond / time.Duration(writes)
+	}
+	m.Utilization = math.Min(float64(cur.TotalTicks-prev.TotalTicks)*100/ms, 100)
+	m.QueueDepth = float64(cur.ReqTicks-prev.ReqTicks) / ms
+	m.ReadIOPS = float64(reads) / interval.Seconds()
+	m.WriteIOPS = float64(writes) / interval.Seconds()
+	return m
+}
+
+// Merge other into 'm'.
+func (m *DiskIOMetrics) Merge(other *DiskIOMetrics) {
+	if other == nil {
+		return
+	}
+	if other.ReadAwait > m.ReadAwait {
+		m.ReadAwait = other.ReadAwait
+	}
+	if other.WriteAwait > m.WriteAwait {
+		m.WriteAwait = other.WriteAwait
+	}
+	m.Utilization = math.Max(m.Utilization, other.Utilization)
+	m.QueueDepth += other.QueueDepth
+	m.ReadIOPS += other.ReadIOPS
+	m.WriteIOPS += other.WriteIOPS
 }
 
 // Merge other into 's'.
@@ -376,6 +513,11 @@ func (d *DiskMetric) Merge(other *DiskMetric) {
 		total.Merge(v)
 		d.LastMinute.Operations[k] = total
 	}
+
+	if d.IOMetrics == nil && other.IOMetrics != nil {
+		d.IOMetrics = &DiskIOMetrics{}
+	}
+	d.IOMetrics.Merge(other.IOMetrics)
 }
 
 // OSMetrics contains metrics for OS operations.
@@ -390,6 +532,10 @@ type OSMetrics struct {
 	LastMinute struct {
 		Operations map[string]TimedAction `json:"operations,omitempty"`
 	} `json:"last_minute"`
+
+	// Pressure stall information of the node, when merged the
+	// highest averages and the summed stall times are kept.
+	Pressure *Pressure `json:"pressure,omitempty"`
 }
 
 // Merge other into 'o'.
@@ -418,4 +564,223 @@ func (o *OSMetrics) Merge(other *OSMetrics) {
 		total.Merge(v)
 		o.LastMinute.Operations[k] = total
 	}
+
+	if o.Pressure == nil && other.Pressure != nil {
+		o.Pressure = &Pressure{}
+	}
+	o.Pressure.Merge(other.Pressure)
+}
+
+// Merge other into 'p', keeping the highest averages of each resource
+// and summing the total stall times of all nodes.
+func (p *Pressure) Merge(other *Pressure) {
+	if other == nil {
+		return
+	}
+	p.CPU.merge(other.CPU)
+	p.IO.merge(other.IO)
+	p.Memory.merge(other.Memory)
+}
+
+func (r *ResourcePressure) merge(other ResourcePressure) {
+	r.Some = mergePressureStall(r.Some, other.Some)
+	r.Full = mergePressureStall(r.Full, other.Full)
+}
+
+func mergePressureStall(a, b *PressureStall) *PressureStall {
+	if a == nil {
+		if b == nil {
+			return nil
+		}
+		c := *b
+		return &c
+	}
+	if b == nil {
+		return a
+	}
+	a.Avg10 = math.Max(a.Avg10, b.Avg10)
+	a.Avg60 = math.Max(a.Avg60, b.Avg60)
+	a.Avg300 = math.Max(a.Avg300, b.Avg300)
+	a.Total += b.Total
+	return a
+}
+
+// LatencyBucketBounds are the upper bounds of the LatencyHistogram
+// buckets, the last bucket counts all requests slower than that.
+var LatencyBucketBounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyHistogram counts requests by latency, Counts[i] is the number
+// of requests that took at most LatencyBucketBounds[i] and longer than
+// the previous bound.
+type LatencyHistogram struct {
+	Counts []uint64 `json:"counts"`
+}
+
+// N returns the total number of requests in the histogram.
+func (h LatencyHistogram) N() (n uint64) {
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Percentile returns the upper bound of the bucket holding the p-th
+// percentile, 0 < p <= 1, or the highest bound if it falls beyond.
+func (h LatencyHistogram) Percentile(p float64) time.Duration {
+	n := h.N()
+	if n == 0 || len(LatencyBucketBounds) == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p * float64(n)))
+	var seen uint64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= target && i < len(LatencyBucketBounds) {
+			return LatencyBucketBounds[i]
+		}
+	}
+	return LatencyBucketBounds[len(LatencyBucketBounds)-1]
+}
+
+// P50 returns the median latency.
+func (h LatencyHistogram) P50() time.Duration { return h.Percentile(0.5) }
+
+// P90 returns the 90th percentile latency.
+func (h LatencyHistogram) P90() time.Duration { return h.Percentile(0.9) }
+
+// P99 returns the 99th percentile latency.
+func (h LatencyHistogram) P99() time.Duration { return h.Percentile(0.99) }
+
+// Merge other into 'h'.
+func (h *LatencyHistogram) Merge(other LatencyHistogram) {
+	if len(h.Counts) < len(other.Counts) {
+		counts := make([]uint64, len(other.Counts))
+		copy(counts, h.Counts)
+		h.Counts = counts
+	}
+	for i, c := range other.Counts {
+		h.Counts[i] += c
+	}
+}
+
+// BucketLatencyMetrics contains the last minute latency
+// histograms of API calls, per bucket and per API.
+type BucketLatencyMetrics struct {
+	// Time these metrics were collected
+	CollectedAt time.Time `json:"collected"`
+
+	// Latency histograms by bucket and then by API, e.g. "PutObject".
+	Buckets map[string]map[string]LatencyHistogram `json:"buckets,omitempty"`
+}
+
+// Merge other into 'b'.
+func (b *BucketLatencyMetrics) Merge(other *BucketLatencyMetrics) {
+	if other == nil {
+		return
+	}
+	if b.CollectedAt.Before(other.CollectedAt) {
+		// Use latest timestamp
+		b.CollectedAt = other.CollectedAt
+	}
+
+	if b.Buckets == nil && len(other.Buckets) > 0 {
+		b.Buckets = make(map[string]map[string]LatencyHistogram, len(other.Buckets))
+	}
+	for bucket, apis := range other.Buckets {
+		if b.Buckets[bucket] == nil {
+			b.Buckets[bucket] = make(map[string]LatencyHistogram, len(apis))
+		}
+		for api, h := range apis {
+			total := b.Buckets[bucket][api]
+			total.Merge(h)
+			b.Buckets[bucket][api] = total
+		}
+	}
+}
+
+// RuntimeMetrics contains Go runtime and process statistics of MinIO servers.
+type RuntimeMetrics struct {
+	// Time these metrics were collected
+	CollectedAt time.Time `json:"collected"`
+
+	// Number of servers these metrics were collected from.
+	Nodes int `json:"nodes"`
+
+	HeapInUse  uint64 `json:"heap_inuse"`
+	HeapAlloc  uint64 `json:"heap_alloc"`
+	Sys        uint64 `json:"sys"`
+	Goroutines int    `json:"goroutines"`
+
+	// Garbage collections since server start and pause time
+	// percentiles over the last minute.
+	NumGC      uint32        `json:"num_gc"`
+	GCPauseP50 time.Duration `json:"gc_pause_p50"`
+	GCPauseP99 time.Duration `json:"gc_pause_p99"`
+	GCPauseMax time.Duration `json:"gc_pause_max"`
+
+	OpenFDs uint64 `json:"open_fds"`
+	MaxFDs  uint64 `json:"max_fds"`
+}
+
+// Merge other into 'r', counters are summed
+// while GC pauses keep the worst of all nodes.
+func (r *RuntimeMetrics) Merge(other *RuntimeMetrics) {
+	if other == nil {
+		return
+	}
+	if r.CollectedAt.Before(other.CollectedAt) {
+		// Use latest timestamp
+		r.CollectedAt = other.CollectedAt
+	}
+	nodes := other.Nodes
+	if nodes == 0 {
+		nodes = 1
+	}
+	r.Nodes += nodes
+	r.HeapInUse += other.HeapInUse
+	r.HeapAlloc += other.HeapAlloc
+	r.Sys += other.Sys
+	r.Goroutines += other.Goroutines
+	r.NumGC += other.NumGC
+	r.OpenFDs += other.OpenFDs
+	r.MaxFDs += other.MaxFDs
+	if other.GCPauseP50 > r.GCPauseP50 {
+		r.GCPauseP50 = other.GCPauseP50
+	}
+	if other.GCPauseP99 > r.GCPauseP99 {
+		r.GCPauseP99 = other.GCPauseP99
+	}
+	if other.GCPauseMax > r.GCPauseMax {
+		r.GCPauseMax = other.GCPauseMax
+	}
+}
+
+// RuntimeStats returns the Go runtime and process statistics
+// of each server, keyed by host.
+func (adm *AdminClient) RuntimeStats(ctx context.Context) (map[string]RuntimeMetrics, error) {
+	stats := make(map[string]RuntimeMetrics)
+	err := adm.Metrics(ctx, MetricsOptions{Type: MetricsRuntime, N: 1, ByHost: true}, func(m RealtimeMetrics) {
+		for host, metrics := range m.ByHost {
+			if metrics.Runtime != nil {
+				stats[host] = *metrics.Runtime
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
 }
@@ -0,0 +1,245 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestComputeDiskIOMetrics(t *testing.T) {
+	prev := DiskIOStats{ReadIOs: 100, ReadTicks: 1000, WriteIOs: 50, WriteTicks: 500, TotalTicks: 2000, ReqTicks: 3000}
+	cur := DiskIOStats{ReadIOs: 200, ReadTicks: 1500, WriteIOs: 70, WriteTicks: 900, TotalTicks: 2500, ReqTicks: 5000}
+
+	m := ComputeDiskIOMetrics(prev, cur, time.Second)
+	if m.ReadAwait != 5*time.Millisecond || m.WriteAwait != 20*time.Millisecond {
+		t.Errorf("unexpected await %v %v", m.ReadAwait, m.WriteAwait)
+	}
+	if m.Utilization != 50 || m.QueueDepth != 2 {
+		t.Errorf("unexpected utilization %v, queue depth %v", m.Utilization, m.QueueDepth)
+	}
+	if m.ReadIOPS != 100 || m.WriteIOPS != 20 {
+		t.Errorf("unexpected iops %v %v", m.ReadIOPS, m.WriteIOPS)
+	}
+
+	// Counters reset, e.g. after a reboot, yield zero metrics.
+	for _, reset := range []DiskIOStats{
+		{ReadIOs: 1, ReadTicks: 1500, WriteIOs: 70, WriteTicks: 900, TotalTicks: 2500, ReqTicks: 5000},
+		{ReadIOs: 200, ReadTicks: 1500, WriteIOs: 70, WriteTicks: 900, TotalTicks: 10, ReqTicks: 5000},
+		{ReadIOs: 200, ReadTicks: 1500, WriteIOs: 70, WriteTicks: 900, TotalTicks: 2500, ReqTicks: 10},
+	} {
+		if zero := ComputeDiskIOMetrics(prev, reset, time.Second); zero != (DiskIOMetrics{}) {
+			t.Errorf("expected zero metrics after a counter reset, got %+v", zero)
+		}
+	}
+
+	var total DiskMetric
+	total.Merge(&DiskMetric{IOMetrics: &m})
+	total.Merge(&DiskMetric{IOMetrics: &DiskIOMetrics{ReadAwait: time.Second, Utilization: 100, QueueDepth: 1}})
+	if total.IOMetrics.ReadAwait != time.Second || total.IOMetrics.Utilization != 100 || total.IOMetrics.QueueDepth != 3 {
+		t.Errorf("unexpected merged metrics %+v", total.IOMetrics)
+	}
+}
+
+func TestPressureMerge(t *testing.T) {
+	p := Pressure{
+		CPU: ResourcePressure{Some: &PressureStall{Avg10: 1, Avg60: 5, Avg300: 2, Total: 100}},
+	}
+	other := &Pressure{
+		CPU: ResourcePressure{Some: &PressureStall{Avg10: 3, Avg60: 4, Avg300: 2, Total: 50}},
+		IO:  ResourcePressure{Full: &PressureStall{Avg10: 7, Total: 30}},
+	}
+	p.Merge(other)
+	p.Merge(nil)
+
+	expected := PressureStall{Avg10: 3, Avg60: 5, Avg300: 2, Total: 150}
+	if *p.CPU.Some != expected {
+		t.Errorf("expected cpu %+v, got %+v", expected, *p.CPU.Some)
+	}
+	if p.IO.Full == nil || *p.IO.Full != *other.IO.Full || p.IO.Full == other.IO.Full {
+		t.Errorf("expected copy of io %+v, got %+v", *other.IO.Full, p.IO.Full)
+	}
+	if p.Memory.Some != nil || p.Memory.Full != nil {
+		t.Errorf("expected no memory pressure, got %+v", p.Memory)
+	}
+}
+
+func TestRuntimeStats(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/metrics" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("types") != strconv.FormatUint(uint64(MetricsRuntime), 10) || q.Get("by-host") != "true" || q.Get("n") != "1" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		json.NewEncoder(w).Encode(RealtimeMetrics{
+			ByHost: map[string]Metrics{
+				"node1:9000": {Runtime: &RuntimeMetrics{Goroutines: 120, GCPauseP99: time.Millisecond}},
+				"node2:9000": {},
+			},
+			Final: true,
+		})
+	})
+
+	stats, err := adm.RuntimeStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats["node1:9000"].Goroutines != 120 || stats["node1:9000"].GCPauseP99 != time.Millisecond {
+		t.Fatalf("unexpected runtime stats %+v", stats)
+	}
+
+	adm = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	if _, err = adm.RuntimeStats(context.Background()); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+}
+
+func TestRuntimeMetricsMerge(t *testing.T) {
+	var m RuntimeMetrics
+	m.Merge(&RuntimeMetrics{Goroutines: 10, OpenFDs: 5, GCPauseMax: 2 * time.Millisecond})
+	m.Merge(&RuntimeMetrics{Nodes: 2, Goroutines: 20, OpenFDs: 7, GCPauseMax: time.Millisecond})
+	if m.Nodes != 3 || m.Goroutines != 30 || m.OpenFDs != 12 || m.GCPauseMax != 2*time.Millisecond {
+		t.Fatalf("unexpected merged metrics %+v", m)
+	}
+}
+
+func TestMetricsAggregationIsValid(t *testing.T) {
+	testCases := []struct {
+		aggregation MetricsAggregation
+		valid       bool
+	}{
+		{"", true},
+		{MetricsAggregateSum, true},
+		{MetricsAggregateAvg, true},
+		{MetricsAggregateMax, true},
+		{"min", false},
+		{"SUM", false},
+	}
+	for i, tc := range testCases {
+		if got := tc.aggregation.IsValid(); got != tc.valid {
+			t.Errorf("Test %d: expected valid %t for %q, got %t", i+1, tc.valid, tc.aggregation, got)
+		}
+	}
+}
+
+func TestMetricsDownsample(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("aggregation") != "max" || q.Get("downsample") != "1m0s" || q.Get("interval") != "10s" {
+			writeTestError(w, http.StatusBadRequest, "XMinioInvalidQuery")
+			return
+		}
+		json.NewEncoder(w).Encode(RealtimeMetrics{Aggregation: MetricsAggregateMax, Samples: 6, Final: true})
+	})
+
+	ctx := context.Background()
+	var samples []RealtimeMetrics
+	opts := MetricsOptions{Interval: 10 * time.Second, Aggregation: MetricsAggregateMax, DownsampleInterval: time.Minute}
+	if err := adm.Metrics(ctx, opts, func(m RealtimeMetrics) { samples = append(samples, m) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 1 || samples[0].Samples != 6 || samples[0].Aggregation != MetricsAggregateMax {
+		t.Fatalf("unexpected samples %+v", samples)
+	}
+
+	invalid := []MetricsOptions{
+		{Interval: 10 * time.Second, DownsampleInterval: 5 * time.Second},
+		{Interval: 10 * time.Second, DownsampleInterval: 25 * time.Second},
+		{DownsampleInterval: 1500 * time.Millisecond},
+		{Aggregation: "min"},
+	}
+	for i, o := range invalid {
+		err := adm.Metrics(ctx, o, func(RealtimeMetrics) { t.Errorf("Test %d: unexpected metrics", i+1) })
+		if ToErrorResponse(err).Code != "InvalidArgument" {
+			t.Errorf("Test %d: expected InvalidArgument, got %v", i+1, err)
+		}
+	}
+}
+
+func TestLatencyHistogramPercentile(t *testing.T) {
+	testCases := []struct {
+		counts        []uint64
+		p50, p90, p99 time.Duration
+	}{
+		// Empty histograms.
+		{nil, 0, 0, 0},
+		{[]uint64{0, 0, 0}, 0, 0, 0},
+		// A single bucket holds all requests.
+		{[]uint64{0, 0, 4}, 10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond},
+		// 100 requests spread over three buckets.
+		{[]uint64{50, 40, 10}, time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond},
+		{[]uint64{49, 41, 10}, 5 * time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond},
+		// Requests slower than the last bound.
+		{append(make([]uint64, len(LatencyBucketBounds)), 1), 10 * time.Second, 10 * time.Second, 10 * time.Second},
+	}
+	for i, testCase := range testCases {
+		h := LatencyHistogram{Counts: testCase.counts}
+		if p50 := h.P50(); p50 != testCase.p50 {
+			t.Errorf("Test %d: expected p50 %v, got %v", i+1, testCase.p50, p50)
+		}
+		if p90 := h.P90(); p90 != testCase.p90 {
+			t.Errorf("Test %d: expected p90 %v, got %v", i+1, testCase.p90, p90)
+		}
+		if p99 := h.P99(); p99 != testCase.p99 {
+			t.Errorf("Test %d: expected p99 %v, got %v", i+1, testCase.p99, p99)
+		}
+	}
+}
+
+func TestLatencyHistogramMerge(t *testing.T) {
+	testCases := []struct {
+		a, b     []uint64
+		expected []uint64
+	}{
+		{nil, nil, nil},
+		{nil, []uint64{1, 2}, []uint64{1, 2}},
+		{[]uint64{1, 2}, nil, []uint64{1, 2}},
+		{[]uint64{1, 2, 3}, []uint64{4, 5}, []uint64{5, 7, 3}},
+		{[]uint64{1}, []uint64{0, 0, 3}, []uint64{1, 0, 3}},
+	}
+	for i, testCase := range testCases {
+		h := LatencyHistogram{Counts: testCase.a}
+		h.Merge(LatencyHistogram{Counts: testCase.b})
+		if !reflect.DeepEqual(h.Counts, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, h.Counts)
+		}
+	}
+
+	// Merging must not modify the merged histogram.
+	other := LatencyHistogram{Counts: []uint64{1, 1}}
+	var b BucketLatencyMetrics
+	b.Merge(&BucketLatencyMetrics{Buckets: map[string]map[string]LatencyHistogram{"photos": {"GetObject": other}}})
+	b.Merge(&BucketLatencyMetrics{Buckets: map[string]map[string]LatencyHistogram{"photos": {"GetObject": other}}})
+	b.Merge(nil)
+	if merged := b.Buckets["photos"]["GetObject"]; !reflect.DeepEqual(merged.Counts, []uint64{2, 2}) {
+		t.Errorf("expected merged counts [2 2], got %v", merged.Counts)
+	}
+	if !reflect.DeepEqual(other.Counts, []uint64{1, 1}) {
+		t.Errorf("merged histogram was modified: %v", other.Counts)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build linux
+// +build linux
+
+//
+// MinIO Object Storage (c) 2021-2022 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/procfs"
+	"github.com/prometheus/procfs/sysfs"
+)
+
+const sysClassNet = "/sys/class/net"
+
+func getNetInterfaces() ([]NetInterface, error) {
+	fs, err := sysfs.NewFS("/sys")
+	if err != nil {
+		return nil, err
+	}
+	netClass, err := fs.NetClass()
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := procfs.NewFS("/proc")
+	if err != nil {
+		return nil, err
+	}
+	netDev, err := proc.NetDev()
+	if err != nil {
+		return nil, err
+	}
+
+	ifaces := make([]NetInterface, 0, len(netClass))
+	for name, nc := range netClass {
+		if name == "lo" {
+			continue
+		}
+		iface := NetInterface{
+			Name:      name,
+			OperState: nc.OperState,
+			Duplex:    nc.Duplex,
+		}
+		// Speed is reported as -1 when the link is down.
+		if nc.Speed != nil && *nc.Speed > 0 {
+			iface.Speed = *nc.Speed
+		}
+		if nc.MTU != nil {
+			iface.MTU = *nc.MTU
+		}
+		if nc.CarrierChanges != nil {
+			iface.CarrierChanges = *nc.CarrierChanges
+		}
+
+		dir := filepath.Join(sysClassNet, name)
+		if driver, err := os.Readlink(filepath.Join(dir, "device", "driver")); err == nil {
+			iface.Driver = filepath.Base(driver)
+		}
+		if master, err := os.Readlink(filepath.Join(dir, "master")); err == nil {
+			iface.BondMaster = filepath.Base(master)
+		}
+		if mode, err := ioutil.ReadFile(filepath.Join(dir, "bonding", "mode")); err == nil {
+			// e.g. "802.3ad 4"
+			if fields := strings.Fields(string(mode)); len(fields) > 0 {
+				iface.BondMode = fields[0]
+			}
+		}
+		if slaves, err := ioutil.ReadFile(filepath.Join(dir, "bonding", "slaves")); err == nil {
+			iface.BondSlaves = strings.Fields(string(slaves))
+		}
+
+		if dev, ok := netDev[name]; ok {
+			iface.RxBytes = dev.RxBytes
+			iface.RxErrors = dev.RxErrors
+			iface.RxDropped = dev.RxDropped
+			iface.TxBytes = dev.TxBytes
+			iface.TxErrors = dev.TxErrors
+			iface.TxDropped = dev.TxDropped
+		}
+		ifaces = append(ifaces, iface)
+	}
+
+	sort.Slice(ifaces, func(i, j int) bool {
+		return ifaces[i].Name < ifaces[j].Name
+	})
+	return ifaces, nil
+}
@@ -0,0 +1,410 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isTargetSubSys returns true if subSys configures logger,
+// audit or bucket notification targets.
+func isTargetSubSys(subSys string) bool {
+	switch subSys {
+	case LoggerWebhookSubSys, AuditWebhookSubSys, AuditKafkaSubSys:
+		return true
+	}
+	return strings.HasPrefix(subSys, "notify_") && SubSystems.Contains(subSys)
+}
+
+// TargetTestResult - result of sending a synthetic event to a target
+type TargetTestResult struct {
+	SubSys    string        `json:"subSys"`
+	TargetID  string        `json:"targetID"`
+	Delivered bool          `json:"delivered"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// TestNotificationTarget - sends a synthetic event to the logger, audit or
+// notification target targetID of subSys (e.g. NotifyWebhookSubSys), the
+// delivery latency or the precise delivery error is returned. Use an empty
+// targetID for the default target of the subsystem.
+func (adm *AdminClient) TestNotificationTarget(ctx context.Context, subSys, targetID string) (res TargetTestResult, err error) {
+	if !isTargetSubSys(subSys) {
+		return res, ErrInvalidArgument("unsupported target subsystem " + subSys)
+	}
+	queryValues := url.Values{}
+	queryValues.Set("subSys", subSys)
+	queryValues.Set("target", targetID)
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		relPath:     adminAPIPrefix + "/target/test",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return res, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return res, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	return res, err
+}
+
+// NotificationTarget is implemented by the typed bucket notification
+// target configurations, e.g. WebhookTarget or KafkaTarget.
+type NotificationTarget interface {
+	// SubSys returns the config subsystem of the target type.
+	SubSys() string
+
+	fields() []targetField
+	validate() error
+}
+
+// targetField maps a config key to exactly one typed target field.
+type targetField struct {
+	key  string
+	str  *string
+	b    *bool
+	i    *int
+	list *[]string
+}
+
+func (f targetField) value() string {
+	switch {
+	case f.str != nil:
+		return *f.str
+	case f.b != nil:
+		if *f.b {
+			return EnableOn
+		}
+		return EnableOff
+	case f.i != nil:
+		if *f.i == 0 {
+			return ""
+		}
+		return strconv.Itoa(*f.i)
+	case f.list != nil:
+		return strings.Join(*f.list, ",")
+	}
+	return ""
+}
+
+func (f targetField) set(v string) error {
+	switch {
+	case f.str != nil:
+		*f.str = v
+	case f.b != nil:
+		*f.b = v == EnableOn || v == "true"
+	case f.i != nil:
+		if v == "" {
+			*f.i = 0
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", v, f.key, err)
+		}
+		*f.i = n
+	case f.list != nil:
+		*f.list = nil
+		if v != "" {
+			*f.list = strings.Split(v, ",")
+		}
+	}
+	return nil
+}
+
+// targetKVString returns the target configuration as space
+// separated key=value pairs, including empty and disabled values.
+func targetKVString(t NotificationTarget) string {
+	kvs := []ConfigKV{{Key: EnableKey, Value: EnableOn}}
+	for _, f := range t.fields() {
+		kvs = append(kvs, ConfigKV{Key: f.key, Value: f.value()})
+	}
+	return configKVString(kvs)
+}
+
+// setTargetFields sets the fields of t from the server config cfg.
+func setTargetFields(t NotificationTarget, cfg SubsysConfig) error {
+	for _, f := range t.fields() {
+		if v, ok := cfg.Lookup(f.key); ok {
+			if err := f.set(SanitizeValue(v)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WebhookTarget - configuration of a webhook notification target
+type WebhookTarget struct {
+	Endpoint   string `json:"endpoint"`
+	AuthToken  string `json:"authToken,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+	QueueDir   string `json:"queueDir,omitempty"`
+	QueueLimit int    `json:"queueLimit,omitempty"`
+}
+
+// SubSys returns NotifyWebhookSubSys
+func (t *WebhookTarget) SubSys() string { return NotifyWebhookSubSys }
+
+func (t *WebhookTarget) fields() []targetField {
+	return []targetField{
+		{key: "endpoint", str: &t.Endpoint},
+		{key: "auth_token", str: &t.AuthToken},
+		{key: "client_cert", str: &t.ClientCert},
+		{key: "client_key", str: &t.ClientKey},
+		{key: "queue_dir", str: &t.QueueDir},
+		{key: "queue_limit", i: &t.QueueLimit},
+	}
+}
+
+func (t *WebhookTarget) validate() error {
+	if t.Endpoint == "" {
+		return ErrInvalidArgument("webhook endpoint cannot be empty")
+	}
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return ErrInvalidArgument("webhook client cert and key must be set together")
+	}
+	return nil
+}
+
+// KafkaTarget - configuration of a Kafka notification target
+type KafkaTarget struct {
+	Brokers       []string `json:"brokers"`
+	Topic         string   `json:"topic"`
+	TLS           bool     `json:"tls,omitempty"`
+	TLSSkipVerify bool     `json:"tlsSkipVerify,omitempty"`
+	SASL          bool     `json:"sasl,omitempty"`
+	SASLUsername  string   `json:"saslUsername,omitempty"`
+	SASLPassword  string   `json:"saslPassword,omitempty"`
+	SASLMechanism string   `json:"saslMechanism,omitempty"`
+	QueueDir      string   `json:"queueDir,omitempty"`
+	QueueLimit    int      `json:"queueLimit,omitempty"`
+}
+
+// SubSys returns NotifyKafkaSubSys
+func (t *KafkaTarget) SubSys() string { return NotifyKafkaSubSys }
+
+func (t *KafkaTarget) fields() []targetField {
+	return []targetField{
+		{key: "brokers", list: &t.Brokers},
+		{key: "topic", str: &t.Topic},
+		{key: "tls", b: &t.TLS},
+		{key: "tls_skip_verify", b: &t.TLSSkipVerify},
+		{key: "sasl", b: &t.SASL},
+		{key: "sasl_username", str: &t.SASLUsername},
+		{key: "sasl_password", str: &t.SASLPassword},
+		{key: "sasl_mechanism", str: &t.SASLMechanism},
+		{key: "queue_dir", str: &t.QueueDir},
+		{key: "queue_limit", i: &t.QueueLimit},
+	}
+}
+
+func (t *KafkaTarget) validate() error {
+	if len(t.Brokers) == 0 {
+		return ErrInvalidArgument("kafka brokers cannot be empty")
+	}
+	if t.Topic == "" {
+		return ErrInvalidArgument("kafka topic cannot be empty")
+	}
+	if t.SASL && t.SASLUsername == "" {
+		return ErrInvalidArgument("kafka SASL username cannot be empty")
+	}
+	return nil
+}
+
+// AMQPTarget - configuration of an AMQP notification target
+type AMQPTarget struct {
+	URL          string `json:"url"`
+	Exchange     string `json:"exchange,omitempty"`
+	ExchangeType string `json:"exchangeType,omitempty"`
+	RoutingKey   string `json:"routingKey,omitempty"`
+	Mandatory    bool   `json:"mandatory,omitempty"`
+	Durable      bool   `json:"durable,omitempty"`
+	DeliveryMode int    `json:"deliveryMode,omitempty"`
+	QueueDir     string `json:"queueDir,omitempty"`
+	QueueLimit   int    `json:"queueLimit,omitempty"`
+}
+
+// SubSys returns NotifyAMQPSubSys
+func (t *AMQPTarget) SubSys() string { return NotifyAMQPSubSys }
+
+func (t *AMQPTarget) fields() []targetField {
+	return []targetField{
+		{key: "url", str: &t.URL},
+		{key: "exchange", str: &t.Exchange},
+		{key: "exchange_type", str: &t.ExchangeType},
+		{key: "routing_key", str: &t.RoutingKey},
+		{key: "mandatory", b: &t.Mandatory},
+		{key: "durable", b: &t.Durable},
+		{key: "delivery_mode", i: &t.DeliveryMode},
+		{key: "queue_dir", str: &t.QueueDir},
+		{key: "queue_limit", i: &t.QueueLimit},
+	}
+}
+
+func (t *AMQPTarget) validate() error {
+	if t.URL == "" {
+		return ErrInvalidArgument("amqp url cannot be empty")
+	}
+	if t.DeliveryMode != 0 && t.DeliveryMode != 1 && t.DeliveryMode != 2 {
+		return ErrInvalidArgument("amqp delivery mode must be 1 or 2")
+	}
+	return nil
+}
+
+// ElasticsearchTarget - configuration of an Elasticsearch notification target
+type ElasticsearchTarget struct {
+	URL        string `json:"url"`
+	Format     string `json:"format"` // "namespace" or "access"
+	Index      string `json:"index"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	QueueDir   string `json:"queueDir,omitempty"`
+	QueueLimit int    `json:"queueLimit,omitempty"`
+}
+
+// SubSys returns NotifyESSubSys
+func (t *ElasticsearchTarget) SubSys() string { return NotifyESSubSys }
+
+func (t *ElasticsearchTarget) fields() []targetField {
+	return []targetField{
+		{key: "url", str: &t.URL},
+		{key: "format", str: &t.Format},
+		{key: "index", str: &t.Index},
+		{key: "username", str: &t.Username},
+		{key: "password", str: &t.Password},
+		{key: "queue_dir", str: &t.QueueDir},
+		{key: "queue_limit", i: &t.QueueLimit},
+	}
+}
+
+func (t *ElasticsearchTarget) validate() error {
+	if t.URL == "" || t.Index == "" {
+		return ErrInvalidArgument("elasticsearch url and index cannot be empty")
+	}
+	if t.Format != "namespace" && t.Format != "access" {
+		return ErrInvalidArgument("elasticsearch format must be namespace or access")
+	}
+	return nil
+}
+
+// newNotificationTarget returns an empty typed target of subSys.
+func newNotificationTarget(subSys string) (NotificationTarget, error) {
+	switch subSys {
+	case NotifyWebhookSubSys:
+		return &WebhookTarget{}, nil
+	case NotifyKafkaSubSys:
+		return &KafkaTarget{}, nil
+	case NotifyAMQPSubSys:
+		return &AMQPTarget{}, nil
+	case NotifyESSubSys:
+		return &ElasticsearchTarget{}, nil
+	}
+	return nil, ErrInvalidArgument("unsupported notification target subsystem " + subSys)
+}
+
+func targetConfigKey(subSys, id string) string {
+	if id == "" || id == Default {
+		return subSys
+	}
+	return subSys + SubSystemSeparator + id
+}
+
+// SetNotificationTarget - creates or updates the notification target id of
+// the target's type, an empty id configures the default target.
+func (adm *AdminClient) SetNotificationTarget(ctx context.Context, id string, t NotificationTarget) (restart bool, err error) {
+	if err = t.validate(); err != nil {
+		return false, err
+	}
+	return adm.SetConfigKV(ctx, targetConfigKey(t.SubSys(), id)+KvSpaceSeparator+targetKVString(t))
+}
+
+// GetNotificationTarget - returns the typed configuration of the notification
+// target id of subSys, e.g. a *WebhookTarget for NotifyWebhookSubSys.
+func (adm *AdminClient) GetNotificationTarget(ctx context.Context, subSys, id string) (NotificationTarget, error) {
+	t, err := newNotificationTarget(subSys)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := adm.GetConfigKV(ctx, targetConfigKey(subSys, id))
+	if err != nil {
+		return nil, err
+	}
+	cfgs, err := ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return nil, err
+	}
+	if len(cfgs) == 0 {
+		return nil, ErrInvalidArgument("notification target " + targetConfigKey(subSys, id) + " not found")
+	}
+	if err = setTargetFields(t, cfgs[0]); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// RemoveNotificationTarget - removes the notification target id of subSys.
+func (adm *AdminClient) RemoveNotificationTarget(ctx context.Context, subSys, id string) (restart bool, err error) {
+	if _, err = newNotificationTarget(subSys); err != nil {
+		return false, err
+	}
+	return adm.DelConfigKV(ctx, targetConfigKey(subSys, id))
+}
+
+// NotificationTargetStatus - runtime status of a notification target
+type NotificationTargetStatus struct {
+	SubSys     string `json:"subSys"`
+	ID         string `json:"id"`
+	ARN        string `json:"arn"`
+	Online     bool   `json:"online"`
+	QueueDepth uint64 `json:"queueDepth"`
+	LastError  string `json:"lastError,omitempty"`
+}
+
+// ListNotificationTargets - returns the status of all configured
+// notification targets.
+func (adm *AdminClient) ListNotificationTargets(ctx context.Context) ([]NotificationTargetStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: adminAPIPrefix + "/target/list",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var targets []NotificationTargetStatus
+	err = json.NewDecoder(resp.Body).Decode(&targets)
+	return targets, err
+}
@@ -0,0 +1,105 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNotificationTargetKVString(t *testing.T) {
+	testCases := []struct {
+		target NotificationTarget
+		kv     string
+	}{
+		{
+			target: &WebhookTarget{Endpoint: "http://localhost:8080", AuthToken: "Bearer token", QueueLimit: 1000},
+			kv:     `enable=on endpoint=http://localhost:8080 auth_token="Bearer token" client_cert="" client_key="" queue_dir="" queue_limit=1000`,
+		},
+		{
+			target: &KafkaTarget{Brokers: []string{"k1:9092", "k2:9092"}, Topic: "events", TLS: true},
+			kv: `enable=on brokers=k1:9092,k2:9092 topic=events tls=on tls_skip_verify=off sasl=off ` +
+				`sasl_username="" sasl_password="" sasl_mechanism="" queue_dir="" queue_limit=""`,
+		},
+		{
+			// Values with quotes and spaces.
+			target: &WebhookTarget{Endpoint: "http://localhost:8080", AuthToken: `Bearer "quoted" token`, QueueDir: "/tmp/it's"},
+			kv:     `enable=on endpoint=http://localhost:8080 auth_token='Bearer "quoted" token' client_cert="" client_key="" queue_dir="/tmp/it's" queue_limit=""`,
+		},
+	}
+	for i, tc := range testCases {
+		kv := targetKVString(tc.target)
+		if kv != tc.kv {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, tc.kv, kv)
+		}
+
+		cfgs, err := ParseServerConfigOutput(tc.target.SubSys() + ":id " + kv)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		parsed, err := newNotificationTarget(tc.target.SubSys())
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if err = setTargetFields(parsed, cfgs[0]); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(parsed, tc.target) {
+			t.Errorf("Test %d: expected %#v, got %#v", i+1, tc.target, parsed)
+		}
+	}
+}
+
+func TestTestNotificationTarget(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/target/test" || q.Get("subSys") != NotifyWebhookSubSys {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		if q.Get("target") != "primary" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchTarget")
+			return
+		}
+		json.NewEncoder(w).Encode(TargetTestResult{
+			SubSys:    NotifyWebhookSubSys,
+			TargetID:  "primary",
+			Delivered: true,
+			Latency:   20 * time.Millisecond,
+		})
+	})
+
+	ctx := context.Background()
+	res, err := adm.TestNotificationTarget(ctx, NotifyWebhookSubSys, "primary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Delivered || res.Latency != 20*time.Millisecond || res.TargetID != "primary" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	if _, err = adm.TestNotificationTarget(ctx, NotifyWebhookSubSys, "other"); ToErrorResponse(err).Code != "XMinioAdminNoSuchTarget" {
+		t.Errorf("expected XMinioAdminNoSuchTarget, got %v", err)
+	}
+	if _, err = adm.TestNotificationTarget(ctx, APISubSys, ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ShardState is the state of an erasure coded shard of an object.
+type ShardState string
+
+// Shard states reported by ObjectLayout.
+const (
+	ShardStateOk      ShardState = "ok"
+	ShardStateMissing ShardState = "missing"
+	ShardStateStale   ShardState = "stale"
+	ShardStateCorrupt ShardState = "corrupt"
+	ShardStateOffline ShardState = "offline"
+)
+
+// ObjectShard describes a shard of an object and the drive holding it.
+type ObjectShard struct {
+	// Index of the shard in the erasure distribution, starting at 1.
+	Index     int        `json:"index"`
+	Endpoint  string     `json:"endpoint"`
+	DrivePath string     `json:"path"`
+	DiskIndex int        `json:"diskIndex"`
+	State     ShardState `json:"state"`
+
+	// Metadata of the version as stored on the drive, a stale
+	// shard holds an older modification time than the object.
+	ModTime time.Time `json:"modTime,omitempty"`
+	// Bitrot checksums of the parts of the shard.
+	Algorithm string   `json:"algorithm,omitempty"`
+	Checksums []string `json:"checksums,omitempty"`
+}
+
+// ObjectLayout describes the erasure coding and
+// shard placement of an object version.
+type ObjectLayout struct {
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	ModTime   time.Time `json:"modTime"`
+	Size      int64     `json:"size"`
+
+	PoolIndex    int   `json:"poolIndex"`
+	SetIndex     int   `json:"setIndex"`
+	DataBlocks   int   `json:"dataBlocks"`
+	ParityBlocks int   `json:"parityBlocks"`
+	BlockSize    int64 `json:"blockSize"`
+
+	Shards []ObjectShard `json:"shards"`
+}
+
+// Unhealthy returns the shards not in the ok state.
+func (l ObjectLayout) Unhealthy() []ObjectShard {
+	var shards []ObjectShard
+	for _, shard := range l.Shards {
+		if shard.State != ShardStateOk {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+// Readable returns true if enough shards are healthy to read the object.
+func (l ObjectLayout) Readable() bool {
+	return len(l.Shards)-len(l.Unhealthy()) >= l.DataBlocks
+}
+
+// ObjectLayout - returns the erasure coding and shard placement of
+// the object version as recorded in the metadata of each drive of its
+// erasure set, an empty versionID selects the latest version.
+func (adm *AdminClient) ObjectLayout(ctx context.Context, bucket, object, versionID string) (ObjectLayout, error) {
+	if bucket == "" || object == "" {
+		return ObjectLayout{}, ErrInvalidArgument("bucket and object must be set")
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+	if versionID != "" {
+		queryValues.Set("versionId", versionID)
+	}
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet, requestData{
+			relPath:     adminAPIPrefix + "/object-layout",
+			queryValues: queryValues,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return ObjectLayout{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ObjectLayout{}, httpRespToErrorResponse(resp)
+	}
+
+	var layout ObjectLayout
+	err = json.NewDecoder(resp.Body).Decode(&layout)
+	return layout, err
+}
@@ -0,0 +1,70 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestObjectLayout(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/object-layout" || q.Get("bucket") != "photos" {
+			writeTestError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		if q.Get("object") != "2021/cat.jpg" || q.Get("versionId") != "v1" {
+			writeTestError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		json.NewEncoder(w).Encode(ObjectLayout{
+			Bucket:       "photos",
+			Object:       "2021/cat.jpg",
+			VersionID:    "v1",
+			DataBlocks:   2,
+			ParityBlocks: 2,
+			Shards: []ObjectShard{
+				{Index: 1, Endpoint: "node1:9000", State: ShardStateOk},
+				{Index: 2, Endpoint: "node2:9000", State: ShardStateStale},
+				{Index: 3, Endpoint: "node3:9000", State: ShardStateOk},
+				{Index: 4, Endpoint: "node4:9000", State: ShardStateOffline},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	layout, err := adm.ObjectLayout(ctx, "photos", "2021/cat.jpg", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout.Shards) != 4 || layout.DataBlocks != 2 || !layout.Readable() {
+		t.Fatalf("unexpected layout %+v", layout)
+	}
+	if unhealthy := layout.Unhealthy(); len(unhealthy) != 2 || unhealthy[0].State != ShardStateStale || unhealthy[1].Index != 4 {
+		t.Fatalf("unexpected unhealthy shards %+v", unhealthy)
+	}
+
+	if _, err = adm.ObjectLayout(ctx, "photos", "2021/dog.jpg", ""); ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Errorf("expected NoSuchKey, got %v", err)
+	}
+	if _, err = adm.ObjectLayout(ctx, "photos", "", ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -118,6 +118,33 @@ func HasSpace(s string) bool {
 	return false
 }
 
+// quoteConfigValue quotes v for a config set call when needed, so the
+// server reads it back unchanged. Empty values are sent as "", values
+// containing double quotes are single quoted.
+func quoteConfigValue(v string) string {
+	switch {
+	case strings.Contains(v, KvDoubleQuote):
+		return KvSingleQuote + v + KvSingleQuote
+	case v == "" || HasSpace(v) || strings.Contains(v, KvSingleQuote):
+		return KvDoubleQuote + v + KvDoubleQuote
+	}
+	return v
+}
+
+// configKVString returns kvs as space separated key=value pairs for a
+// config set call. Every key is sent, including empty values, so that
+// previously configured values are reset.
+func configKVString(kvs []ConfigKV) string {
+	var s strings.Builder
+	for _, kv := range kvs {
+		if s.Len() > 0 {
+			s.WriteString(KvSpaceSeparator)
+		}
+		s.WriteString(kv.Key + KvSeparator + quoteConfigValue(kv.Value))
+	}
+	return s.String()
+}
+
 // Constant separators
 const (
 	SubSystemSeparator = `:`
@@ -251,10 +278,11 @@ func parseConfigKey(text string) (key, rem string, err error) {
 }
 
 func parseConfigValue(text string) (v, rem string, err error) {
-	// Value may be double quoted.
-	if strings.HasPrefix(text, KvDoubleQuote) {
-		text = strings.TrimPrefix(text, KvDoubleQuote)
-		ts := strings.SplitN(text, KvDoubleQuote, 2)
+	// Value may be double or single quoted.
+	if strings.HasPrefix(text, KvDoubleQuote) || strings.HasPrefix(text, KvSingleQuote) {
+		quote := text[:1]
+		text = text[1:]
+		ts := strings.SplitN(text, quote, 2)
 		v = ts[0]
 		if len(ts) == 1 {
 			err = ErrInvalidConfigKV
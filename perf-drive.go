@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"time"
 )
 
 // DriveSpeedTestResult - result of the drive speed test
@@ -39,14 +41,78 @@ type DrivePerf struct {
 	ReadThroughput  uint64 `json:"readThroughput"`
 	WriteThroughput uint64 `json:"writeThroughput"`
 
+	ReadLatency  DriveLatency     `json:"readLatency"`
+	WriteLatency DriveLatency     `json:"writeLatency"`
+	BlockPerf    []DriveBlockPerf `json:"blockPerf,omitempty"`
+
 	Error string `json:"error,omitempty"`
 }
 
+// DriveLatency - latency percentiles of the I/O requests issued on a drive
+type DriveLatency struct {
+	P50  time.Duration `json:"p50"`
+	P99  time.Duration `json:"p99"`
+	P999 time.Duration `json:"p999"`
+}
+
+// DriveBlockPerf - IOPS and latencies of a drive at a given block size
+type DriveBlockPerf struct {
+	BlockSize    uint64       `json:"blockSize"`
+	ReadIOPS     uint64       `json:"readIOPS"`
+	WriteIOPS    uint64       `json:"writeIOPS"`
+	ReadLatency  DriveLatency `json:"readLatency"`
+	WriteLatency DriveLatency `json:"writeLatency"`
+}
+
 // DriveSpeedTestOpts provide configurable options for drive speedtest
 type DriveSpeedTestOpts struct {
 	Serial    bool   // Run speed tests one drive at a time
 	BlockSize uint64 // BlockSize for read/write (default 4MiB)
 	FileSize  uint64 // Total fileSize to write and read (default 1GiB)
+
+	IOPSBlockSizes []uint64 // Additional block sizes to measure IOPS at, e.g. 4KiB, 64KiB
+}
+
+// SlowDrive is a drive reported by SlowDrives.
+type SlowDrive struct {
+	Endpoint string `json:"endpoint"`
+	DrivePerf
+}
+
+// SlowDrives returns the drives whose p99 read or write latency is more
+// than factor times the median p99 latency of all the tested drives.
+func SlowDrives(results []DriveSpeedTestResult, factor float64) []SlowDrive {
+	var drives []SlowDrive
+	for _, result := range results {
+		for _, drive := range result.DrivePerf {
+			if drive.Error == "" {
+				drives = append(drives, SlowDrive{Endpoint: result.Endpoint, DrivePerf: drive})
+			}
+		}
+	}
+	if len(drives) == 0 {
+		return nil
+	}
+
+	median := func(latency func(DrivePerf) time.Duration) time.Duration {
+		l := make([]time.Duration, 0, len(drives))
+		for _, drive := range drives {
+			l = append(l, latency(drive.DrivePerf))
+		}
+		sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+		return l[len(l)/2]
+	}
+	readP99 := median(func(d DrivePerf) time.Duration { return d.ReadLatency.P99 })
+	writeP99 := median(func(d DrivePerf) time.Duration { return d.WriteLatency.P99 })
+
+	var slow []SlowDrive
+	for _, drive := range drives {
+		if float64(drive.ReadLatency.P99) > factor*float64(readP99) ||
+			float64(drive.WriteLatency.P99) > factor*float64(writeP99) {
+			slow = append(slow, drive)
+		}
+	}
+	return slow
 }
 
 // DriveSpeedtest - perform drive speedtest on the MinIO servers
@@ -57,10 +123,14 @@ func (adm *AdminClient) DriveSpeedtest(ctx context.Context, opts DriveSpeedTestO
 	}
 	queryVals.Set("blocksize", strconv.FormatUint(opts.BlockSize, 10))
 	queryVals.Set("filesize", strconv.FormatUint(opts.FileSize, 10))
+	for _, bs := range opts.IOPSBlockSizes {
+		queryVals.Add("iopsBlocksize", strconv.FormatUint(bs, 10))
+	}
 	resp, err := adm.executeMethod(ctx,
 		http.MethodPost, requestData{
 			relPath:     adminAPIPrefix + "/speedtest/drive",
 			queryValues: queryVals,
+			category:    RequestCategoryStreaming,
 		})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,63 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlowDrives(t *testing.T) {
+	drive := func(path string, read, write time.Duration) DrivePerf {
+		return DrivePerf{
+			Path:         path,
+			ReadLatency:  DriveLatency{P99: read},
+			WriteLatency: DriveLatency{P99: write},
+		}
+	}
+	results := []DriveSpeedTestResult{
+		{Endpoint: "node1", DrivePerf: []DrivePerf{
+			drive("/d1", 2*time.Millisecond, 3*time.Millisecond),
+			drive("/d2", 2*time.Millisecond, 30*time.Millisecond),
+		}},
+		{Endpoint: "node2", DrivePerf: []DrivePerf{
+			drive("/d1", 3*time.Millisecond, 3*time.Millisecond),
+			drive("/d2", 20*time.Millisecond, 4*time.Millisecond),
+			{Path: "/d3", Error: "drive offline"},
+		}},
+	}
+
+	testCases := []struct {
+		factor float64
+		want   int
+	}{
+		{factor: 2, want: 2},
+		{factor: 7, want: 1},
+		{factor: 20, want: 0},
+	}
+	for i, tc := range testCases {
+		if got := SlowDrives(results, tc.factor); len(got) != tc.want {
+			t.Errorf("Test %d: expected %d slow drives, got %v", i+1, tc.want, got)
+		}
+	}
+	if got := SlowDrives(results, 7); got[0].Endpoint != "node1" || got[0].Path != "/d2" {
+		t.Errorf("expected node1 /d2 to be slow, got %s %s", got[0].Endpoint, got[0].Path)
+	}
+	if got := SlowDrives(nil, 2); got != nil {
+		t.Errorf("expected no slow drives, got %v", got)
+	}
+}
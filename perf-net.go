@@ -18,9 +18,14 @@ package madmin
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -46,13 +51,123 @@ func (adm *AdminClient) Netperf(ctx context.Context, duration time.Duration) (re
 		http.MethodPost, requestData{
 			relPath:     adminAPIPrefix + "/speedtest/net",
 			queryValues: queryVals,
+			category:    RequestCategoryStreaming,
+			minTimeout:  duration,
 		})
 	if err != nil {
 		return result, err
 	}
+	defer closeResponse(resp)
 	if resp.StatusCode != http.StatusOK {
 		return result, httpRespToErrorResponse(resp)
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	return result, err
 }
+
+// clientNetperfBlockSize is the amount of data sent or requested
+// per request by the client to cluster network perf test.
+const clientNetperfBlockSize = 16 << 20
+
+// clientNetperfNodeHeader is the response header carrying the
+// endpoint of the node that served a download request.
+const clientNetperfNodeHeader = "X-Minio-Speedtest-Node"
+
+// ClientNetperf - perform a network perf test between the caller and the
+// cluster. Data is uploaded to and then downloaded from the cluster, each
+// for half the duration, TX and RX are the upload and download throughput
+// in bytes/sec measured by the client for every node that served requests.
+func (adm *AdminClient) ClientNetperf(ctx context.Context, duration time.Duration) (result NetperfResult, err error) {
+	if duration <= 0 {
+		return result, ErrInvalidArgument("duration must be positive")
+	}
+
+	type nodeStats struct {
+		tx, rx         uint64
+		txTime, rxTime time.Duration
+	}
+	stats := make(map[string]*nodeStats)
+	var endpoints []string
+	nodeOf := func(endpoint string) *nodeStats {
+		ns, ok := stats[endpoint]
+		if !ok {
+			ns = &nodeStats{}
+			stats[endpoint] = ns
+			endpoints = append(endpoints, endpoint)
+		}
+		return ns
+	}
+
+	block := make([]byte, clientNetperfBlockSize)
+	// Hash the block once, outside of the timed requests.
+	sum := sha256.Sum256(block)
+	blockSHA256 := hex.EncodeToString(sum[:])
+	deadline := time.Now().Add(duration / 2)
+	for time.Now().Before(deadline) {
+		start := time.Now()
+		resp, err := adm.executeMethod(ctx,
+			http.MethodPost, requestData{
+				relPath:       adminAPIPrefix + "/speedtest/client/devnull",
+				content:       block,
+				contentSHA256: blockSHA256,
+				category:      RequestCategoryTransfer,
+			})
+		if err != nil {
+			return result, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			defer closeResponse(resp)
+			return result, httpRespToErrorResponse(resp)
+		}
+		var node NetperfNodeResult
+		err = json.NewDecoder(resp.Body).Decode(&node)
+		closeResponse(resp)
+		if err != nil {
+			return result, err
+		}
+		ns := nodeOf(node.Endpoint)
+		ns.tx += uint64(len(block))
+		ns.txTime += time.Since(start)
+	}
+
+	queryVals := make(url.Values)
+	queryVals.Set("size", strconv.Itoa(clientNetperfBlockSize))
+	deadline = time.Now().Add(duration / 2)
+	for time.Now().Before(deadline) {
+		start := time.Now()
+		resp, err := adm.executeMethod(ctx,
+			http.MethodGet, requestData{
+				relPath:     adminAPIPrefix + "/speedtest/client/devnull",
+				queryValues: queryVals,
+				category:    RequestCategoryTransfer,
+			})
+		if err != nil {
+			return result, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			defer closeResponse(resp)
+			return result, httpRespToErrorResponse(resp)
+		}
+		n, err := io.Copy(ioutil.Discard, resp.Body)
+		closeResponse(resp)
+		if err != nil {
+			return result, err
+		}
+		ns := nodeOf(resp.Header.Get(clientNetperfNodeHeader))
+		ns.rx += uint64(n)
+		ns.rxTime += time.Since(start)
+	}
+
+	for _, endpoint := range endpoints {
+		ns := stats[endpoint]
+		node := NetperfNodeResult{Endpoint: endpoint}
+		if ns.txTime > 0 {
+			node.TX = uint64(float64(ns.tx) / ns.txTime.Seconds())
+		}
+		if ns.rxTime > 0 {
+			node.RX = uint64(float64(ns.rx) / ns.rxTime.Seconds())
+		}
+		result.NodeResults = append(result.NodeResults, node)
+	}
+	return result, nil
+}
@@ -0,0 +1,66 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClientNetperf(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/speedtest/client/devnull" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if r.Method == http.MethodPost {
+			data, _ := ioutil.ReadAll(r.Body)
+			sum := sha256.Sum256(data)
+			if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+				writeTestError(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch")
+				return
+			}
+			json.NewEncoder(w).Encode(NetperfNodeResult{Endpoint: "node1"})
+			return
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		w.Header().Set(clientNetperfNodeHeader, "node1")
+		w.Write(make([]byte, size))
+	})
+
+	result, err := adm.ClientNetperf(context.Background(), 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.NodeResults) != 1 {
+		t.Fatalf("expected 1 node result, got %d", len(result.NodeResults))
+	}
+	if node := result.NodeResults[0]; node.Endpoint != "node1" || node.TX == 0 || node.RX == 0 {
+		t.Errorf("unexpected node result %+v", node)
+	}
+
+	if _, err = adm.ClientNetperf(context.Background(), 0); err == nil {
+		t.Error("expected error for zero duration")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -52,8 +53,29 @@ type SpeedTestResult struct {
 	Concurrent int    `json:"concurrent"`
 	PUTStats   SpeedTestStats
 	GETStats   SpeedTestStats
+
+	// Set for the sustained and ramp modes, Time is when the sample
+	// was taken and Final marks the last result of the run, which is
+	// the plateau concurrency in ramp mode.
+	Mode  SpeedtestMode `json:"mode,omitempty"`
+	Time  time.Time     `json:"time,omitempty"`
+	Final bool          `json:"final,omitempty"`
 }
 
+// SpeedtestMode selects how the object speedtest is run.
+type SpeedtestMode string
+
+// Supported speedtest modes, the zero value runs a single
+// test or autotunes the concurrency if Autotune is set.
+const (
+	// SpeedtestSustained runs at a fixed concurrency for the full duration,
+	// reporting the throughput every sample interval.
+	SpeedtestSustained SpeedtestMode = "sustained"
+	// SpeedtestRamp steps up the concurrency, running each step for the
+	// duration, until the throughput does not improve anymore.
+	SpeedtestRamp SpeedtestMode = "ramp"
+)
+
 // SpeedtestOpts provide configurable options for speedtest
 type SpeedtestOpts struct {
 	Size         int           // Object size used in speed test
@@ -62,15 +84,76 @@ type SpeedtestOpts struct {
 	Autotune     bool          // Enable autotuning
 	StorageClass string        // Choose type of storage-class to be used while performing I/O
 	Bucket       string        // Choose a custom bucket name while performing I/O
+
+	Mode           SpeedtestMode // Run in sustained or ramp mode, can not be combined with Autotune
+	SampleInterval time.Duration // Interval between sustained mode samples (default 1s)
+	RampStep       int           // Concurrency added on every ramp mode step (default Concurrency)
+	RampPlateau    float64       // Minimum relative throughput improvement of a ramp step to continue (default 0.05)
+
+	SizeDistribution  []SpeedtestObjectSize // Mix of object sizes used instead of Size, percentages must add up to 100
+	UseExistingBucket bool                  // Run against the existing Bucket instead of creating a temporary one
+}
+
+// SpeedtestObjectSize - percentage of the speedtest objects of a given size
+type SpeedtestObjectSize struct {
+	Size    int `json:"size"`
+	Percent int `json:"percent"`
+}
+
+func validateSizeDistribution(dist []SpeedtestObjectSize) error {
+	total := 0
+	for _, s := range dist {
+		if s.Size <= 0 {
+			return errors.New("size distribution sizes must be greater than 0 bytes")
+		}
+		if s.Percent <= 0 {
+			return errors.New("size distribution percentages must be greater than 0")
+		}
+		total += s.Percent
+	}
+	if total != 100 {
+		return errors.New("size distribution percentages must add up to 100, got " + strconv.Itoa(total))
+	}
+	return nil
+}
+
+// encodeSizeDistribution encodes the distribution as size:percent pairs,
+// e.g. "4096:50,1048576:50".
+func encodeSizeDistribution(dist []SpeedtestObjectSize) string {
+	pairs := make([]string, 0, len(dist))
+	for _, s := range dist {
+		pairs = append(pairs, strconv.Itoa(s.Size)+":"+strconv.Itoa(s.Percent))
+	}
+	return strings.Join(pairs, ",")
 }
 
 // Speedtest - perform speedtest on the MinIO servers
 func (adm *AdminClient) Speedtest(ctx context.Context, opts SpeedtestOpts) (chan SpeedTestResult, error) {
+	switch opts.Mode {
+	case "":
+	case SpeedtestSustained, SpeedtestRamp:
+		if opts.Autotune {
+			return nil, errors.New("autotune can not be combined with " + string(opts.Mode) + " mode")
+		}
+		if opts.SampleInterval < 0 || opts.RampStep < 0 || opts.RampPlateau < 0 {
+			return nil, errors.New("sample interval, ramp step and ramp plateau must not be negative")
+		}
+	default:
+		return nil, errors.New("unsupported speedtest mode " + string(opts.Mode))
+	}
+	if len(opts.SizeDistribution) > 0 {
+		if err := validateSizeDistribution(opts.SizeDistribution); err != nil {
+			return nil, err
+		}
+	}
+	if opts.UseExistingBucket && opts.Bucket == "" {
+		return nil, errors.New("bucket must be specified to use an existing bucket")
+	}
 	if !opts.Autotune {
 		if opts.Duration <= time.Second {
 			return nil, errors.New("duration must be greater a second")
 		}
-		if opts.Size <= 0 {
+		if opts.Size <= 0 && len(opts.SizeDistribution) == 0 {
 			return nil, errors.New("size must be greater than 0 bytes")
 		}
 		if opts.Concurrency <= 0 {
@@ -88,16 +171,37 @@ func (adm *AdminClient) Speedtest(ctx context.Context, opts SpeedtestOpts) (chan
 	if opts.Concurrency > 0 {
 		queryVals.Set("concurrent", strconv.Itoa(opts.Concurrency))
 	}
+	if len(opts.SizeDistribution) > 0 {
+		queryVals.Set("sizes", encodeSizeDistribution(opts.SizeDistribution))
+	}
 	if opts.Bucket != "" {
 		queryVals.Set("bucket", opts.Bucket)
 	}
+	if opts.UseExistingBucket {
+		queryVals.Set("existingBucket", "true")
+	}
 	if opts.Autotune {
 		queryVals.Set("autotune", "true")
 	}
+	if opts.Mode != "" {
+		queryVals.Set("mode", string(opts.Mode))
+	}
+	if opts.Mode == SpeedtestSustained && opts.SampleInterval > 0 {
+		queryVals.Set("interval", opts.SampleInterval.String())
+	}
+	if opts.Mode == SpeedtestRamp {
+		if opts.RampStep > 0 {
+			queryVals.Set("rampStep", strconv.Itoa(opts.RampStep))
+		}
+		if opts.RampPlateau > 0 {
+			queryVals.Set("rampPlateau", strconv.FormatFloat(opts.RampPlateau, 'f', -1, 64))
+		}
+	}
 	resp, err := adm.executeMethod(ctx,
 		http.MethodPost, requestData{
 			relPath:     adminAPIPrefix + "/speedtest",
 			queryValues: queryVals,
+			category:    RequestCategoryStreaming,
 		})
 	if err != nil {
 		return nil, err
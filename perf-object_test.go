@@ -0,0 +1,160 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestValidateSizeDistribution(t *testing.T) {
+	testCases := []struct {
+		dist    []SpeedtestObjectSize
+		encoded string
+		wantErr bool
+	}{
+		{
+			dist:    []SpeedtestObjectSize{{Size: 4 << 10, Percent: 50}, {Size: 1 << 20, Percent: 40}, {Size: 64 << 20, Percent: 10}},
+			encoded: "4096:50,1048576:40,67108864:10",
+		},
+		{
+			dist:    []SpeedtestObjectSize{{Size: 4 << 10, Percent: 100}},
+			encoded: "4096:100",
+		},
+		{
+			dist:    []SpeedtestObjectSize{{Size: 4 << 10, Percent: 50}, {Size: 1 << 20, Percent: 40}},
+			wantErr: true,
+		},
+		{
+			dist:    []SpeedtestObjectSize{{Size: 0, Percent: 100}},
+			wantErr: true,
+		},
+		{
+			dist:    []SpeedtestObjectSize{{Size: 4 << 10, Percent: 110}, {Size: 1 << 20, Percent: -10}},
+			wantErr: true,
+		},
+	}
+	for i, tc := range testCases {
+		err := validateSizeDistribution(tc.dist)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.wantErr, err)
+		}
+		if err == nil {
+			if got := encodeSizeDistribution(tc.dist); got != tc.encoded {
+				t.Errorf("Test %d: expected %q, got %q", i+1, tc.encoded, got)
+			}
+		}
+	}
+}
+
+func TestSpeedtestSustained(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/speedtest" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("mode") != "sustained" || q.Get("interval") != "2s" || q.Get("duration") != "6s" || q.Get("autotune") != "" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		enc := json.NewEncoder(w)
+		for i := 1; i <= 3; i++ {
+			enc.Encode(SpeedTestResult{
+				Mode:       SpeedtestSustained,
+				Concurrent: 32,
+				Time:       start.Add(time.Duration(i) * 2 * time.Second),
+				PUTStats:   SpeedTestStats{ThroughputPerSec: uint64(i) << 20},
+				Final:      i == 3,
+			})
+		}
+	})
+
+	ctx := context.Background()
+	resultCh, err := adm.Speedtest(ctx, SpeedtestOpts{
+		Size:           1 << 20,
+		Concurrency:    32,
+		Duration:       6 * time.Second,
+		Mode:           SpeedtestSustained,
+		SampleInterval: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []SpeedTestResult
+	for result := range resultCh {
+		results = append(results, result)
+	}
+	if len(results) != 3 || !results[2].Final || results[0].Final {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	for i, result := range results {
+		if result.Mode != SpeedtestSustained || !result.Time.Equal(start.Add(time.Duration(i+1)*2*time.Second)) ||
+			result.PUTStats.ThroughputPerSec != uint64(i+1)<<20 {
+			t.Errorf("unexpected sample %d: %+v", i+1, result)
+		}
+	}
+}
+
+func TestSpeedtestRamp(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("mode") != "ramp" || q.Get("rampStep") != "16" || q.Get("rampPlateau") != "0.1" || q.Get("interval") != "" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(SpeedTestResult{Mode: SpeedtestRamp, Concurrent: 16})
+		enc.Encode(SpeedTestResult{Mode: SpeedtestRamp, Concurrent: 32, Final: true})
+	})
+
+	ctx := context.Background()
+	opts := SpeedtestOpts{
+		Size:           1 << 20,
+		Concurrency:    16,
+		Duration:       10 * time.Second,
+		Mode:           SpeedtestRamp,
+		SampleInterval: time.Second, // Ignored in ramp mode
+		RampStep:       16,
+		RampPlateau:    0.1,
+	}
+	resultCh, err := adm.Speedtest(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plateau SpeedTestResult
+	for result := range resultCh {
+		plateau = result
+	}
+	if !plateau.Final || plateau.Concurrent != 32 {
+		t.Fatalf("unexpected plateau %+v", plateau)
+	}
+
+	opts.Autotune = true
+	if _, err = adm.Speedtest(ctx, opts); err == nil {
+		t.Fatal("expected an error combining autotune and ramp mode")
+	}
+	opts.Autotune = false
+	opts.Mode = "burst"
+	if _, err = adm.Speedtest(ctx, opts); err == nil {
+		t.Fatal("expected an error for an unsupported mode")
+	}
+}
@@ -196,6 +196,21 @@ func (adm *AdminClient) AddCannedPolicy(ctx context.Context, policyName string,
 
 // SetPolicy - sets the policy for a user or a group.
 func (adm *AdminClient) SetPolicy(ctx context.Context, policyName, entityName string, isGroup bool) error {
+	_, err := adm.setPolicy(ctx, policyName, entityName, isGroup, adm.autoIdempotencyKey())
+	return err
+}
+
+// SetPolicyWithIdempotencyKey - sets the policy for a user or a group, the
+// request is applied only once for a given idempotency key so it can be
+// safely retried. replayed is true if an earlier request set the policy.
+func (adm *AdminClient) SetPolicyWithIdempotencyKey(ctx context.Context, idempotencyKey, policyName, entityName string, isGroup bool) (replayed bool, err error) {
+	if idempotencyKey == "" {
+		return false, ErrInvalidArgument("idempotency key cannot be empty")
+	}
+	return adm.setPolicy(ctx, policyName, entityName, isGroup, idempotencyKey)
+}
+
+func (adm *AdminClient) setPolicy(ctx context.Context, policyName, entityName string, isGroup bool, idempotencyKey string) (replayed bool, err error) {
 	queryValues := url.Values{}
 	queryValues.Set("policyName", policyName)
 	queryValues.Set("userOrGroup", entityName)
@@ -206,13 +221,295 @@ func (adm *AdminClient) SetPolicy(ctx context.Context, policyName, entityName st
 	queryValues.Set("isGroup", groupStr)
 
 	reqData := requestData{
-		relPath:     adminAPIPrefix + "/set-user-or-group-policy",
-		queryValues: queryValues,
+		relPath:        adminAPIPrefix + "/set-user-or-group-policy",
+		queryValues:    queryValues,
+		idempotencyKey: idempotencyKey,
 	}
 
 	// Execute PUT on /minio/admin/v3/set-user-or-group-policy to set policy.
 	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
 	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, httpRespToErrorResponse(resp)
+	}
+	return isIdempotencyReplay(resp), nil
+}
+
+// PolicyAssociation - attaches or detaches policies to
+// exactly one of a user or a group.
+type PolicyAssociation struct {
+	Policies []string `json:"policies"`
+	User     string   `json:"user,omitempty"`
+	Group    string   `json:"group,omitempty"`
+	// Detach the policies instead of attaching them.
+	Detach bool `json:"detach,omitempty"`
+}
+
+func (p PolicyAssociation) validate() error {
+	if len(p.Policies) == 0 {
+		return ErrInvalidArgument("no policies specified")
+	}
+	if (p.User == "") == (p.Group == "") {
+		return ErrInvalidArgument("exactly one of user or group must be specified")
+	}
+	return nil
+}
+
+// PolicyAssociationResult - result of a single PolicyAssociation
+// applied by AttachPolicyBulk.
+type PolicyAssociationResult struct {
+	PolicyAssociation
+	// Policies effectively mapped to the user or group afterwards.
+	PoliciesMapped []string `json:"policiesMapped,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// AttachPolicyBulk - attaches or detaches policies to multiple users and
+// groups in a single request. The associations are applied atomically,
+// if any of them fails none are applied and the per item results report
+// which associations failed.
+func (adm *AdminClient) AttachPolicyBulk(ctx context.Context, associations []PolicyAssociation) ([]PolicyAssociationResult, error) {
+	if len(associations) == 0 {
+		return nil, ErrInvalidArgument("policy associations cannot be empty")
+	}
+	for _, a := range associations {
+		if err := a.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(associations)
+	if err != nil {
+		return nil, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/set-policy-bulk",
+		content: data,
+	}
+
+	// Execute POST on /minio/admin/v3/set-policy-bulk to attach/detach policies.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var results []PolicyAssociationResult
+	if err = json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// PolicyValidationError - a single problem found in a policy document.
+type PolicyValidationError struct {
+	Statement int    `json:"statement"` // Index of the offending statement, -1 if not statement specific.
+	Message   string `json:"message"`
+}
+
+// PolicyValidationResult - result of validating a policy document.
+type PolicyValidationResult struct {
+	Valid  bool                    `json:"valid"`
+	Errors []PolicyValidationError `json:"errors,omitempty"`
+}
+
+// ValidatePolicy - validates the policy document on the server without
+// adding it, reporting all problems found in the document.
+func (adm *AdminClient) ValidatePolicy(ctx context.Context, policy []byte) (PolicyValidationResult, error) {
+	if len(policy) == 0 {
+		return PolicyValidationResult{}, ErrInvalidArgument("policy input cannot be empty")
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/validate-policy",
+		content: policy,
+	}
+
+	// Execute POST on /minio/admin/v3/validate-policy to validate policy.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return PolicyValidationResult{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return PolicyValidationResult{}, httpRespToErrorResponse(resp)
+	}
+
+	var result PolicyValidationResult
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return PolicyValidationResult{}, err
+	}
+	return result, nil
+}
+
+// PolicyEvaluationResult - result of evaluating whether
+// a principal is allowed to perform an action on a resource.
+type PolicyEvaluationResult struct {
+	Allowed bool `json:"allowed"`
+	// Policies which were considered in the evaluation.
+	Policies []string `json:"policies,omitempty"`
+	// Policy with the statement deciding the result, empty
+	// when the action was denied because nothing allowed it.
+	MatchedPolicy string `json:"matchedPolicy,omitempty"`
+}
+
+// EvaluatePolicy - evaluates on the server whether principal, a user,
+// service account or group, is allowed to perform action on resource,
+// e.g. "s3:GetObject" on "arn:aws:s3:::bucket/object", without
+// performing the actual request.
+func (adm *AdminClient) EvaluatePolicy(ctx context.Context, principal, action, resource string) (PolicyEvaluationResult, error) {
+	if principal == "" || action == "" {
+		return PolicyEvaluationResult{}, ErrInvalidArgument("principal and action cannot be empty")
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("principal", principal)
+	queryValues.Set("action", action)
+	queryValues.Set("resource", resource)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/evaluate-policy",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/evaluate-policy to evaluate policy.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return PolicyEvaluationResult{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return PolicyEvaluationResult{}, httpRespToErrorResponse(resp)
+	}
+
+	var result PolicyEvaluationResult
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return PolicyEvaluationResult{}, err
+	}
+	return result, nil
+}
+
+// Sources of a policy mapped to a user
+const (
+	PolicySourceUser  = "user"  // Directly attached to the user
+	PolicySourceGroup = "group" // Attached to a group the user is a member of
+	PolicySourceLDAP  = "ldap"  // Mapped to the LDAP DN or one of its groups
+	PolicySourceClaim = "claim" // Mapped from an OpenID claim or role
+)
+
+// EffectivePolicySource - a policy applying to a user and why.
+type EffectivePolicySource struct {
+	Policy string `json:"policy"`
+	Source string `json:"source"`
+	// Group, LDAP DN or claim value the policy is mapped to,
+	// empty for policies attached to the user directly.
+	MappedTo string `json:"mappedTo,omitempty"`
+}
+
+// EffectivePolicies - the policies a user is subject to, resolved
+// through its group memberships and identity provider mappings.
+type EffectivePolicies struct {
+	User    string                  `json:"user"`
+	Groups  []string                `json:"groups,omitempty"`
+	Sources []EffectivePolicySource `json:"sources"`
+	// Policy is the combined policy document the server enforces.
+	Policy json.RawMessage `json:"policy,omitempty"`
+}
+
+// PolicyNames - returns the distinct names of the policies
+// applying to the user, in the order they were resolved.
+func (e EffectivePolicies) PolicyNames() []string {
+	var names []string
+	seen := make(map[string]bool, len(e.Sources))
+	for _, s := range e.Sources {
+		if !seen[s.Policy] {
+			seen[s.Policy] = true
+			names = append(names, s.Policy)
+		}
+	}
+	return names
+}
+
+// GetEffectivePolicies - resolves the policies of user from its
+// directly attached policies, group memberships and LDAP or OpenID
+// mappings into the combined policy document the server enforces.
+func (adm *AdminClient) GetEffectivePolicies(ctx context.Context, user string) (EffectivePolicies, error) {
+	if user == "" {
+		return EffectivePolicies{}, ErrInvalidArgument("user cannot be empty")
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("user", user)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/effective-policies",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/effective-policies to resolve policies.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return EffectivePolicies{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return EffectivePolicies{}, httpRespToErrorResponse(resp)
+	}
+
+	var result EffectivePolicies
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return EffectivePolicies{}, err
+	}
+	return result, nil
+}
+
+// TemporaryPolicyGrant - policies attached to a user or service
+// account until ExpiresAt, when the server detaches them again.
+type TemporaryPolicyGrant struct {
+	Policies  []string  `json:"policies"`
+	User      string    `json:"user"` // User or service account access key
+	ExpiresAt time.Time `json:"expiresAt"`
+	Reason    string    `json:"reason,omitempty"`
+	// GrantedBy and GrantedAt are set by the server.
+	GrantedBy string    `json:"grantedBy,omitempty"`
+	GrantedAt time.Time `json:"grantedAt,omitempty"`
+}
+
+// AttachPolicyTemporary - attaches the policies of grant to its user
+// until grant.ExpiresAt, e.g. for break-glass access.
+func (adm *AdminClient) AttachPolicyTemporary(ctx context.Context, grant TemporaryPolicyGrant) error {
+	if len(grant.Policies) == 0 || grant.User == "" {
+		return ErrInvalidArgument("policies and user cannot be empty")
+	}
+	if !grant.ExpiresAt.After(time.Now()) {
+		return ErrInvalidArgument("grant expiry must be in the future")
+	}
+
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/temporary-policy/attach",
+		content: data,
+	}
+
+	// Execute POST on /minio/admin/v3/temporary-policy/attach to grant policies.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
 	if err != nil {
 		return err
 	}
@@ -222,3 +519,64 @@ func (adm *AdminClient) SetPolicy(ctx context.Context, policyName, entityName st
 	}
 	return nil
 }
+
+// RevokeTemporaryPolicyGrant - detaches temporarily granted
+// policies from user before the grant expires.
+func (adm *AdminClient) RevokeTemporaryPolicyGrant(ctx context.Context, user string, policies ...string) error {
+	if len(policies) == 0 || user == "" {
+		return ErrInvalidArgument("policies and user cannot be empty")
+	}
+
+	data, err := json.Marshal(TemporaryPolicyGrant{Policies: policies, User: user})
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/temporary-policy/revoke",
+		content: data,
+	}
+
+	// Execute POST on /minio/admin/v3/temporary-policy/revoke to revoke policies.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// ListTemporaryPolicyGrants - lists the active temporary policy
+// grants of user, or of all users if user is empty.
+func (adm *AdminClient) ListTemporaryPolicyGrants(ctx context.Context, user string) ([]TemporaryPolicyGrant, error) {
+	queryValues := url.Values{}
+	if user != "" {
+		queryValues.Set("user", user)
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/temporary-policy/list",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/temporary-policy/list to list grants.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var grants []TemporaryPolicyGrant
+	if err = json.NewDecoder(resp.Body).Decode(&grants); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
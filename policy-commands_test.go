@@ -18,7 +18,10 @@ package madmin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -64,3 +67,273 @@ func TestPolicyInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyAssociationValidate(t *testing.T) {
+	testCases := []struct {
+		pa      PolicyAssociation
+		success bool
+	}{
+		{PolicyAssociation{Policies: []string{"readwrite"}, User: "alice"}, true},
+		{PolicyAssociation{Policies: []string{"readonly"}, Group: "devs", Detach: true}, true},
+		{PolicyAssociation{User: "alice"}, false},
+		{PolicyAssociation{Policies: []string{"readwrite"}}, false},
+		{PolicyAssociation{Policies: []string{"readwrite"}, User: "alice", Group: "devs"}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.pa.validate()
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: expected failure, got success", i+1)
+		}
+	}
+}
+
+func TestAttachPolicyBulk(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/set-policy-bulk" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		var associations []PolicyAssociation
+		if err := json.NewDecoder(r.Body).Decode(&associations); err != nil || len(associations) != 2 {
+			writeTestError(w, http.StatusBadRequest, "XMinioMalformedJSON")
+			return
+		}
+		if associations[1].Group == "ops" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchGroup")
+			return
+		}
+		results := make([]PolicyAssociationResult, len(associations))
+		for i, a := range associations {
+			results[i] = PolicyAssociationResult{PolicyAssociation: a}
+			if !a.Detach {
+				results[i].PoliciesMapped = a.Policies
+			}
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	ctx := context.Background()
+	results, err := adm.AttachPolicyBulk(ctx, []PolicyAssociation{
+		{Policies: []string{"readwrite"}, User: "alice"},
+		{Policies: []string{"readonly"}, Group: "devs", Detach: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].User != "alice" || len(results[0].PoliciesMapped) != 1 ||
+		results[1].Group != "devs" || !results[1].Detach || results[1].PoliciesMapped != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+
+	_, err = adm.AttachPolicyBulk(ctx, []PolicyAssociation{
+		{Policies: []string{"readwrite"}, User: "alice"},
+		{Policies: []string{"diagnostics"}, Group: "ops"},
+	})
+	if ToErrorResponse(err).Code != "XMinioAdminNoSuchGroup" {
+		t.Errorf("expected XMinioAdminNoSuchGroup, got %v", err)
+	}
+	if _, err = adm.AttachPolicyBulk(ctx, []PolicyAssociation{{User: "alice"}}); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	policy := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObjekt"]}]}`)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/validate-policy" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !bytes.Equal(body, policy) {
+			writeTestError(w, http.StatusBadRequest, "XMinioMalformedJSON")
+			return
+		}
+		json.NewEncoder(w).Encode(PolicyValidationResult{
+			Errors: []PolicyValidationError{{Statement: 0, Message: "unknown action s3:GetObjekt"}},
+		})
+	})
+
+	ctx := context.Background()
+	result, err := adm.ValidatePolicy(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0].Message != "unknown action s3:GetObjekt" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if _, err = adm.ValidatePolicy(ctx, []byte("{}")); ToErrorResponse(err).Code != "XMinioMalformedJSON" {
+		t.Errorf("expected XMinioMalformedJSON, got %v", err)
+	}
+	if _, err = adm.ValidatePolicy(ctx, nil); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/evaluate-policy" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("principal") != "alice" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchUser")
+			return
+		}
+		if q.Get("action") != "s3:GetObject" || q.Get("resource") != "arn:aws:s3:::photos/cat.jpg" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(PolicyEvaluationResult{
+			Allowed:       true,
+			Policies:      []string{"readonly", "diagnostics"},
+			MatchedPolicy: "readonly",
+		})
+	})
+
+	ctx := context.Background()
+	result, err := adm.EvaluatePolicy(ctx, "alice", "s3:GetObject", "arn:aws:s3:::photos/cat.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Allowed || result.MatchedPolicy != "readonly" || len(result.Policies) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if _, err = adm.EvaluatePolicy(ctx, "bob", "s3:GetObject", ""); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+	if _, err = adm.EvaluatePolicy(ctx, "alice", "", ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestEffectivePolicyNames(t *testing.T) {
+	e := EffectivePolicies{Sources: []EffectivePolicySource{
+		{Policy: "readonly", Source: PolicySourceUser},
+		{Policy: "diagnostics", Source: PolicySourceGroup, MappedTo: "ops"},
+		{Policy: "readonly", Source: PolicySourceClaim, MappedTo: "viewer"},
+	}}
+	names := e.PolicyNames()
+	if len(names) != 2 || names[0] != "readonly" || names[1] != "diagnostics" {
+		t.Fatalf("unexpected policy names %v", names)
+	}
+}
+
+func TestGetEffectivePolicies(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/effective-policies" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if r.URL.Query().Get("user") != "alice" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchUser")
+			return
+		}
+		json.NewEncoder(w).Encode(EffectivePolicies{
+			User:   "alice",
+			Groups: []string{"ops"},
+			Sources: []EffectivePolicySource{
+				{Policy: "readonly", Source: PolicySourceUser},
+				{Policy: "diagnostics", Source: PolicySourceGroup, MappedTo: "ops"},
+			},
+			Policy: json.RawMessage(`{"Version":"2012-10-17","Statement":[]}`),
+		})
+	})
+
+	ctx := context.Background()
+	result, err := adm.GetEffectivePolicies(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.User != "alice" || len(result.Groups) != 1 || result.Sources[1].MappedTo != "ops" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if names := result.PolicyNames(); len(names) != 2 || names[1] != "diagnostics" {
+		t.Fatalf("unexpected policy names %v", names)
+	}
+	if string(result.Policy) != `{"Version":"2012-10-17","Statement":[]}` {
+		t.Fatalf("unexpected policy %s", result.Policy)
+	}
+
+	if _, err = adm.GetEffectivePolicies(ctx, "bob"); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+	if _, err = adm.GetEffectivePolicies(ctx, ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestTemporaryPolicyGrants(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	var attached, revoked TemporaryPolicyGrant
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/temporary-policy/attach":
+			if r.Method != http.MethodPost {
+				writeTestError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&attached)
+		case "/minio/admin/v3/temporary-policy/revoke":
+			json.NewDecoder(r.Body).Decode(&revoked)
+			if revoked.User != "alice" {
+				writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchUser")
+			}
+		case "/minio/admin/v3/temporary-policy/list":
+			if r.URL.Query().Get("user") != "alice" {
+				writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchUser")
+				return
+			}
+			json.NewEncoder(w).Encode([]TemporaryPolicyGrant{{
+				Policies:  []string{"consoleAdmin"},
+				User:      "alice",
+				ExpiresAt: expiry,
+				GrantedBy: "minio",
+			}})
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	grant := TemporaryPolicyGrant{Policies: []string{"consoleAdmin"}, User: "alice", ExpiresAt: expiry, Reason: "incident"}
+	if err := adm.AttachPolicyTemporary(ctx, grant); err != nil {
+		t.Fatal(err)
+	}
+	if attached.User != "alice" || attached.Reason != "incident" || !attached.ExpiresAt.Equal(expiry) {
+		t.Fatalf("unexpected attach request %+v", attached)
+	}
+
+	grant.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := adm.AttachPolicyTemporary(ctx, grant); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if err := adm.RevokeTemporaryPolicyGrant(ctx, "alice", "consoleAdmin"); err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked.Policies) != 1 || revoked.Policies[0] != "consoleAdmin" {
+		t.Fatalf("unexpected revoke request %+v", revoked)
+	}
+	if err := adm.RevokeTemporaryPolicyGrant(ctx, "bob", "consoleAdmin"); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+
+	grants, err := adm.ListTemporaryPolicyGrants(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 1 || grants[0].GrantedBy != "minio" || !grants[0].ExpiresAt.Equal(expiry) {
+		t.Fatalf("unexpected grants %+v", grants)
+	}
+	if _, err = adm.ListTemporaryPolicyGrants(ctx, ""); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+}
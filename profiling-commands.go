@@ -25,6 +25,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -94,7 +96,8 @@ func (adm *AdminClient) DownloadProfilingData(ctx context.Context) (io.ReadClose
 	path := fmt.Sprintf(adminAPIPrefix + "/profiling/download")
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet, requestData{
-			relPath: path,
+			relPath:  path,
+			category: RequestCategoryTransfer,
 		},
 	)
 	if err != nil {
@@ -123,6 +126,7 @@ func (adm *AdminClient) Profile(ctx context.Context, profiler ProfilerType, dura
 		http.MethodPost, requestData{
 			relPath:     adminAPIPrefix + "/profile",
 			queryValues: v,
+			category:    RequestCategoryTransfer,
 		},
 	)
 	if err != nil {
@@ -139,3 +143,165 @@ func (adm *AdminClient) Profile(ctx context.Context, profiler ProfilerType, dura
 	}
 	return resp.Body, nil
 }
+
+// ContinuousProfilingStatus holds the continuous profiling
+// schedule configured on a given node.
+type ContinuousProfilingStatus struct {
+	NodeName string         `json:"nodeName"`
+	Types    []ProfilerType `json:"types,omitempty"`
+	Interval time.Duration  `json:"interval,omitempty"`
+	Retain   int            `json:"retain,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// StartContinuousProfiling makes an admin call to make all servers capture
+// the given profiles every interval, keeping the last retain captures of
+// each type. Captures can be listed using ListProfileCaptures and downloaded
+// using DownloadProfileCapture, so intermittent problems can be looked into
+// after the fact.
+func (adm *AdminClient) StartContinuousProfiling(ctx context.Context, types []ProfilerType, interval time.Duration, retain int) ([]ContinuousProfilingStatus, error) {
+	if len(types) == 0 {
+		return nil, ErrInvalidArgument("no profiler types specified")
+	}
+	if interval <= 0 || retain <= 0 {
+		return nil, ErrInvalidArgument("interval and retain must be positive")
+	}
+
+	profilers := make([]string, 0, len(types))
+	for _, t := range types {
+		profilers = append(profilers, string(t))
+	}
+	v := url.Values{}
+	v.Set("profilerType", strings.Join(profilers, ","))
+	v.Set("interval", interval.String())
+	v.Set("retain", strconv.Itoa(retain))
+	return adm.continuousProfiling(ctx, "start", v)
+}
+
+// StopContinuousProfiling makes an admin call to stop capturing profiles
+// on all servers, existing captures are kept until they are downloaded
+// or replaced.
+func (adm *AdminClient) StopContinuousProfiling(ctx context.Context) ([]ContinuousProfilingStatus, error) {
+	return adm.continuousProfiling(ctx, "stop", nil)
+}
+
+func (adm *AdminClient) continuousProfiling(ctx context.Context, action string, v url.Values) ([]ContinuousProfilingStatus, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/profile/continuous/" + action,
+			queryValues: v,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var results []ContinuousProfilingStatus
+	if err = json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ProfileCapture describes a single archived profile
+// captured by continuous profiling.
+type ProfileCapture struct {
+	ID         string       `json:"id"`
+	NodeName   string       `json:"nodeName"`
+	Type       ProfilerType `json:"type"`
+	CapturedAt time.Time    `json:"capturedAt"`
+	Size       int64        `json:"size"`
+}
+
+// ListProfileCaptures makes an admin call to list the profiles
+// archived by continuous profiling on all servers.
+func (adm *AdminClient) ListProfileCaptures(ctx context.Context) ([]ProfileCapture, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet, requestData{
+			relPath: adminAPIPrefix + "/profile/continuous/list",
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var captures []ProfileCapture
+	if err = json.NewDecoder(resp.Body).Decode(&captures); err != nil {
+		return nil, err
+	}
+	return captures, nil
+}
+
+// DownloadProfileCapture makes an admin call to download the archived
+// profile with the given ID, as returned by ListProfileCaptures.
+func (adm *AdminClient) DownloadProfileCapture(ctx context.Context, id string) (io.ReadCloser, error) {
+	v := url.Values{}
+	v.Set("id", id)
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet, requestData{
+			relPath:     adminAPIPrefix + "/profile/continuous/download",
+			queryValues: v,
+			category:    RequestCategoryTransfer,
+		},
+	)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if resp.Body == nil {
+		return nil, errors.New("body is nil")
+	}
+	return resp.Body, nil
+}
+
+// FetchProfile makes an admin call to capture a single profile on the given
+// node, as listed in ServerInfo, for the specified duration. Unlike Profile
+// the raw pprof profile is returned instead of a zip archive of all nodes,
+// so it can be passed directly to `go tool pprof`.
+func (adm *AdminClient) FetchProfile(ctx context.Context, host string, profiler ProfilerType, duration time.Duration) (io.ReadCloser, error) {
+	if host == "" {
+		return nil, ErrInvalidArgument("host cannot be empty")
+	}
+
+	v := url.Values{}
+	v.Set("node", host)
+	v.Set("profilerType", string(profiler))
+	v.Set("duration", duration.String())
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/profile/node",
+			queryValues: v,
+			category:    RequestCategoryTransfer,
+		},
+	)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if resp.Body == nil {
+		return nil, errors.New("body is nil")
+	}
+	return resp.Body, nil
+}
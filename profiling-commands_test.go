@@ -0,0 +1,150 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContinuousProfiling(t *testing.T) {
+	captures := []ProfileCapture{
+		{ID: "node1-cpu-1", NodeName: "node1:9000", Type: ProfilerCPU, CapturedAt: time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), Size: 1024},
+	}
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/minio/admin/v3/profile/continuous/start":
+			if r.Method != http.MethodPost || q.Get("profilerType") != "cpu,mem" || q.Get("interval") != "5m0s" || q.Get("retain") != "3" {
+				writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+				return
+			}
+			json.NewEncoder(w).Encode([]ContinuousProfilingStatus{
+				{NodeName: "node1:9000", Types: []ProfilerType{ProfilerCPU, ProfilerMEM}, Interval: 5 * time.Minute, Retain: 3},
+				{NodeName: "node2:9000", Error: "profiling not supported"},
+			})
+		case "/minio/admin/v3/profile/continuous/stop":
+			json.NewEncoder(w).Encode([]ContinuousProfilingStatus{{NodeName: "node1:9000"}})
+		case "/minio/admin/v3/profile/continuous/list":
+			json.NewEncoder(w).Encode(captures)
+		case "/minio/admin/v3/profile/continuous/download":
+			if q.Get("id") != "node1-cpu-1" {
+				writeTestError(w, http.StatusNotFound, "XMinioAdminProfileCaptureNotFound")
+				return
+			}
+			w.Write([]byte("pprof"))
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+	ctx := context.Background()
+
+	status, err := adm.StartContinuousProfiling(ctx, []ProfilerType{ProfilerCPU, ProfilerMEM}, 5*time.Minute, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status) != 2 || status[0].Retain != 3 || status[0].Interval != 5*time.Minute || status[1].Error == "" {
+		t.Fatalf("unexpected start status %+v", status)
+	}
+	for _, args := range []struct {
+		types    []ProfilerType
+		interval time.Duration
+		retain   int
+	}{
+		{nil, time.Minute, 1},
+		{[]ProfilerType{ProfilerCPU}, 0, 1},
+		{[]ProfilerType{ProfilerCPU}, time.Minute, 0},
+	} {
+		_, err = adm.StartContinuousProfiling(ctx, args.types, args.interval, args.retain)
+		if ToErrorResponse(err).Code != "InvalidArgument" {
+			t.Errorf("%+v: expected InvalidArgument, got %v", args, err)
+		}
+	}
+
+	if status, err = adm.StopContinuousProfiling(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(status) != 1 || status[0].NodeName != "node1:9000" {
+		t.Fatalf("unexpected stop status %+v", status)
+	}
+
+	list, err := adm.ListProfileCaptures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, captures) {
+		t.Fatalf("expected %+v, got %+v", captures, list)
+	}
+
+	rc, err := adm.DownloadProfileCapture(ctx, list[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "pprof" {
+		t.Fatalf("unexpected capture %q: %v", data, err)
+	}
+	_, err = adm.DownloadProfileCapture(ctx, "unknown")
+	if ToErrorResponse(err).Code != "XMinioAdminProfileCaptureNotFound" {
+		t.Fatalf("expected XMinioAdminProfileCaptureNotFound, got %v", err)
+	}
+}
+
+func TestFetchProfile(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/profile/node" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if q.Get("node") != "node1:9000" {
+			writeTestError(w, http.StatusBadRequest, "XMinioAdminNodeNotFound")
+			return
+		}
+		if q.Get("profilerType") != "goroutines" || q.Get("duration") != "10s" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		w.Write([]byte("pprof"))
+	})
+	ctx := context.Background()
+
+	rc, err := adm.FetchProfile(ctx, "node1:9000", ProfilerGoroutines, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "pprof" {
+		t.Fatalf("unexpected profile %q: %v", data, err)
+	}
+
+	_, err = adm.FetchProfile(ctx, "node2:9000", ProfilerGoroutines, 10*time.Second)
+	if ToErrorResponse(err).Code != "XMinioAdminNodeNotFound" {
+		t.Fatalf("expected XMinioAdminNodeNotFound, got %v", err)
+	}
+	_, err = adm.FetchProfile(ctx, "", ProfilerGoroutines, 10*time.Second)
+	if ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build linux
+// +build linux
+
+//
+// MinIO Object Storage (c) 2021-2022 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"github.com/prometheus/procfs"
+)
+
+func toPressureStall(l *procfs.PSILine) *PressureStall {
+	if l == nil {
+		return nil
+	}
+	return &PressureStall{
+		Avg10:  l.Avg10,
+		Avg60:  l.Avg60,
+		Avg300: l.Avg300,
+		Total:  l.Total,
+	}
+}
+
+// getPressure returns the pressure stall information of the
+// cpu, io and memory resources of the system.
+func getPressure() (Pressure, error) {
+	fs, err := procfs.NewDefaultFS()
+	if err != nil {
+		return Pressure{}, err
+	}
+	return getPressureFS(fs)
+}
+
+// getPressureFS returns the pressure stall information of fs.
+func getPressureFS(fs procfs.FS) (Pressure, error) {
+	var p Pressure
+	for resource, dst := range map[string]*ResourcePressure{
+		"cpu":    &p.CPU,
+		"io":     &p.IO,
+		"memory": &p.Memory,
+	} {
+		stats, err := fs.PSIStatsForResource(resource)
+		if err != nil {
+			return Pressure{}, err
+		}
+		dst.Some = toPressureStall(stats.Some)
+		dst.Full = toPressureStall(stats.Full)
+	}
+	return p, nil
+}
@@ -0,0 +1,71 @@
+//go:build linux
+// +build linux
+
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/procfs"
+)
+
+func TestGetPressureFS(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "pressure"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"cpu": "some avg10=1.50 avg60=0.75 avg300=0.25 total=123456\n",
+		"io": "some avg10=0.00 avg60=0.10 avg300=0.20 total=1000\n" +
+			"full avg10=0.00 avg60=0.05 avg300=0.10 total=500\n",
+		"memory": "some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n" +
+			"full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, "pressure", name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs, err := procfs.NewFS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := getPressureFS(fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (PressureStall{Avg10: 1.5, Avg60: 0.75, Avg300: 0.25, Total: 123456}); p.CPU.Some == nil || *p.CPU.Some != expected {
+		t.Errorf("expected cpu some %+v, got %+v", expected, p.CPU.Some)
+	}
+	if p.CPU.Full != nil {
+		t.Errorf("expected no cpu full pressure, got %+v", p.CPU.Full)
+	}
+	if p.IO.Full == nil || p.IO.Full.Total != 500 || p.IO.Some.Avg300 != 0.2 {
+		t.Errorf("unexpected io pressure %+v %+v", p.IO.Some, p.IO.Full)
+	}
+
+	os.Remove(filepath.Join(dir, "pressure", "memory"))
+	if _, err = getPressureFS(fs); err == nil {
+		t.Error("expected error for missing memory pressure")
+	}
+}
@@ -41,11 +41,23 @@ func (t QuotaType) IsValid() bool {
 type BucketQuota struct {
 	Quota uint64    `json:"quota"`
 	Type  QuotaType `json:"quotatype,omitempty"`
+
+	// SoftQuota is the usage in bytes above which the notification
+	// targets in NotifyARNs are notified, writes are not rejected.
+	SoftQuota  uint64   `json:"softQuota,omitempty"`
+	NotifyARNs []string `json:"notifyARNs,omitempty"`
+
+	// Usage is the current usage of the bucket in bytes, it is
+	// only set by GetBucketQuota and ignored by SetBucketQuota.
+	Usage uint64 `json:"usage,omitempty"`
 }
 
 // IsValid returns false if quota is invalid
 // empty quota when Quota == 0 is always true.
 func (q BucketQuota) IsValid() bool {
+	if q.Quota > 0 && q.SoftQuota >= q.Quota {
+		return false
+	}
 	if q.Quota > 0 {
 		return q.Type.IsValid()
 	}
@@ -53,7 +65,18 @@ func (q BucketQuota) IsValid() bool {
 	return true
 }
 
-// GetBucketQuota - get info on a user
+// Exceeded returns true if the usage reached the hard quota.
+func (q BucketQuota) Exceeded() bool {
+	return q.Quota > 0 && q.Usage >= q.Quota
+}
+
+// SoftExceeded returns true if the usage reached the soft quota.
+func (q BucketQuota) SoftExceeded() bool {
+	return q.SoftQuota > 0 && q.Usage >= q.SoftQuota
+}
+
+// GetBucketQuota - returns the quota configuration
+// of a bucket along with its current usage.
 func (adm *AdminClient) GetBucketQuota(ctx context.Context, bucket string) (q BucketQuota, err error) {
 	queryValues := url.Values{}
 	queryValues.Set("bucket", bucket)
@@ -89,7 +112,15 @@ func (adm *AdminClient) GetBucketQuota(ctx context.Context, bucket string) (q Bu
 // SetBucketQuota - sets a bucket's quota, if quota is set to '0'
 // quota is disabled.
 func (adm *AdminClient) SetBucketQuota(ctx context.Context, bucket string, quota *BucketQuota) error {
-	data, err := json.Marshal(quota)
+	if quota == nil {
+		return ErrInvalidArgument("quota must not be nil")
+	}
+	if quota.Quota > 0 && quota.SoftQuota >= quota.Quota {
+		return ErrInvalidArgument("soft quota must be less than the hard quota")
+	}
+	q := *quota
+	q.Usage = 0
+	data, err := json.Marshal(q)
 	if err != nil {
 		return err
 	}
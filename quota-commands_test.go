@@ -0,0 +1,60 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestBucketQuota(t *testing.T) {
+	testCases := []struct {
+		quota        BucketQuota
+		valid        bool
+		exceeded     bool
+		softExceeded bool
+	}{
+		{BucketQuota{}, true, false, false},
+		{BucketQuota{Quota: 100, Type: HardQuota, SoftQuota: 80, Usage: 50}, true, false, false},
+		{BucketQuota{Quota: 100, Type: HardQuota, SoftQuota: 80, Usage: 90}, true, false, true},
+		{BucketQuota{Quota: 100, Type: HardQuota, SoftQuota: 80, Usage: 100}, true, true, true},
+		{BucketQuota{SoftQuota: 80, Usage: 90}, true, false, true},
+		{BucketQuota{Quota: 100, Type: HardQuota, SoftQuota: 100}, false, false, false},
+	}
+	for i, tc := range testCases {
+		if got := tc.quota.IsValid(); got != tc.valid {
+			t.Errorf("Test %d: expected valid %t, got %t", i+1, tc.valid, got)
+		}
+		if got := tc.quota.Exceeded(); got != tc.exceeded {
+			t.Errorf("Test %d: expected exceeded %t, got %t", i+1, tc.exceeded, got)
+		}
+		if got := tc.quota.SoftExceeded(); got != tc.softExceeded {
+			t.Errorf("Test %d: expected soft exceeded %t, got %t", i+1, tc.softExceeded, got)
+		}
+	}
+}
+
+func TestSetBucketQuotaNil(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	err := adm.SetBucketQuota(context.Background(), "bucket", nil)
+	if ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
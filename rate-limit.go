@@ -0,0 +1,102 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// RateLimit is a requests per second limit enforced by the server for an
+// S3 API, a user, or an API of a user. An empty API or User matches all.
+type RateLimit struct {
+	API   string  `json:"api,omitempty"`
+	User  string  `json:"user,omitempty"`
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// IsValid returns true if the rate limit is valid.
+func (l RateLimit) IsValid() bool {
+	return (l.API != "" || l.User != "") && l.RPS > 0 && l.Burst >= 0
+}
+
+// RateLimitStatus is a rate limit along with its rejection counters.
+type RateLimitStatus struct {
+	RateLimit
+	// Rejected is the number of requests rejected since the server started.
+	Rejected uint64 `json:"rejected"`
+	// RejectedLastMinute is the number of requests rejected in the last minute.
+	RejectedLastMinute uint64 `json:"rejectedLastMinute"`
+}
+
+// RateLimits - rate limits configured on the cluster.
+type RateLimits struct {
+	Limits []RateLimitStatus `json:"limits"`
+}
+
+// GetRateLimits - returns the rate limits of the cluster
+// along with the requests each rejected.
+func (adm *AdminClient) GetRateLimits(ctx context.Context) (RateLimits, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: adminAPIPrefix + "/get-rate-limits",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return RateLimits{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RateLimits{}, httpRespToErrorResponse(resp)
+	}
+
+	var limits RateLimits
+	err = json.NewDecoder(resp.Body).Decode(&limits)
+	return limits, err
+}
+
+// SetRateLimits - replaces the rate limits of the cluster,
+// an empty list removes all rate limits.
+func (adm *AdminClient) SetRateLimits(ctx context.Context, limits []RateLimit) error {
+	for _, l := range limits {
+		if !l.IsValid() {
+			return ErrInvalidArgument("rate limit must set an API or a user and a positive rps")
+		}
+	}
+	if limits == nil {
+		limits = []RateLimit{}
+	}
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, requestData{
+		relPath: adminAPIPrefix + "/set-rate-limits",
+		content: data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestRateLimits(t *testing.T) {
+	var body string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/get-rate-limits":
+			json.NewEncoder(w).Encode(RateLimits{Limits: []RateLimitStatus{{
+				RateLimit: RateLimit{API: "PutObject", RPS: 100, Burst: 10},
+				Rejected:  42,
+			}}})
+		case r.Method == http.MethodPut && r.URL.Path == "/minio/admin/v3/set-rate-limits":
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			if body == "[]" {
+				writeTestError(w, http.StatusForbidden, "AccessDenied")
+			}
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	limits, err := adm.GetRateLimits(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limits.Limits) != 1 || limits.Limits[0].API != "PutObject" || limits.Limits[0].RPS != 100 || limits.Limits[0].Rejected != 42 {
+		t.Fatalf("unexpected limits %+v", limits)
+	}
+
+	if err = adm.SetRateLimits(ctx, []RateLimit{{User: "alice", RPS: 5}}); err != nil {
+		t.Fatal(err)
+	}
+	if body != `[{"user":"alice","rps":5}]` {
+		t.Fatalf("unexpected request body %s", body)
+	}
+
+	if err = adm.SetRateLimits(ctx, nil); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+	if err = adm.SetRateLimits(ctx, []RateLimit{{API: "GetObject"}}); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
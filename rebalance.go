@@ -0,0 +1,72 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// RebalancePoolPlan - projected data movement of a pool during a rebalance
+type RebalancePoolPlan struct {
+	ID          int     `json:"id"`
+	CmdLine     string  `json:"cmdline"`
+	TotalSize   uint64  `json:"totalSize"`
+	UsedSize    uint64  `json:"usedSize"`
+	UsedPct     float64 `json:"usedPct"`
+	TargetPct   float64 `json:"targetPct"`
+	Participant bool    `json:"participant"` // true if the pool moves data out
+
+	// BytesToMove is the amount of data moved out of the pool, pools
+	// receiving data report the expected amount in BytesToReceive.
+	BytesToMove       uint64        `json:"bytesToMove"`
+	BytesToReceive    uint64        `json:"bytesToReceive"`
+	ObjectsToMove     uint64        `json:"objectsToMove"`
+	EstimatedDuration time.Duration `json:"estimatedDuration"`
+}
+
+// RebalancePlanResult - projected data movement of a rebalance, estimated
+// at the current throughput of the cluster
+type RebalancePlanResult struct {
+	Pools             []RebalancePoolPlan `json:"pools"`
+	TotalBytesToMove  uint64              `json:"totalBytesToMove"`
+	Throughput        uint64              `json:"throughput"` // bytes/sec used for the estimation
+	EstimatedDuration time.Duration       `json:"estimatedDuration"`
+}
+
+// RebalancePlan - returns the data movement per pool a rebalance would
+// perform, without starting it.
+func (adm *AdminClient) RebalancePlan(ctx context.Context) (RebalancePlanResult, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath: adminAPIPrefix + "/rebalance/plan", // GET <endpoint>/<admin-API>/rebalance/plan
+	})
+	if err != nil {
+		return RebalancePlanResult{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return RebalancePlanResult{}, httpRespToErrorResponse(resp)
+	}
+
+	var plan RebalancePlanResult
+	if err = json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		return RebalancePlanResult{}, err
+	}
+	return plan, nil
+}
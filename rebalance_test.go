@@ -0,0 +1,71 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRebalancePlan(t *testing.T) {
+	expected := RebalancePlanResult{
+		Pools: []RebalancePoolPlan{
+			{
+				ID: 0, CmdLine: "http://server{1...4}/disk{1...4}", TotalSize: 100 << 30, UsedSize: 80 << 30,
+				UsedPct: 80, TargetPct: 50, Participant: true,
+				BytesToMove: 30 << 30, ObjectsToMove: 3000, EstimatedDuration: 5 * time.Minute,
+			},
+			{
+				ID: 1, CmdLine: "http://server{5...8}/disk{1...4}", TotalSize: 100 << 30, UsedSize: 20 << 30,
+				UsedPct: 20, TargetPct: 50, BytesToReceive: 30 << 30,
+			},
+		},
+		TotalBytesToMove:  30 << 30,
+		Throughput:        100 << 20,
+		EstimatedDuration: 5 * time.Minute,
+	}
+	rebalancing := false
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/rebalance/plan" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if rebalancing {
+			writeTestError(w, http.StatusConflict, "XMinioAdminRebalanceAlreadyStarted")
+			return
+		}
+		json.NewEncoder(w).Encode(expected)
+	})
+
+	plan, err := adm.RebalancePlan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, plan)
+	}
+
+	rebalancing = true
+	_, err = adm.RebalancePlan(context.Background())
+	if ToErrorResponse(err).Code != "XMinioAdminRebalanceAlreadyStarted" {
+		t.Fatalf("expected XMinioAdminRebalanceAlreadyStarted, got %v", err)
+	}
+}
@@ -204,17 +204,14 @@ func (adm *AdminClient) SetRemoteTarget(ctx context.Context, bucket string, targ
 	if err != nil {
 		return "", err
 	}
-	encData, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return "", err
-	}
 	queryValues := url.Values{}
 	queryValues.Set("bucket", bucket)
 
 	reqData := requestData{
 		relPath:     adminAPIPrefix + "/set-remote-target",
 		queryValues: queryValues,
-		content:     encData,
+		content:     data,
+		encrypt:     true,
 	}
 
 	// Execute PUT on /minio/admin/v3/set-remote-target to set a target for this bucket of specific arn type.
@@ -296,10 +293,6 @@ func (adm *AdminClient) UpdateRemoteTarget(ctx context.Context, target *BucketTa
 	if err != nil {
 		return "", err
 	}
-	encData, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return "", err
-	}
 	queryValues := url.Values{}
 	queryValues.Set("bucket", target.SourceBucket)
 	queryValues.Set("update", "true")
@@ -324,7 +317,8 @@ func (adm *AdminClient) UpdateRemoteTarget(ctx context.Context, target *BucketTa
 	reqData := requestData{
 		relPath:     adminAPIPrefix + "/set-remote-target",
 		queryValues: queryValues,
-		content:     encData,
+		content:     data,
+		encrypt:     true,
 	}
 
 	// Execute PUT on /minio/admin/v3/set-remote-target to set a target for this bucket of specific arn type.
@@ -19,6 +19,7 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -75,6 +76,7 @@ func (adm *AdminClient) BucketReplicationDiff(ctx context.Context, bucketName st
 		reqData := requestData{
 			relPath:     adminAPIPrefix + "/replication/diff",
 			queryValues: queryValues,
+			category:    RequestCategoryStreaming,
 		}
 
 		// Execute PUT on /minio/admin/v3/diff to set quota for a bucket.
@@ -106,3 +108,198 @@ func (adm *AdminClient) BucketReplicationDiff(ctx context.Context, bucketName st
 	// Returns the diff channel, for caller to start reading from.
 	return diffCh
 }
+
+// ReplTargetDiag holds the diagnostics of a bucket's replication target
+type ReplTargetDiag struct {
+	Arn              string `json:"arn"`
+	Endpoint         string `json:"endpoint"`
+	TargetBucket     string `json:"targetBucket"`
+	Online           bool   `json:"online"`
+	CredentialsValid bool   `json:"credentialsValid"`
+	Error            string `json:"error,omitempty"`
+
+	PendingCount uint64 `json:"pendingCount"`
+	PendingSize  uint64 `json:"pendingSize"`
+	FailedCount  uint64 `json:"failedCount"`
+	FailedSize   uint64 `json:"failedSize"`
+
+	// OldestPending is the modification time of the oldest object
+	// not yet replicated to this target.
+	OldestPending time.Time `json:"oldestPending,omitempty"`
+}
+
+// Healthy returns true if the target is reachable with valid credentials
+// and has no failed replications
+func (t ReplTargetDiag) Healthy() bool {
+	return t.Online && t.CredentialsValid && t.FailedCount == 0 && t.Error == ""
+}
+
+// ReplicationDiagReport aggregates the replication diagnostics of a bucket
+type ReplicationDiagReport struct {
+	Bucket  string           `json:"bucket"`
+	Targets []ReplTargetDiag `json:"targets"`
+	// OldestPending is the oldest OldestPending of all targets.
+	OldestPending time.Time `json:"oldestPending,omitempty"`
+}
+
+// ReplicationDiag - returns the reachability, credentials validity, pending
+// and failed counts of every replication target of the bucket
+func (adm *AdminClient) ReplicationDiag(ctx context.Context, bucketName string) (report ReplicationDiagReport, err error) {
+	if bucketName == "" {
+		return report, ErrInvalidArgument("bucket name cannot be empty")
+	}
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucketName)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replication/diag",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/replication/diag to diagnose bucket replication.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return report, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return report, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return report, err
+	}
+	for _, t := range report.Targets {
+		if !t.OldestPending.IsZero() && (report.OldestPending.IsZero() || t.OldestPending.Before(report.OldestPending)) {
+			report.OldestPending = t.OldestPending
+		}
+	}
+	return report, nil
+}
+
+// ReplicationMRF represents an object version in the replication
+// must-replicate-failures (MRF) backlog
+type ReplicationMRF struct {
+	NodeName   string    `json:"nodeName"`
+	Bucket     string    `json:"bucket"`
+	Object     string    `json:"object"`
+	VersionID  string    `json:"versionId"`
+	Arn        string    `json:"arn,omitempty"`
+	RetryCount int       `json:"retryCount"`
+	LastError  string    `json:"lastError,omitempty"`
+	QueuedAt   time.Time `json:"queuedAt,omitempty"`
+	Err        error     `json:"-"`
+}
+
+// ReplicationMRFList - streams the entries of the replication MRF backlog of the
+// bucket, an entry with Err set is sent if the listing fails
+func (adm *AdminClient) ReplicationMRFList(ctx context.Context, bucketName string) <-chan ReplicationMRF {
+	mrfCh := make(chan ReplicationMRF)
+
+	// start a routine to start reading line by line.
+	go func(mrfCh chan<- ReplicationMRF) {
+		defer close(mrfCh)
+		queryValues := url.Values{}
+		queryValues.Set("bucket", bucketName)
+
+		reqData := requestData{
+			relPath:     adminAPIPrefix + "/replication/mrf",
+			queryValues: queryValues,
+			category:    RequestCategoryStreaming,
+		}
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case mrfCh <- ReplicationMRF{Err: err}:
+			}
+		}
+
+		// Execute GET on /minio/admin/v3/replication/mrf to list the MRF backlog.
+		resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var mrf ReplicationMRF
+			if err = dec.Decode(&mrf); err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case mrfCh <- mrf:
+			}
+		}
+	}(mrfCh)
+	// Returns the MRF channel, for caller to start reading from.
+	return mrfCh
+}
+
+// ReplicationMRFVersion identifies an object version in the MRF backlog.
+type ReplicationMRFVersion struct {
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+}
+
+// ReplicationMRFRequeueResult - result of a ReplicationMRFRequeue call
+type ReplicationMRFRequeueResult struct {
+	Requeued []ReplicationMRFVersion `json:"requeued"`
+	// NotFound lists the object versions not present in the MRF backlog.
+	NotFound []ReplicationMRFVersion `json:"notFound,omitempty"`
+}
+
+// ReplicationMRFRequeue - forces an immediate replication retry of the given
+// failed object versions of the bucket
+func (adm *AdminClient) ReplicationMRFRequeue(ctx context.Context, bucketName string, versions []ReplicationMRFVersion) (res ReplicationMRFRequeueResult, err error) {
+	if bucketName == "" {
+		return res, ErrInvalidArgument("bucket name cannot be empty")
+	}
+	if len(versions) == 0 {
+		return res, ErrInvalidArgument("at least one object version is required")
+	}
+	for _, v := range versions {
+		if v.Object == "" {
+			return res, ErrInvalidArgument("object name cannot be empty")
+		}
+	}
+	content, err := json.Marshal(map[string][]ReplicationMRFVersion{"versions": versions})
+	if err != nil {
+		return res, err
+	}
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucketName)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replication/mrf/requeue",
+		queryValues: queryValues,
+		content:     content,
+	}
+
+	// Execute POST on /minio/admin/v3/replication/mrf/requeue to retry failed versions.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return res, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return res, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	return res, err
+}
@@ -0,0 +1,122 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReplicationMRFList(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/replication/mrf" || r.URL.Query().Get("bucket") != "photos" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(ReplicationMRF{Bucket: "photos", Object: "a.jpg", VersionID: "v1"})
+		w.Write([]byte("{\"bucket\":"))
+	})
+
+	var entries []ReplicationMRF
+	for mrf := range adm.ReplicationMRFList(context.Background(), "photos") {
+		entries = append(entries, mrf)
+	}
+	if len(entries) != 2 || entries[0].Object != "a.jpg" || entries[0].Err != nil {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if entries[1].Err == nil {
+		t.Fatal("expected the truncated entry to be reported")
+	}
+
+	for mrf := range adm.ReplicationMRFList(context.Background(), "other") {
+		if ToErrorResponse(mrf.Err).Code != "InvalidRequest" {
+			t.Fatalf("expected InvalidRequest, got %v", mrf.Err)
+		}
+	}
+}
+
+func TestReplicationMRFRequeue(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Versions []ReplicationMRFVersion `json:"versions"`
+		}
+		if r.URL.Path != "/minio/admin/v3/replication/mrf/requeue" || r.Method != http.MethodPost ||
+			json.NewDecoder(r.Body).Decode(&req) != nil || len(req.Versions) != 2 {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(ReplicationMRFRequeueResult{
+			Requeued: req.Versions[:1],
+			NotFound: req.Versions[1:],
+		})
+	})
+
+	versions := []ReplicationMRFVersion{
+		{Object: "a.jpg", VersionID: "v1"},
+		{Object: "b.jpg", VersionID: "v1"},
+	}
+	res, err := adm.ReplicationMRFRequeue(context.Background(), "photos", versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Requeued) != 1 || res.Requeued[0] != versions[0] || len(res.NotFound) != 1 || res.NotFound[0] != versions[1] {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	if _, err = adm.ReplicationMRFRequeue(context.Background(), "photos", []ReplicationMRFVersion{{VersionID: "v1"}}); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestReplicationDiag(t *testing.T) {
+	oldest := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/replication/diag" || r.URL.Query().Get("bucket") != "photos" {
+			writeTestError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		json.NewEncoder(w).Encode(ReplicationDiagReport{
+			Bucket: "photos",
+			Targets: []ReplTargetDiag{
+				{Arn: "arn1", Online: true, CredentialsValid: true, OldestPending: oldest.Add(time.Hour)},
+				{Arn: "arn2", Online: true, CredentialsValid: true, FailedCount: 2, OldestPending: oldest},
+				{Arn: "arn3", Online: false},
+			},
+		})
+	})
+
+	report, err := adm.ReplicationDiag(context.Background(), "photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Targets) != 3 || !report.OldestPending.Equal(oldest) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if !report.Targets[0].Healthy() || report.Targets[1].Healthy() || report.Targets[2].Healthy() {
+		t.Errorf("unexpected target health %+v", report.Targets)
+	}
+
+	if _, err = adm.ReplicationDiag(context.Background(), "other"); ToErrorResponse(err).Code != "NoSuchBucket" {
+		t.Errorf("expected NoSuchBucket, got %v", err)
+	}
+	if _, err = adm.ReplicationDiag(context.Background(), ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
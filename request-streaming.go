@@ -0,0 +1,60 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// streamingUploadThreshold is the payload size above which seekable
+// payloads are uploaded with a streaming (chunked) signature instead
+// of being buffered in memory to compute their checksum.
+const streamingUploadThreshold = 5 << 20 // 5 MiB
+
+// setContent sets the payload of the request from rd. If stream is
+// set, seekable payloads larger than streamingUploadThreshold, e.g.
+// files, are streamed with bounded memory, others are read into memory.
+// Streaming requires the server to accept aws-chunked payloads.
+func (r *requestData) setContent(rd io.Reader, stream bool) error {
+	if seeker, ok := rd.(io.ReadSeeker); ok && stream {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		if end-offset > streamingUploadThreshold {
+			r.contentStream = seeker
+			r.contentOffset = offset
+			r.contentLength = end - offset
+			return nil
+		}
+	}
+
+	content, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return err
+	}
+	r.content = content
+	return nil
+}
@@ -0,0 +1,86 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestRequestStreamingContent(t *testing.T) {
+	adm, err := NewWithOptions("localhost:9000", &Options{
+		Creds: credentials.NewStaticV4("minio", "minio123", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Small payloads are buffered.
+	var small requestData
+	if err = small.setContent(bytes.NewReader([]byte("small")), true); err != nil {
+		t.Fatal(err)
+	}
+	if small.contentStream != nil || string(small.content) != "small" {
+		t.Fatalf("expected buffered content, got %q", small.content)
+	}
+
+	// Large payloads are buffered unless streaming is requested.
+	payload := bytes.NewReader(make([]byte, streamingUploadThreshold+11))
+	var buffered requestData
+	if err = buffered.setContent(payload, false); err != nil {
+		t.Fatal(err)
+	}
+	if buffered.contentStream != nil || len(buffered.content) != streamingUploadThreshold+11 {
+		t.Fatal("expected buffered content")
+	}
+
+	// Large seekable payloads are streamed from the current offset.
+	payload.Seek(10, 0)
+	var large requestData
+	if err = large.setContent(payload, true); err != nil {
+		t.Fatal(err)
+	}
+	if large.contentStream == nil || large.content != nil {
+		t.Fatal("expected streamed content")
+	}
+	if large.contentOffset != 10 || large.contentLength != streamingUploadThreshold+1 {
+		t.Fatalf("unexpected offset %d and length %d", large.contentOffset, large.contentLength)
+	}
+
+	// Retries must upload the whole payload again.
+	for i := 0; i < 2; i++ {
+		req, err := adm.newRequest(context.Background(), http.MethodPut, large)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := req.Header.Get("X-Amz-Content-Sha256"); got != "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+			t.Fatalf("unexpected content sha256 %q", got)
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(body)) != req.ContentLength {
+			t.Fatalf("attempt %d: read %d bytes, expected %d", i+1, len(body), req.ContentLength)
+		}
+	}
+}
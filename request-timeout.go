@@ -0,0 +1,86 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// RequestCategory classifies admin API calls by their expected duration,
+// to apply different default timeouts.
+type RequestCategory int
+
+// Admin API call categories
+const (
+	// RequestCategoryMetadata are short calls reading or changing
+	// configuration and metadata, the default category.
+	RequestCategoryMetadata RequestCategory = iota
+	// RequestCategoryStreaming are long running calls streaming results,
+	// e.g. traces, logs and speedtests.
+	RequestCategoryStreaming
+	// RequestCategoryTransfer are calls uploading or downloading large
+	// payloads, e.g. IAM exports and profiles.
+	RequestCategoryTransfer
+)
+
+// requestTimeout returns the timeout applied to calls of category
+// whose context has no deadline, zero means no timeout.
+func (adm AdminClient) requestTimeout(category RequestCategory) time.Duration {
+	if timeout, ok := adm.categoryTimeouts[category]; ok {
+		return timeout
+	}
+	if category == RequestCategoryMetadata {
+		return adm.defaultTimeout
+	}
+	return 0
+}
+
+// longRequestMargin is added to the duration of calls running for a
+// caller provided duration, to leave time for the server to respond.
+const longRequestMargin = time.Minute
+
+// withRequestTimeout applies the default timeout of category to ctx
+// if it has no deadline yet. The timeout is never shorter than
+// minTimeout, the time the call is expected to run.
+func (adm AdminClient) withRequestTimeout(ctx context.Context, category RequestCategory, minTimeout time.Duration) (context.Context, context.CancelFunc) {
+	timeout := adm.requestTimeout(category)
+	if timeout > 0 && minTimeout > 0 && timeout < minTimeout+longRequestMargin {
+		timeout = minTimeout + longRequestMargin
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose cancels the request timeout context
+// once the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
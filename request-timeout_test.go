@@ -0,0 +1,96 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("done"))
+	}))
+	defer srv.Close()
+
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		Creds:          credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy:    &RetryPolicy{MaxRetry: 1, Unit: time.Millisecond},
+		DefaultTimeout: 50 * time.Millisecond,
+		CategoryTimeouts: map[RequestCategory]time.Duration{
+			RequestCategoryTransfer: time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		ctx        func() (context.Context, context.CancelFunc)
+		category   RequestCategory
+		minTimeout time.Duration
+		timeout    bool
+	}{
+		// Metadata calls use the default timeout.
+		{ctx: func() (context.Context, context.CancelFunc) { return context.Background(), func() {} }, category: RequestCategoryMetadata, timeout: true},
+		// A caller deadline takes precedence.
+		{ctx: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Second)
+		}, category: RequestCategoryMetadata},
+		// Streaming calls have no timeout by default.
+		{ctx: func() (context.Context, context.CancelFunc) { return context.Background(), func() {} }, category: RequestCategoryStreaming},
+		// Overridden category timeout.
+		{ctx: func() (context.Context, context.CancelFunc) { return context.Background(), func() {} }, category: RequestCategoryTransfer},
+		// Calls running for a given duration are not cut off early.
+		{ctx: func() (context.Context, context.CancelFunc) { return context.Background(), func() {} }, category: RequestCategoryMetadata, minTimeout: time.Second},
+	}
+	for i, tc := range testCases {
+		ctx, cancel := tc.ctx()
+		resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{relPath: adminAPIPrefix + "/info", category: tc.category, minTimeout: tc.minTimeout})
+		if tc.timeout {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Test %d: expected deadline exceeded, got %v", i+1, err)
+			}
+			cancel()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		// The body must remain readable until closed.
+		b, err := ioutil.ReadAll(resp.Body)
+		closeResponse(resp)
+		cancel()
+		if err != nil || string(b) != "done" {
+			t.Errorf("Test %d: unexpected body %q, %v", i+1, b, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestOperation describes an admin API call being traced.
+type RequestOperation struct {
+	// Name of the operation, the HTTP method and the admin API path,
+	// e.g. "GET /info".
+	Name     string
+	Method   string
+	Path     string
+	Host     string
+	Category RequestCategory
+}
+
+// RequestResult describes the outcome of a traced admin API call.
+type RequestResult struct {
+	StatusCode int
+	Attempts   int
+	Duration   time.Duration
+	Err        error
+}
+
+// RequestTracer traces admin API calls, e.g. by starting an OpenTelemetry
+// span in StartRequest and ending it in the returned function.
+type RequestTracer interface {
+	// StartRequest is called before an admin API call, the returned
+	// context is used for the call, including all of its retries, and
+	// end is called once the call completes.
+	StartRequest(ctx context.Context, op RequestOperation) (_ context.Context, end func(RequestResult))
+}
+
+// startRequest starts tracing the call if a tracer is configured.
+func (adm AdminClient) startRequest(ctx context.Context, method string, reqData requestData) (context.Context, func(RequestResult)) {
+	if adm.tracer == nil {
+		return ctx, func(RequestResult) {}
+	}
+	path := strings.TrimPrefix(reqData.relPath, adminAPIPrefix)
+	host := adm.endpointURL.Host
+	if reqData.endpointOverride != nil {
+		host = reqData.endpointOverride.Host
+	}
+	return adm.tracer.StartRequest(ctx, RequestOperation{
+		Name:     method + " " + path,
+		Method:   method,
+		Path:     path,
+		Host:     host,
+		Category: reqData.category,
+	})
+}
+
+// wrapTransport applies the configured transport wrapper to tr.
+func (adm AdminClient) wrapTransport(tr http.RoundTripper) http.RoundTripper {
+	if adm.transportWrapper == nil {
+		return tr
+	}
+	return adm.transportWrapper(tr)
+}
@@ -0,0 +1,93 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type testTracer struct {
+	ops     []RequestOperation
+	results []RequestResult
+}
+
+func (t *testTracer) StartRequest(ctx context.Context, op RequestOperation) (context.Context, func(RequestResult)) {
+	t.ops = append(t.ops, op)
+	return ctx, func(res RequestResult) {
+		t.results = append(t.results, res)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestRequestTracer(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var wrapped int32
+	tracer := &testTracer{}
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy: &RetryPolicy{MaxRetry: 3, Unit: time.Millisecond},
+		Tracer:      tracer,
+		TransportWrapper: func(tr http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&wrapped, 1)
+				return tr.RoundTrip(r)
+			})
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := adm.executeMethod(context.Background(), http.MethodGet, requestData{relPath: adminAPIPrefix + "/info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeResponse(resp)
+
+	if len(tracer.ops) != 1 || len(tracer.results) != 1 {
+		t.Fatalf("expected one traced call, got %d started and %d ended", len(tracer.ops), len(tracer.results))
+	}
+	if op := tracer.ops[0]; op.Name != "GET /info" || op.Host != adm.endpointURL.Host {
+		t.Errorf("unexpected operation %+v", op)
+	}
+	if res := tracer.results[0]; res.StatusCode != http.StatusNoContent || res.Attempts != 2 || res.Err != nil {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if got := atomic.LoadInt32(&wrapped); got != 2 {
+		t.Errorf("expected 2 requests through the wrapped transport, got %d", got)
+	}
+}
@@ -41,6 +41,81 @@ const DefaultRetryUnit = time.Second
 // this maximum time duration.
 const DefaultRetryCap = time.Second * 30
 
+// RetryPolicy configures how requests which failed with a
+// transient error are retried by the admin client.
+type RetryPolicy struct {
+	// MaxRetry is the maximum number of attempts per request,
+	// defaults to MaxRetry when zero.
+	MaxRetry int
+
+	// Unit is multiplied exponentially per retry to compute
+	// the backoff, defaults to DefaultRetryUnit when zero.
+	Unit time.Duration
+
+	// Cap is the longest duration waited between two attempts,
+	// defaults to DefaultRetryCap when zero.
+	Cap time.Duration
+
+	// Jitter randomizes the backoff, it is normalized to the
+	// range [NoJitter, MaxJitter]. Zero disables jitter.
+	Jitter float64
+
+	// RetryableStatusCodes overrides the list of HTTP status
+	// codes which are retried, defaults to the built-in list
+	// when empty.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used when
+// no RetryPolicy is configured in Options.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetry: MaxRetry,
+		Unit:     DefaultRetryUnit,
+		Cap:      DefaultRetryCap,
+		Jitter:   MaxJitter,
+	}
+}
+
+// withDefaults returns a copy of the policy where all
+// unset fields are filled in from DefaultRetryPolicy.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	def := DefaultRetryPolicy()
+	if p.MaxRetry <= 0 {
+		p.MaxRetry = def.MaxRetry
+	}
+	if p.Unit <= 0 {
+		p.Unit = def.Unit
+	}
+	if p.Cap <= 0 {
+		p.Cap = def.Cap
+	}
+	return p
+}
+
+// getRetryPolicy returns the retry policy configured for
+// the client, or the default one.
+func (adm AdminClient) getRetryPolicy() RetryPolicy {
+	if adm.retryPolicy.MaxRetry == 0 {
+		// Client was not constructed through New or NewWithOptions.
+		return DefaultRetryPolicy()
+	}
+	return adm.retryPolicy
+}
+
+// isHTTPStatusRetryable - is HTTP error code retryable under this policy.
+func (p RetryPolicy) isHTTPStatusRetryable(httpStatusCode int) bool {
+	if len(p.RetryableStatusCodes) == 0 {
+		return isHTTPStatusRetryable(httpStatusCode)
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == httpStatusCode {
+			return true
+		}
+	}
+	return false
+}
+
 // lockedRandSource provides protected rand source, implements rand.Source interface.
 type lockedRandSource struct {
 	lk  sync.Mutex
@@ -63,33 +138,33 @@ func (r *lockedRandSource) Seed(seed int64) {
 	r.lk.Unlock()
 }
 
+// exponentialBackoffWait computes the exponential backoff duration according to
+// https://www.awsarchitectureblog.com/2015/03/backoff.html, jitter requires random.
+func exponentialBackoffWait(random *rand.Rand, attempt int, unit time.Duration, cap time.Duration, jitter float64) time.Duration {
+	// normalize jitter to the range [0, 1.0]
+	if jitter < NoJitter {
+		jitter = NoJitter
+	}
+	if jitter > MaxJitter {
+		jitter = MaxJitter
+	}
+
+	// sleep = random_between(0, min(cap, base * 2 ** attempt))
+	sleep := unit * 1 << uint(attempt)
+	if sleep > cap || sleep <= 0 {
+		sleep = cap
+	}
+	if jitter > NoJitter && random != nil {
+		sleep -= time.Duration(random.Float64() * float64(sleep) * jitter)
+	}
+	return sleep
+}
+
 // newRetryTimer creates a timer with exponentially increasing
 // delays until the maximum retry attempts are reached.
 func (adm AdminClient) newRetryTimer(ctx context.Context, maxRetry int, unit time.Duration, cap time.Duration, jitter float64) <-chan int {
 	attemptCh := make(chan int)
 
-	// computes the exponential backoff duration according to
-	// https://www.awsarchitectureblog.com/2015/03/backoff.html
-	exponentialBackoffWait := func(attempt int) time.Duration {
-		// normalize jitter to the range [0, 1.0]
-		if jitter < NoJitter {
-			jitter = NoJitter
-		}
-		if jitter > MaxJitter {
-			jitter = MaxJitter
-		}
-
-		// sleep = random_between(0, min(cap, base * 2 ** attempt))
-		sleep := unit * 1 << uint(attempt)
-		if sleep > cap {
-			sleep = cap
-		}
-		if jitter > NoJitter {
-			sleep -= time.Duration(adm.random.Float64() * float64(sleep) * jitter)
-		}
-		return sleep
-	}
-
 	go func() {
 		defer close(attemptCh)
 		for i := 0; i < maxRetry; i++ {
@@ -102,7 +177,7 @@ func (adm AdminClient) newRetryTimer(ctx context.Context, maxRetry int, unit tim
 			}
 
 			select {
-			case <-time.After(exponentialBackoffWait(i)):
+			case <-time.After(exponentialBackoffWait(adm.random, i, unit, cap, jitter)):
 			case <-ctx.Done():
 				// Stop the routine.
 				return
@@ -0,0 +1,206 @@
+//
+// MinIO Object Storage (c) 2022 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   RetryPolicy
+		failures int32
+		status   int
+		attempts int32
+	}{
+		// Default retryable status codes, succeeds on third attempt.
+		{RetryPolicy{MaxRetry: 5, Unit: time.Millisecond}, 2, http.StatusOK, 3},
+		// Attempts are exhausted before the server recovers.
+		{RetryPolicy{MaxRetry: 2, Unit: time.Millisecond}, 5, http.StatusServiceUnavailable, 2},
+		// Custom status codes, 503 is not retried anymore.
+		{RetryPolicy{MaxRetry: 5, Unit: time.Millisecond, RetryableStatusCodes: []int{http.StatusInternalServerError}}, 2, http.StatusServiceUnavailable, 1},
+	}
+
+	for i, testCase := range testCases {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) <= testCase.failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		policy := testCase.policy
+		adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+			Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+			RetryPolicy: &policy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := adm.executeMethod(context.Background(), http.MethodGet, requestData{relPath: adminAPIPrefix + "/info"})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		closeResponse(resp)
+		srv.Close()
+
+		if resp.StatusCode != testCase.status {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.status, resp.StatusCode)
+		}
+		if got := atomic.LoadInt32(&attempts); got != testCase.attempts {
+			t.Errorf("Test %d: expected %d attempts, got %d", i+1, testCase.attempts, got)
+		}
+	}
+}
+
+// countingProvider hands out a new secret key and
+// session token on every retrieval.
+type countingProvider struct {
+	retrieved int32
+}
+
+func (p *countingProvider) Retrieve() (credentials.Value, error) {
+	n := atomic.AddInt32(&p.retrieved, 1)
+	return credentials.Value{
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio123" + strings.Repeat("x", int(n)),
+		SessionToken:    "token" + strings.Repeat("x", int(n)),
+	}, nil
+}
+
+func (p *countingProvider) IsExpired() bool { return false }
+
+func TestRetryExpiredToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the refreshed token is accepted.
+		if r.Header.Get("X-Amz-Security-Token") != "tokenxx" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"Code":"ExpiredToken","Message":"token expired"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	provider := &countingProvider{}
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		CredsProvider: provider,
+		RetryPolicy:   &RetryPolicy{MaxRetry: 5, Unit: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := adm.executeMethod(context.Background(), http.MethodGet, requestData{relPath: adminAPIPrefix + "/info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected refreshed credentials to succeed, got %d", resp.StatusCode)
+	}
+	if n := atomic.LoadInt32(&provider.retrieved); n != 2 {
+		t.Errorf("expected 2 retrievals, got %d", n)
+	}
+}
+
+func TestRetryExpiredTokenEncryptedBody(t *testing.T) {
+	var plaintext []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Security-Token") != "tokenxx" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"Code":"ExpiredToken","Message":"token expired"}`))
+			return
+		}
+		// The retry must be encrypted with the refreshed secret key.
+		data, err := DecryptData("minio123xx", r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		plaintext = data
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		CredsProvider: &countingProvider{},
+		RetryPolicy:   &RetryPolicy{MaxRetry: 5, Unit: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = adm.SetConfigKV(context.Background(), "region name=us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "region name=us-east-1" {
+		t.Errorf("unexpected decrypted body %q", plaintext)
+	}
+}
+
+func TestRetryIdempotencyKey(t *testing.T) {
+	var (
+		attempts int32
+		keys     = make(map[string]int)
+		mu       sync.Mutex
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get(IdempotencyKeyHeader)]++
+		mu.Unlock()
+		// Fail the first attempt after applying it, the retry is a replay.
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(IdempotencyReplayedHeader, "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy: &RetryPolicy{MaxRetry: 5, Unit: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := adm.SetPolicyWithIdempotencyKey(context.Background(), "key-1", "readwrite", "user", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replayed {
+		t.Error("expected the retried request to be replayed")
+	}
+	if len(keys) != 1 || keys["key-1"] != 2 {
+		t.Errorf("expected both attempts to carry key-1, got %v", keys)
+	}
+}
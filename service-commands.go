@@ -19,6 +19,9 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -84,6 +87,137 @@ func (adm *AdminClient) serviceCallAction(ctx context.Context, action ServiceAct
 	return nil
 }
 
+// ServiceRestartDrain - restarts the MinIO cluster once the in-flight S3
+// requests on each node completed, requests still running after the drain
+// timeout are aborted and reported in the result
+func (adm *AdminClient) ServiceRestartDrain(ctx context.Context, timeout time.Duration) (ServiceActionResult, error) {
+	return adm.serviceCallActionDrain(ctx, ServiceActionRestart, timeout)
+}
+
+// ServiceStopDrain - stops the MinIO cluster once the in-flight S3 requests
+// on each node completed, requests still running after the drain timeout
+// are aborted and reported in the result
+func (adm *AdminClient) ServiceStopDrain(ctx context.Context, timeout time.Duration) (ServiceActionResult, error) {
+	return adm.serviceCallActionDrain(ctx, ServiceActionStop, timeout)
+}
+
+// ServiceActionPeerResult - result of a service action on a node
+type ServiceActionPeerResult struct {
+	Host            string        `json:"host"`
+	Drained         bool          `json:"drained"`         // true if all in-flight requests completed
+	DrainTime       time.Duration `json:"drainTime"`       // time spent waiting for in-flight requests
+	AbortedRequests int64         `json:"abortedRequests"` // in-flight requests aborted at the timeout
+	Err             string        `json:"error,omitempty"`
+}
+
+// ServiceActionResult - result of a drain-aware service action
+type ServiceActionResult struct {
+	Action  ServiceAction             `json:"action"`
+	Results []ServiceActionPeerResult `json:"results"`
+}
+
+// AbortedRequests returns the total number of requests aborted on all nodes
+func (r ServiceActionResult) AbortedRequests() (n int64) {
+	for _, res := range r.Results {
+		n += res.AbortedRequests
+	}
+	return n
+}
+
+// ErrDrainUnsupported is returned by ServiceRestartDrain and ServiceStopDrain
+// when the server does not support draining. The server rejected the request
+// without acting on it, so callers may fall back to ServiceRestart and
+// ServiceStop.
+var ErrDrainUnsupported = errors.New("madmin: server does not support draining service actions")
+
+// serviceCallActionDrain - call service restart/stop API waiting
+// up to timeout for the in-flight requests to complete.
+//
+// Draining uses a dedicated endpoint so that servers not supporting
+// it reject the request instead of acting on it without draining.
+func (adm *AdminClient) serviceCallActionDrain(ctx context.Context, action ServiceAction, timeout time.Duration) (res ServiceActionResult, err error) {
+	if timeout <= 0 {
+		return res, ErrInvalidArgument("drain timeout must be positive")
+	}
+	queryValues := url.Values{}
+	queryValues.Set("action", string(action))
+	queryValues.Set("timeout", timeout.String())
+
+	// The server responds once the drain completed, wait at
+	// most the drain timeout unless the caller set a deadline.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout+longRequestMargin)
+		defer cancel()
+	}
+
+	// Retrying would restart or stop the cluster twice.
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/service-drain",
+			queryValues: queryValues,
+			noRetry:     true,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return res, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		// Unknown admin API, the action was not performed.
+		return res, ErrDrainUnsupported
+	default:
+		return res, httpRespToErrorResponse(resp)
+	}
+
+	// The action was performed, an empty body only
+	// lacks the per node results.
+	if err = json.NewDecoder(resp.Body).Decode(&res); err == io.EOF {
+		return ServiceActionResult{Action: action}, nil
+	}
+	return res, err
+}
+
+// CertificateReloadResult - result of reloading the certificates of a node
+type CertificateReloadResult struct {
+	Node string `json:"node"`
+	// Certificates currently served by the node after the reload.
+	Certificates []TLSCert `json:"certificates,omitempty"`
+	Err          string    `json:"error,omitempty"`
+}
+
+// ReloadCertificates - makes the given nodes, or all nodes if none are
+// given, re-read their TLS certificates from disk without restarting.
+// Nodes failing to load the new certificates keep serving the old ones.
+func (adm *AdminClient) ReloadCertificates(ctx context.Context, nodes ...string) ([]CertificateReloadResult, error) {
+	queryValues := url.Values{}
+	if len(nodes) > 0 {
+		queryValues.Set("node", strings.Join(nodes, ","))
+	}
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/reload-certificates",
+			queryValues: queryValues,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var results []CertificateReloadResult
+	err = json.NewDecoder(resp.Body).Decode(&results)
+	return results, err
+}
+
 // ServiceTraceInfo holds http trace
 type ServiceTraceInfo struct {
 	Trace TraceInfo
@@ -103,6 +237,13 @@ type ServiceTraceOpts struct {
 
 	OnlyErrors bool
 	Threshold  time.Duration
+
+	// Filters applied on the server, only traces
+	// matching all of the set filters are sent.
+	Bucket        string   // Only traces on this bucket.
+	Prefix        string   // Only traces on objects with this prefix, requires Bucket.
+	APINames      []string // Only traces of these API calls, e.g. "s3.PutObject".
+	StatusClasses []int    // Only traces with these response status classes, e.g. 4 for 4xx.
 }
 
 // TraceTypes returns the enabled traces as a bitfield value.
@@ -130,6 +271,19 @@ func (t ServiceTraceOpts) AddParams(u url.Values) {
 	u.Set("scanner", strconv.FormatBool(t.Scanner))
 	u.Set("decommission", strconv.FormatBool(t.Decommission))
 	u.Set("healing", strconv.FormatBool(t.Healing))
+
+	if t.Bucket != "" {
+		u.Set("bucket", t.Bucket)
+	}
+	if t.Prefix != "" {
+		u.Set("prefix", t.Prefix)
+	}
+	for _, name := range t.APINames {
+		u.Add("api", name)
+	}
+	for _, class := range t.StatusClasses {
+		u.Add("status-class", strconv.Itoa(class))
+	}
 }
 
 // ParseParams will parse parameters and set them to t.
@@ -142,6 +296,23 @@ func (t *ServiceTraceOpts) ParseParams(r *http.Request) (err error) {
 	t.Storage = r.Form.Get("storage") == "true"
 	t.Internal = r.Form.Get("internal") == "true"
 	t.OnlyErrors = r.Form.Get("err") == "true"
+	t.Bucket = r.Form.Get("bucket")
+	t.Prefix = r.Form.Get("prefix")
+	t.APINames = r.Form["api"]
+	t.StatusClasses = nil
+	for _, v := range r.Form["status-class"] {
+		class, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		if class < 1 || class > 5 {
+			return fmt.Errorf("invalid status class %d", class)
+		}
+		t.StatusClasses = append(t.StatusClasses, class)
+	}
+	if t.Prefix != "" && t.Bucket == "" {
+		return errors.New("prefix filter requires a bucket")
+	}
 
 	if th := r.Form.Get("threshold"); th != "" {
 		d, err := time.ParseDuration(th)
@@ -166,6 +337,7 @@ func (adm AdminClient) ServiceTrace(ctx context.Context, opts ServiceTraceOpts)
 			reqData := requestData{
 				relPath:     adminAPIPrefix + "/trace",
 				queryValues: urlValues,
+				category:    RequestCategoryStreaming,
 			}
 			// Execute GET to call trace handler
 			resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
@@ -175,8 +347,8 @@ func (adm AdminClient) ServiceTrace(ctx context.Context, opts ServiceTraceOpts)
 			}
 
 			if resp.StatusCode != http.StatusOK {
-				closeResponse(resp)
 				traceInfoCh <- ServiceTraceInfo{Err: httpRespToErrorResponse(resp)}
+				closeResponse(resp)
 				return
 			}
 
@@ -0,0 +1,211 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestServiceRestartDrain(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/service-drain" ||
+			q.Get("action") != "restart" || q.Get("timeout") != "1m0s" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(ServiceActionResult{
+			Action:  ServiceActionRestart,
+			Results: []ServiceActionPeerResult{{Host: "node1", Drained: true}, {Host: "node2", AbortedRequests: 3}},
+		})
+	})
+
+	res, err := adm.ServiceRestartDrain(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 2 || res.AbortedRequests() != 3 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	if _, err = adm.ServiceRestartDrain(context.Background(), 0); err == nil {
+		t.Error("expected error for a zero drain timeout")
+	}
+}
+
+func TestServiceRestartDrainUnsupported(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusNotFound, "XMinioUnknownAPIRequest")
+	})
+	if _, err := adm.ServiceStopDrain(context.Background(), time.Minute); !errors.Is(err, ErrDrainUnsupported) {
+		t.Fatalf("expected unsupported drain, got %v", err)
+	}
+
+	adm = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	if _, err := adm.ServiceStopDrain(context.Background(), time.Minute); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %v", err)
+	}
+}
+
+func TestServiceRestartDrainEmptyResult(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	res, err := adm.ServiceStopDrain(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ServiceActionStop || len(res.Results) != 0 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestServiceRestartDrainNoRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeTestError(w, http.StatusServiceUnavailable, "XMinioServerNotInitialized")
+	}))
+	defer srv.Close()
+
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy: &RetryPolicy{MaxRetry: 5, Unit: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = adm.ServiceRestartDrain(context.Background(), time.Minute); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single attempt, got %d", n)
+	}
+}
+
+func TestServiceTraceFilters(t *testing.T) {
+	opts := ServiceTraceOpts{
+		S3:            true,
+		Bucket:        "photos",
+		Prefix:        "2022/",
+		APINames:      []string{"s3.PutObject", "s3.GetObject"},
+		StatusClasses: []int{4, 5},
+	}
+
+	var calls int32
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			writeTestError(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		var parsed ServiceTraceOpts
+		r.ParseForm()
+		if r.URL.Path != "/minio/admin/v3/trace" || parsed.ParseParams(r) != nil {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		if parsed.Bucket != opts.Bucket || parsed.Prefix != opts.Prefix ||
+			strings.Join(parsed.APINames, ",") != "s3.PutObject,s3.GetObject" ||
+			len(parsed.StatusClasses) != 2 || parsed.StatusClasses[1] != 5 {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(TraceInfo{TraceType: TraceS3, FuncName: "s3.PutObject"})
+	})
+
+	var traces []TraceInfo
+	var errs []error
+	for info := range adm.ServiceTrace(context.Background(), opts) {
+		if info.Err != nil {
+			errs = append(errs, info.Err)
+			continue
+		}
+		traces = append(traces, info.Trace)
+	}
+	if len(traces) != 1 || traces[0].FuncName != "s3.PutObject" {
+		t.Fatalf("unexpected traces %+v", traces)
+	}
+	if len(errs) == 0 || ToErrorResponse(errs[len(errs)-1]).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %v", errs)
+	}
+}
+
+func TestServiceTraceOptsParseParams(t *testing.T) {
+	testCases := []struct {
+		query string
+		valid bool
+	}{
+		{"bucket=photos&prefix=a/&status-class=2", true},
+		{"prefix=a/", false},
+		{"status-class=6", false},
+		{"status-class=x", false},
+	}
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/minio/admin/v3/trace?"+tc.query, nil)
+		r.ParseForm()
+		var opts ServiceTraceOpts
+		if err := opts.ParseParams(r); (err == nil) != tc.valid {
+			t.Errorf("Test %d: expected valid %t, got %v", i+1, tc.valid, err)
+		}
+	}
+}
+
+func TestReloadCertificates(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/reload-certificates" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		if r.URL.Query().Get("node") != "node1:9000,node2:9000" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchNode")
+			return
+		}
+		json.NewEncoder(w).Encode([]CertificateReloadResult{
+			{Node: "node1:9000", Certificates: []TLSCert{{PubKeyAlgo: "ECDSA"}}},
+			{Node: "node2:9000", Err: "private key does not match"},
+		})
+	})
+
+	ctx := context.Background()
+	results, err := adm.ReloadCertificates(ctx, "node1:9000", "node2:9000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(results[0].Certificates) != 1 || results[0].Err != "" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results[1].Err != "private key does not match" {
+		t.Fatalf("expected error of node2, got %+v", results[1])
+	}
+
+	if _, err = adm.ReloadCertificates(ctx); ToErrorResponse(err).Code != "XMinioAdminNoSuchNode" {
+		t.Errorf("expected XMinioAdminNoSuchNode, got %v", err)
+	}
+}
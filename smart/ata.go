@@ -0,0 +1,163 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package smart
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ATASmartDataSize is the size of the data returned
+// by the ATA SMART READ DATA and READ THRESHOLDS commands.
+const ATASmartDataSize = 512
+
+const (
+	ataAttrOffset = 2
+	ataAttrSize   = 12
+	ataAttrCount  = 30
+)
+
+// Well known ATA SMART attribute IDs.
+const (
+	ATAReallocatedSectors = 5
+	ATAPowerOnHours       = 9
+	ATAPowerCycles        = 12
+	ATAWearLeveling       = 177
+	ATAMediaWearout       = 233
+	ATATemperature        = 194
+	ATAPendingSectors     = 197
+	ATAUncorrectable      = 198
+)
+
+// ErrChecksum is returned when the checksum of ATA SMART data is invalid.
+var ErrChecksum = errors.New("smart: invalid checksum")
+
+// ATAAttribute is a single ATA SMART attribute.
+type ATAAttribute struct {
+	ID        uint8  `json:"id"`
+	Flags     uint16 `json:"flags"`
+	Current   uint8  `json:"current"` // Normalized value, higher is better
+	Worst     uint8  `json:"worst"`
+	Threshold uint8  `json:"threshold,omitempty"`
+	Raw       uint64 `json:"raw"` // 48 bit vendor specific raw value
+}
+
+// Failing returns whether the normalized value crossed the threshold.
+func (a ATAAttribute) Failing() bool {
+	return a.Threshold != 0 && a.Current <= a.Threshold
+}
+
+// ATAAttributes are the attributes reported by an ATA drive.
+type ATAAttributes []ATAAttribute
+
+// ParseATASmartData parses the attributes of the ATA SMART READ DATA
+// command response. If thresholds, the ATA SMART READ THRESHOLDS
+// response, is non-nil the thresholds of the attributes are set too.
+func ParseATASmartData(data, thresholds []byte) (ATAAttributes, error) {
+	if err := checkATABuffer(data); err != nil {
+		return nil, err
+	}
+	if thresholds != nil {
+		if err := checkATABuffer(thresholds); err != nil {
+			return nil, err
+		}
+	}
+
+	var attrs ATAAttributes
+	for i := 0; i < ataAttrCount; i++ {
+		b := data[ataAttrOffset+i*ataAttrSize:][:ataAttrSize]
+		if b[0] == 0 {
+			continue
+		}
+		var raw [8]byte
+		copy(raw[:], b[5:11])
+		attr := ATAAttribute{
+			ID:      b[0],
+			Flags:   binary.LittleEndian.Uint16(b[1:3]),
+			Current: b[3],
+			Worst:   b[4],
+			Raw:     binary.LittleEndian.Uint64(raw[:]),
+		}
+		if thresholds != nil {
+			// Thresholds are listed in the same order as the attributes.
+			t := thresholds[ataAttrOffset+i*ataAttrSize:][:ataAttrSize]
+			if t[0] == attr.ID {
+				attr.Threshold = t[1]
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+func checkATABuffer(buf []byte) error {
+	if len(buf) < ATASmartDataSize {
+		return ErrShortBuffer
+	}
+	var sum byte
+	for _, b := range buf[:ATASmartDataSize] {
+		sum += b
+	}
+	if sum != 0 {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Get returns the attribute with the given ID.
+func (attrs ATAAttributes) Get(id uint8) (ATAAttribute, bool) {
+	for _, a := range attrs {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return ATAAttribute{}, false
+}
+
+// Health returns the drive type independent summary of the attributes.
+func (attrs ATAAttributes) Health() Health {
+	var h Health
+	if a, ok := attrs.Get(ATATemperature); ok {
+		// Lowest byte holds the current temperature.
+		h.Temperature = int(a.Raw & 0xff)
+	}
+	for _, id := range []uint8{ATAReallocatedSectors, ATAPendingSectors, ATAUncorrectable} {
+		if a, ok := attrs.Get(id); ok {
+			h.MediaErrors += a.Raw & 0xffffffff
+		}
+	}
+	for _, id := range []uint8{ATAWearLeveling, ATAMediaWearout} {
+		if a, ok := attrs.Get(id); ok && a.Current <= 100 {
+			// Normalized value counts down from 100.
+			h.WearLevel = 100 - int(a.Current)
+			break
+		}
+	}
+	if a, ok := attrs.Get(ATAPowerOnHours); ok {
+		h.PowerOnHours = a.Raw & 0xffffffff
+	}
+	if a, ok := attrs.Get(ATAPowerCycles); ok {
+		h.PowerCycles = a.Raw & 0xffffffff
+	}
+	for _, a := range attrs {
+		if a.Failing() {
+			h.Failing = true
+			break
+		}
+	}
+	return h
+}
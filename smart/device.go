@@ -0,0 +1,74 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package smart
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotSupported is returned when the S.M.A.R.T data of a drive
+// cannot be read on this platform.
+var ErrNotSupported = errors.New("smart: not supported")
+
+// IdentifySize is the size of the data returned by the ATA IDENTIFY
+// DEVICE command, NVMe identify controller data is 4096 bytes.
+const IdentifySize = 512
+
+const nvmeIdentifySize = 4096
+
+// Identity identifies a drive.
+type Identity struct {
+	SerialNum string `json:"serialNum,omitempty"`
+	ModelNum  string `json:"modelNum,omitempty"`
+	Firmware  string `json:"firmware,omitempty"`
+}
+
+// ParseNVMeIdentify parses the identify controller data structure
+// (CNS 01h) of an NVMe controller.
+func ParseNVMeIdentify(buf []byte) (Identity, error) {
+	if len(buf) < nvmeIdentifySize {
+		return Identity{}, ErrShortBuffer
+	}
+	return Identity{
+		SerialNum: strings.TrimSpace(string(buf[4:24])),
+		ModelNum:  strings.TrimSpace(string(buf[24:64])),
+		Firmware:  strings.TrimSpace(string(buf[64:72])),
+	}, nil
+}
+
+// ParseATAIdentify parses the ATA IDENTIFY DEVICE data.
+func ParseATAIdentify(buf []byte) (Identity, error) {
+	if len(buf) < IdentifySize {
+		return Identity{}, ErrShortBuffer
+	}
+	return Identity{
+		SerialNum: ataString(buf[20:40]),
+		Firmware:  ataString(buf[46:54]),
+		ModelNum:  ataString(buf[54:94]),
+	}, nil
+}
+
+// ataString decodes an ATA string, which stores two characters
+// per 16 bit little endian word with the first in the high byte.
+func ataString(b []byte) string {
+	s := make([]byte, len(b))
+	for i := 0; i+1 < len(b); i += 2 {
+		s[i], s[i+1] = b[i+1], b[i]
+	}
+	return strings.TrimSpace(string(s))
+}
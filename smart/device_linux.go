@@ -0,0 +1,152 @@
+//go:build linux
+// +build linux
+
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package smart
+
+import (
+	"encoding/binary"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	nvmeIoctlAdminCmd = 0xC0484E41 // _IOWR('N', 0x41, struct nvme_admin_cmd)
+	hdioDriveCmd      = 0x031f
+	hdioGetIdentity   = 0x030d
+
+	nvmeAdminGetLogPage = 0x02
+	nvmeAdminIdentify   = 0x06
+	nvmeLogHealth       = 0x02
+
+	ataSmartCmd            = 0xB0
+	ataSmartReadValues     = 0xD0
+	ataSmartReadThresholds = 0xD1
+)
+
+// nvmeAdminCmd mirrors struct nvme_admin_cmd of linux/nvme_ioctl.h.
+type nvmeAdminCmd struct {
+	Opcode      uint8
+	Flags       uint8
+	Rsvd1       uint16
+	NSID        uint32
+	Cdw2        uint32
+	Cdw3        uint32
+	Metadata    uint64
+	Addr        uint64
+	MetadataLen uint32
+	DataLen     uint32
+	Cdw10       uint32
+	Cdw11       uint32
+	Cdw12       uint32
+	Cdw13       uint32
+	Cdw14       uint32
+	Cdw15       uint32
+	TimeoutMS   uint32
+	Result      uint32
+}
+
+func ioctl(f *os.File, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func nvmeAdmin(f *os.File, cmd nvmeAdminCmd, buf []byte) error {
+	cmd.Addr = uint64(uintptr(unsafe.Pointer(&buf[0])))
+	cmd.DataLen = uint32(len(buf))
+	return ioctl(f, nvmeIoctlAdminCmd, unsafe.Pointer(&cmd))
+}
+
+// ReadNVMe reads the identity and the SMART / Health Information
+// log page of the NVMe drive at device, e.g. /dev/nvme0n1.
+func ReadNVMe(device string) (Identity, NVMeHealthLog, error) {
+	f, err := os.OpenFile(device, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return Identity{}, NVMeHealthLog{}, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, nvmeIdentifySize)
+	if err = nvmeAdmin(f, nvmeAdminCmd{Opcode: nvmeAdminIdentify, Cdw10: 1}, buf); err != nil {
+		return Identity{}, NVMeHealthLog{}, err
+	}
+	id, err := ParseNVMeIdentify(buf)
+	if err != nil {
+		return Identity{}, NVMeHealthLog{}, err
+	}
+
+	buf = make([]byte, NVMeHealthLogSize)
+	cmd := nvmeAdminCmd{
+		Opcode: nvmeAdminGetLogPage,
+		NSID:   0xFFFFFFFF,
+		Cdw10:  uint32(NVMeHealthLogSize/4-1)<<16 | nvmeLogHealth,
+	}
+	if err = nvmeAdmin(f, cmd, buf); err != nil {
+		return id, NVMeHealthLog{}, err
+	}
+	log, err := ParseNVMeHealthLog(buf)
+	return id, log, err
+}
+
+// ataSmart issues the SMART command with the given feature, the
+// first 4 bytes of the buffer hold command, sector, feature and count.
+func ataSmart(f *os.File, feature byte) ([]byte, error) {
+	buf := make([]byte, 4+ATASmartDataSize)
+	buf[0], buf[2], buf[3] = ataSmartCmd, feature, 1
+	if err := ioctl(f, hdioDriveCmd, unsafe.Pointer(&buf[0])); err != nil {
+		return nil, err
+	}
+	return buf[4:], nil
+}
+
+// ReadATA reads the identity and the SMART attributes
+// of the ATA drive at device, e.g. /dev/sda.
+func ReadATA(device string) (Identity, ATAAttributes, error) {
+	f, err := os.OpenFile(device, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return Identity{}, nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, IdentifySize)
+	if err = ioctl(f, hdioGetIdentity, unsafe.Pointer(&buf[0])); err != nil {
+		return Identity{}, nil, err
+	}
+	// HDIO_GET_IDENTITY returns the words in host byte order.
+	for i := 0; i < len(buf); i += 2 {
+		binary.LittleEndian.PutUint16(buf[i:], *(*uint16)(unsafe.Pointer(&buf[i])))
+	}
+	id, err := ParseATAIdentify(buf)
+	if err != nil {
+		return Identity{}, nil, err
+	}
+
+	data, err := ataSmart(f, ataSmartReadValues)
+	if err != nil {
+		return id, nil, err
+	}
+	thresholds, err := ataSmart(f, ataSmartReadThresholds)
+	if err != nil {
+		thresholds = nil
+	}
+	attrs, err := ParseATASmartData(data, thresholds)
+	return id, attrs, err
+}
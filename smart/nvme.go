@@ -0,0 +1,104 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package smart
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// NVMeHealthLogSize is the size of the NVMe SMART / Health
+// Information log page (log identifier 02h).
+const NVMeHealthLogSize = 512
+
+// NVMe critical warning bits.
+const (
+	NVMeWarnSpare       = 1 << 0 // Available spare below threshold
+	NVMeWarnTemperature = 1 << 1 // Temperature above or below threshold
+	NVMeWarnReliability = 1 << 2 // Reliability degraded by media errors
+	NVMeWarnReadOnly    = 1 << 3 // Media placed in read only mode
+	NVMeWarnBackup      = 1 << 4 // Volatile memory backup device failed
+)
+
+// ErrShortBuffer is returned when a log page is smaller than expected.
+var ErrShortBuffer = errors.New("smart: buffer too short")
+
+// NVMeHealthLog is the parsed NVMe SMART / Health Information log page.
+// 128 bit counters are saturated at the maximum uint64 value.
+type NVMeHealthLog struct {
+	CriticalWarning       uint8  `json:"criticalWarning"`
+	Temperature           int    `json:"temperature"` // Composite temperature in degrees Celsius
+	AvailableSpare        uint8  `json:"availableSpare"`
+	AvailableSpareThresh  uint8  `json:"availableSpareThreshold"`
+	PercentageUsed        uint8  `json:"percentageUsed"`
+	DataUnitsRead         uint64 `json:"dataUnitsRead"`    // In units of 512000 bytes
+	DataUnitsWritten      uint64 `json:"dataUnitsWritten"` // In units of 512000 bytes
+	HostReadCommands      uint64 `json:"hostReadCommands"`
+	HostWriteCommands     uint64 `json:"hostWriteCommands"`
+	ControllerBusyMinutes uint64 `json:"controllerBusyMinutes"`
+	PowerCycles           uint64 `json:"powerCycles"`
+	PowerOnHours          uint64 `json:"powerOnHours"`
+	UnsafeShutdowns       uint64 `json:"unsafeShutdowns"`
+	MediaErrors           uint64 `json:"mediaErrors"`
+	ErrorLogEntries       uint64 `json:"errorLogEntries"`
+}
+
+// ParseNVMeHealthLog parses the NVMe SMART / Health Information log page.
+func ParseNVMeHealthLog(buf []byte) (NVMeHealthLog, error) {
+	if len(buf) < NVMeHealthLogSize {
+		return NVMeHealthLog{}, ErrShortBuffer
+	}
+
+	return NVMeHealthLog{
+		CriticalWarning:       buf[0],
+		Temperature:           int(binary.LittleEndian.Uint16(buf[1:3])) - 273, // Kelvin
+		AvailableSpare:        buf[3],
+		AvailableSpareThresh:  buf[4],
+		PercentageUsed:        buf[5],
+		DataUnitsRead:         uint128(buf[32:48]),
+		DataUnitsWritten:      uint128(buf[48:64]),
+		HostReadCommands:      uint128(buf[64:80]),
+		HostWriteCommands:     uint128(buf[80:96]),
+		ControllerBusyMinutes: uint128(buf[96:112]),
+		PowerCycles:           uint128(buf[112:128]),
+		PowerOnHours:          uint128(buf[128:144]),
+		UnsafeShutdowns:       uint128(buf[144:160]),
+		MediaErrors:           uint128(buf[160:176]),
+		ErrorLogEntries:       uint128(buf[176:192]),
+	}, nil
+}
+
+// uint128 decodes a little endian 128 bit counter, saturated to uint64.
+func uint128(b []byte) uint64 {
+	if binary.LittleEndian.Uint64(b[8:16]) != 0 {
+		return math.MaxUint64
+	}
+	return binary.LittleEndian.Uint64(b[0:8])
+}
+
+// Health returns the drive type independent summary of the log page.
+func (l NVMeHealthLog) Health() Health {
+	return Health{
+		Temperature:  l.Temperature,
+		MediaErrors:  l.MediaErrors,
+		WearLevel:    int(l.PercentageUsed),
+		PowerOnHours: l.PowerOnHours,
+		PowerCycles:  l.PowerCycles,
+		Failing:      l.CriticalWarning&(NVMeWarnSpare|NVMeWarnReliability|NVMeWarnReadOnly|NVMeWarnBackup) != 0,
+	}
+}
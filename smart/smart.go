@@ -0,0 +1,41 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package smart implements parsing of the S.M.A.R.T health data
+// reported by NVMe and ATA drives into typed structs.
+package smart
+
+// Health is the drive type independent summary of the S.M.A.R.T data
+// of a drive, fields not reported by the drive are left at zero.
+type Health struct {
+	// Temperature of the drive in degrees Celsius.
+	Temperature int `json:"temperature,omitempty"`
+
+	// MediaErrors counts unrecovered data integrity errors, for ATA
+	// drives the sum of reallocated, pending and uncorrectable sectors.
+	MediaErrors uint64 `json:"mediaErrors,omitempty"`
+
+	// WearLevel is the estimated percentage of the drive life used,
+	// may exceed 100 for drives used beyond their rated endurance.
+	WearLevel int `json:"wearLevel,omitempty"`
+
+	PowerOnHours uint64 `json:"powerOnHours,omitempty"`
+	PowerCycles  uint64 `json:"powerCycles,omitempty"`
+
+	// Failing is set when the drive reports a critical warning
+	// or an attribute crossed its failure threshold.
+	Failing bool `json:"failing,omitempty"`
+}
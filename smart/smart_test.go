@@ -0,0 +1,146 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package smart
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestParseNVMeHealthLog(t *testing.T) {
+	buf := make([]byte, NVMeHealthLogSize)
+	buf[0] = NVMeWarnReliability
+	binary.LittleEndian.PutUint16(buf[1:3], 313) // 40C
+	buf[3], buf[4], buf[5] = 100, 10, 7
+	binary.LittleEndian.PutUint64(buf[128:], 12345) // power on hours
+	binary.LittleEndian.PutUint64(buf[160:], 3)     // media errors
+	buf[96+8] = 1                                   // controller busy time overflows uint64
+
+	log, err := ParseNVMeHealthLog(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Temperature != 40 || log.PowerOnHours != 12345 || log.MediaErrors != 3 {
+		t.Fatalf("unexpected log %+v", log)
+	}
+	if log.ControllerBusyMinutes != math.MaxUint64 {
+		t.Fatalf("expected saturated counter, got %d", log.ControllerBusyMinutes)
+	}
+
+	h := log.Health()
+	if !h.Failing || h.WearLevel != 7 || h.Temperature != 40 {
+		t.Fatalf("unexpected health %+v", h)
+	}
+
+	if _, err = ParseNVMeHealthLog(buf[:100]); err != ErrShortBuffer {
+		t.Fatalf("expected %v, got %v", ErrShortBuffer, err)
+	}
+}
+
+func TestParseATASmartData(t *testing.T) {
+	data := make([]byte, ATASmartDataSize)
+	thresholds := make([]byte, ATASmartDataSize)
+	setAttr := func(i int, id, current, threshold uint8, raw uint64) {
+		b := data[ataAttrOffset+i*ataAttrSize:]
+		b[0], b[3], b[4] = id, current, current
+		var r [8]byte
+		binary.LittleEndian.PutUint64(r[:], raw)
+		copy(b[5:11], r[:6])
+		tb := thresholds[ataAttrOffset+i*ataAttrSize:]
+		tb[0], tb[1] = id, threshold
+	}
+	setAttr(0, ATAReallocatedSectors, 5, 10, 200)
+	setAttr(1, ATAPowerOnHours, 90, 0, 4000)
+	setAttr(2, ATAWearLeveling, 85, 0, 0)
+	setAttr(3, ATATemperature, 60, 0, 0x0032001e0035) // 53C current, min/max in upper bytes
+	setAttr(4, ATAPendingSectors, 100, 0, 2)
+	fixChecksum := func(b []byte) {
+		var sum byte
+		for _, v := range b[:ATASmartDataSize-1] {
+			sum += v
+		}
+		b[ATASmartDataSize-1] = -sum
+	}
+	fixChecksum(data)
+	fixChecksum(thresholds)
+
+	attrs, err := ParseATASmartData(data, thresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 5 {
+		t.Fatalf("expected 5 attributes, got %d", len(attrs))
+	}
+
+	h := attrs.Health()
+	expected := Health{
+		Temperature:  53,
+		MediaErrors:  202,
+		WearLevel:    15,
+		PowerOnHours: 4000,
+		Failing:      true,
+	}
+	if h != expected {
+		t.Fatalf("expected %+v, got %+v", expected, h)
+	}
+
+	data[100]++
+	if _, err = ParseATASmartData(data, nil); err != ErrChecksum {
+		t.Fatalf("expected %v, got %v", ErrChecksum, err)
+	}
+}
+
+func TestParseIdentify(t *testing.T) {
+	buf := make([]byte, nvmeIdentifySize)
+	copy(buf[4:24], "S4EVNX0N123456      ")
+	copy(buf[24:64], "Samsung SSD 970 EVO Plus 1TB            ")
+	copy(buf[64:72], "2B2QEXM7")
+	id, err := ParseNVMeIdentify(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Identity{SerialNum: "S4EVNX0N123456", ModelNum: "Samsung SSD 970 EVO Plus 1TB", Firmware: "2B2QEXM7"}
+	if id != expected {
+		t.Fatalf("expected %+v, got %+v", expected, id)
+	}
+
+	// ATA strings store the first character of each word in the high byte.
+	swap := func(s string) []byte {
+		b := []byte(s)
+		for i := 0; i+1 < len(b); i += 2 {
+			b[i], b[i+1] = b[i+1], b[i]
+		}
+		return b
+	}
+	buf = make([]byte, IdentifySize)
+	copy(buf[20:40], swap("  WD-WCC4N1234567   "))
+	copy(buf[46:54], swap("82.00A82"))
+	copy(buf[54:94], swap("WDC WD40EFRX-68N32N0                    "))
+	id, err = ParseATAIdentify(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = Identity{SerialNum: "WD-WCC4N1234567", ModelNum: "WDC WD40EFRX-68N32N0", Firmware: "82.00A82"}
+	if id != expected {
+		t.Fatalf("expected %+v, got %+v", expected, id)
+	}
+
+	if _, err = ParseATAIdentify(buf[:100]); err != ErrShortBuffer {
+		t.Fatalf("expected %v, got %v", ErrShortBuffer, err)
+	}
+}
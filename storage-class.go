@@ -0,0 +1,53 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+// StorageClassImpact - estimated usable capacity of the standard
+// storage class before and after a parity change.
+type StorageClassImpact struct {
+	UsableCapacityBefore uint64 `json:"usableCapacityBefore"`
+	UsableCapacityAfter  uint64 `json:"usableCapacityAfter"`
+}
+
+// Change returns the usable capacity gained, negative if capacity is lost.
+func (i StorageClassImpact) Change() int64 {
+	return int64(i.UsableCapacityAfter) - int64(i.UsableCapacityBefore)
+}
+
+// EstimateStorageClassImpact estimates the usable capacity of the
+// layout with the standard parity changed from one value to another.
+func EstimateStorageClassImpact(layout ClusterLayout, from, to int) StorageClassImpact {
+	var impact StorageClassImpact
+	for _, pool := range layout.Pools {
+		for _, set := range pool.Sets {
+			drives := pool.DrivesPerSet
+			if drives == 0 {
+				drives = len(set.Drives)
+			}
+			impact.UsableCapacityBefore += usableCapacity(set.RawCapacity, drives, from)
+			impact.UsableCapacityAfter += usableCapacity(set.RawCapacity, drives, to)
+		}
+	}
+	return impact
+}
+
+func usableCapacity(raw uint64, drives, parity int) uint64 {
+	if drives <= 0 || parity >= drives {
+		return 0
+	}
+	return raw / uint64(drives) * uint64(drives-parity)
+}
@@ -0,0 +1,30 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import "testing"
+
+func TestEstimateStorageClassImpact(t *testing.T) {
+	layout := ClusterLayout{Pools: []PoolLayout{{
+		DrivesPerSet: 4,
+		Sets:         []ErasureSetLayout{{RawCapacity: 400}, {RawCapacity: 400}},
+	}}}
+	impact := EstimateStorageClassImpact(layout, 2, 1)
+	if impact.UsableCapacityBefore != 400 || impact.UsableCapacityAfter != 600 || impact.Change() != 200 {
+		t.Errorf("unexpected impact %+v", impact)
+	}
+}
@@ -0,0 +1,151 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package subnet
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// LicensePublicKeys are the public keys SUBNET licenses are verified
+// with by VerifyLicense. This package does not ship any keys, they must
+// be set to the SUBNET license signing keys by the application.
+var LicensePublicKeys []*ecdsa.PublicKey
+
+// licenseClaims are the JWT claims of a SUBNET license.
+type licenseClaims struct {
+	ID           string   `json:"lid"`
+	Organization string   `json:"org"`
+	Plan         string   `json:"plan"`
+	DeploymentID string   `json:"dep,omitempty"`
+	Capacity     uint64   `json:"cap"`
+	Entitlements []string `json:"ent,omitempty"`
+	Trial        bool     `json:"trial,omitempty"`
+	IssuedAt     int64    `json:"iat"`
+	ExpiresAt    int64    `json:"exp"`
+}
+
+// VerifyLicense verifies the signature of the SUBNET license JWT with
+// one of keys, or LicensePublicKeys if none are given, and returns its
+// details. Expired licenses are returned without error, use
+// LicenseInfo.Expired to check them.
+func VerifyLicense(license string, keys ...*ecdsa.PublicKey) (madmin.LicenseInfo, error) {
+	if len(keys) == 0 {
+		keys = LicensePublicKeys
+	}
+	if len(keys) == 0 {
+		return madmin.LicenseInfo{}, errors.New("no license public keys configured")
+	}
+
+	parts := strings.Split(strings.TrimSpace(license), ".")
+	if len(parts) != 3 {
+		return madmin.LicenseInfo{}, errors.New("invalid license: expected a signed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return madmin.LicenseInfo{}, err
+	}
+	// The algorithm fixes both the digest and the curve, keys of
+	// other curves are never used to verify the signature.
+	var (
+		hash  crypto.Hash
+		curve elliptic.Curve
+	)
+	switch header.Alg {
+	case "ES256":
+		hash, curve = crypto.SHA256, elliptic.P256()
+	case "ES384":
+		hash, curve = crypto.SHA384, elliptic.P384()
+	default:
+		return madmin.LicenseInfo{}, fmt.Errorf("unsupported license signature algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if size := (curve.Params().BitSize + 7) / 8; err != nil || len(sig) != 2*size {
+		return madmin.LicenseInfo{}, errors.New("invalid license signature")
+	}
+	r := new(big.Int).SetBytes(sig[:len(sig)/2])
+	s := new(big.Int).SetBytes(sig[len(sig)/2:])
+	digest := licenseDigest(hash, parts[0]+"."+parts[1])
+
+	var verified, matched bool
+	for _, key := range keys {
+		if key == nil || key.Curve != curve {
+			continue
+		}
+		matched = true
+		if ecdsa.Verify(key, digest, r, s) {
+			verified = true
+			break
+		}
+	}
+	if !matched {
+		return madmin.LicenseInfo{}, fmt.Errorf("no license public key for signature algorithm %q", header.Alg)
+	}
+	if !verified {
+		return madmin.LicenseInfo{}, errors.New("license signature verification failed")
+	}
+
+	var claims licenseClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return madmin.LicenseInfo{}, err
+	}
+	return madmin.LicenseInfo{
+		ID:              claims.ID,
+		Organization:    claims.Organization,
+		Plan:            claims.Plan,
+		DeploymentID:    claims.DeploymentID,
+		StorageCapacity: claims.Capacity,
+		Entitlements:    claims.Entitlements,
+		IssuedAt:        time.Unix(claims.IssuedAt, 0).UTC(),
+		ExpiresAt:       time.Unix(claims.ExpiresAt, 0).UTC(),
+		Trial:           claims.Trial,
+	}, nil
+}
+
+// decodeSegment decodes a base64url encoded JWT segment into v.
+func decodeSegment(segment string, v interface{}) error {
+	buf, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("invalid license: %w", err)
+	}
+	return json.Unmarshal(buf, v)
+}
+
+func licenseDigest(hash crypto.Hash, signed string) []byte {
+	if hash == crypto.SHA384 {
+		sum := sha512.Sum384([]byte(signed))
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(signed))
+	return sum[:]
+}
@@ -0,0 +1,118 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package subnet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signLicense returns an ES384 signed license JWT.
+func signLicense(t *testing.T, key *ecdsa.PrivateKey, claims string) string {
+	return signLicenseAlg(t, key, "ES384", claims)
+}
+
+// signLicenseAlg returns a license JWT with alg in its header, signed
+// by key with the digest of its curve.
+func signLicenseAlg(t *testing.T, key *ecdsa.PrivateKey, alg, claims string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"`+alg+`","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	var digest []byte
+	if key.Curve == elliptic.P384() {
+		sum := sha512.Sum384([]byte(signed))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(signed))
+		digest = sum[:]
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	sig := make([]byte, 2*size)
+	r.FillBytes(sig[:size])
+	s.FillBytes(sig[size:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifyLicense(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)
+	license := signLicense(t, key, `{"lid":"l1","org":"acme","plan":"ENTERPRISE","cap":100,"ent":["support"],"exp":`+expiry+`}`)
+	info, err := VerifyLicense(license, &other.PublicKey, &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Organization != "acme" || info.StorageCapacity != 100 || !info.Entitled("support") {
+		t.Fatalf("unexpected license info %+v", info)
+	}
+	if info.Expired() || !info.ExpiresWithin(48*time.Hour) {
+		t.Fatalf("unexpected expiry %s", info.ExpiresAt)
+	}
+
+	if _, err = VerifyLicense(license, &other.PublicKey); err == nil {
+		t.Fatal("expected verification with another key to fail")
+	}
+	if _, err = VerifyLicense(license); err == nil {
+		t.Fatal("expected an error without public keys")
+	}
+}
+
+func TestVerifyLicenseAlgorithmCurve(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := `{"lid":"l1","org":"acme","exp":` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `}`
+	if _, err = VerifyLicense(signLicenseAlg(t, p256, "ES256", claims), &p384.PublicKey, &p256.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// The header algorithm must match the curve of the key.
+	for _, license := range []string{
+		signLicenseAlg(t, p256, "ES384", claims),
+		signLicenseAlg(t, p384, "ES256", claims),
+	} {
+		if _, err = VerifyLicense(license, &p256.PublicKey, &p384.PublicKey); err == nil {
+			t.Errorf("expected license %s to be rejected", license)
+		}
+	}
+	if _, err = VerifyLicense(signLicenseAlg(t, p256, "ES256", claims), &p384.PublicKey); err == nil {
+		t.Error("expected an error without a P-256 public key")
+	}
+}
@@ -0,0 +1,216 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package subnet implements the MinIO SUBNET cluster registration,
+// license and health report APIs.
+//
+// Clusters without internet access are registered in offline mode: the
+// registration token is written to a file, registered on SUBNET from a
+// connected machine and the license returned by SUBNET is applied to
+// the cluster with ApplyLicense.
+package subnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/madmin-go"
+)
+
+// DefaultURL is the URL of SUBNET.
+const DefaultURL = "https://subnet.min.io"
+
+// Client is a SUBNET API client.
+type Client struct {
+	// URL of SUBNET, DefaultURL if empty.
+	URL string
+	// APIKey authenticates the client, it is returned on
+	// registration or can be copied from the SUBNET web UI.
+	APIKey string
+	// HTTPClient performs the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// New returns a SUBNET client authenticated with apiKey.
+func New(apiKey string) *Client {
+	return &Client{URL: DefaultURL, APIKey: apiKey}
+}
+
+// RegisterResponse is the response of SUBNET to a registration.
+type RegisterResponse struct {
+	License string `json:"license"`
+	APIKey  string `json:"api_key"`
+}
+
+// Register registers the cluster described by info on SUBNET. The API
+// key in the response is not stored in c, callers set it on the client
+// used for later requests.
+func (c *Client) Register(ctx context.Context, info madmin.ClusterRegistrationInfo) (RegisterResponse, error) {
+	token, err := RegistrationToken(info)
+	if err != nil {
+		return RegisterResponse{}, err
+	}
+	body, err := json.Marshal(madmin.ClusterRegistrationReq{Token: token})
+	if err != nil {
+		return RegisterResponse{}, err
+	}
+
+	var resp RegisterResponse
+	if err = c.do(ctx, "/api/cluster/register", "application/json", bytes.NewReader(body), &resp); err != nil {
+		return RegisterResponse{}, err
+	}
+	return resp, nil
+}
+
+// UploadHealthReport uploads the health report of the cluster
+// deploymentID, as returned by madmin.AdminClient.ServerHealthInfo.
+// The report is streamed to SUBNET without buffering it in memory.
+func (c *Client) UploadHealthReport(ctx context.Context, deploymentID, filename string, report io.Reader) error {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		part, err := w.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, report)
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	path := "/api/health/upload?" + url.Values{"deploymentId": {deploymentID}}.Encode()
+	err := c.do(ctx, path, w.FormDataContentType(), pr, nil)
+	// Unblock the writer if the request failed before reading the body.
+	pr.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+// RegistrationToken encodes info as the registration token accepted
+// by SUBNET, also used for offline registrations.
+func RegistrationToken(info madmin.ClusterRegistrationInfo) (string, error) {
+	if info.DeploymentID == "" {
+		return "", errors.New("deployment ID must not be empty")
+	}
+	buf, err := json.Marshal(info)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// ParseRegistrationToken decodes a token created by RegistrationToken.
+func ParseRegistrationToken(token string) (info madmin.ClusterRegistrationInfo, err error) {
+	buf, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return info, fmt.Errorf("invalid registration token: %w", err)
+	}
+	err = json.Unmarshal(buf, &info)
+	return info, err
+}
+
+// WriteOfflineRegistration writes the registration token of info to w,
+// to be registered on SUBNET from a machine with internet access.
+func WriteOfflineRegistration(w io.Writer, info madmin.ClusterRegistrationInfo) error {
+	token, err := RegistrationToken(info)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, token+"\n")
+	return err
+}
+
+// OfflineRegistrationURL returns the SUBNET web URL registering the
+// cluster described by info, for air-gapped clusters.
+func (c *Client) OfflineRegistrationURL(info madmin.ClusterRegistrationInfo) (string, error) {
+	token, err := RegistrationToken(info)
+	if err != nil {
+		return "", err
+	}
+	return c.baseURL() + "/cluster/register?" + url.Values{"token": {token}}.Encode(), nil
+}
+
+// ReadOfflineLicense reads a license downloaded from SUBNET
+// for an offline registration.
+func ReadOfflineLicense(r io.Reader) (string, error) {
+	buf, err := ioutil.ReadAll(io.LimitReader(r, 64<<10))
+	if err != nil {
+		return "", err
+	}
+	license := strings.TrimSpace(string(buf))
+	if strings.Count(license, ".") != 2 {
+		return "", errors.New("invalid license: expected a signed JWT")
+	}
+	return license, nil
+}
+
+// ApplyLicense configures the cluster to use license.
+func ApplyLicense(ctx context.Context, adm *madmin.AdminClient, license string) error {
+	if license == "" {
+		return errors.New("license must not be empty")
+	}
+	_, err := adm.SetConfigKV(ctx, fmt.Sprintf("subnet license=%q", license))
+	return err
+}
+
+// baseURL returns the URL of SUBNET without trailing slash.
+func (c *Client) baseURL() string {
+	if c.URL == "" {
+		return DefaultURL
+	}
+	return strings.TrimSuffix(c.URL, "/")
+}
+
+// do posts body to path and decodes the JSON response into v, if not nil.
+func (c *Client) do(ctx context.Context, path, contentType string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.APIKey != "" {
+		req.Header.Set("x-subnet-api-key", c.APIKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("subnet: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,152 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package subnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestRegistrationToken(t *testing.T) {
+	info := madmin.ClusterRegistrationInfo{
+		DeploymentID: "d1",
+		ClusterName:  "prod",
+		Info:         madmin.ClusterInfo{NoOfServers: 4},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOfflineRegistration(&buf, info); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseRegistrationToken(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != info {
+		t.Fatalf("expected %+v, got %+v", info, parsed)
+	}
+
+	if _, err = RegistrationToken(madmin.ClusterRegistrationInfo{}); err == nil {
+		t.Fatal("expected an error without deployment ID")
+	}
+}
+
+func TestReadOfflineLicense(t *testing.T) {
+	license, err := ReadOfflineLicense(strings.NewReader("header.payload.signature\n"))
+	if err != nil || license != "header.payload.signature" {
+		t.Fatalf("unexpected license %q: %v", license, err)
+	}
+	if _, err = ReadOfflineLicense(strings.NewReader("not a license")); err == nil {
+		t.Fatal("expected an error for an invalid license")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req madmin.ClusterRegistrationReq
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		info, err := ParseRegistrationToken(req.Token)
+		if err != nil || info.DeploymentID != "d1" || r.URL.Path != "/api/cluster/register" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(RegisterResponse{License: "a.b.c", APIKey: "key"})
+	}))
+	defer srv.Close()
+
+	c := &Client{URL: srv.URL}
+	resp, err := c.Register(context.Background(), madmin.ClusterRegistrationInfo{DeploymentID: "d1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.License != "a.b.c" || resp.APIKey != "key" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if c.APIKey != "" {
+		t.Fatalf("expected the client API key to be unchanged, got %q", c.APIKey)
+	}
+}
+
+func TestUploadHealthReport(t *testing.T) {
+	report := strings.Repeat("health ", 1<<16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health/upload" || r.URL.Query().Get("deploymentId") != "d1" || r.Header.Get("x-subnet-api-key") != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		buf, _ := ioutil.ReadAll(f)
+		if hdr.Filename != "health.json.gz" || string(buf) != report {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, "corrupted upload")
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{URL: srv.URL, APIKey: "key"}
+	if err := c.UploadHealthReport(context.Background(), "d1", "health.json.gz", strings.NewReader(report)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UploadHealthReport(context.Background(), "d2", "health.json.gz", strings.NewReader(report)); err == nil {
+		t.Fatal("expected an error for a rejected upload")
+	}
+
+	// Failing before the body is read must not leak the writer.
+	c.URL = "://invalid"
+	if err := c.UploadHealthReport(context.Background(), "d1", "health.json.gz", strings.NewReader(report)); err == nil {
+		t.Fatal("expected an error for an invalid URL")
+	}
+}
+
+func TestOfflineRegistrationURL(t *testing.T) {
+	info := madmin.ClusterRegistrationInfo{DeploymentID: "d1"}
+	for _, c := range []*Client{{}, New("key")} {
+		u, err := c.OfflineRegistrationURL(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(u, DefaultURL+"/cluster/register?token=") {
+			t.Fatalf("unexpected URL %s", u)
+		}
+	}
+
+	c := &Client{URL: "https://subnet.example.com/"}
+	u, err := c.OfflineRegistrationURL(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u, "https://subnet.example.com/cluster/register?token=") {
+		t.Fatalf("expected the client URL to be used, got %s", u)
+	}
+}
@@ -0,0 +1,217 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SysConfigSysctlKey is the SysConfig key holding the
+// kernel tunables collected by GetSysConfig.
+const SysConfigSysctlKey = "sysctl"
+
+// SysctlTHPEnabled is the pseudo sysctl key holding the
+// transparent hugepages setting.
+const SysctlTHPEnabled = "kernel.mm.transparent_hugepage.enabled"
+
+// SysConfigSeverity is the severity of a deviation from the recommended baseline.
+type SysConfigSeverity string
+
+// Deviation severities.
+const (
+	SysConfigSeverityInfo     SysConfigSeverity = "info"
+	SysConfigSeverityWarning  SysConfigSeverity = "warning"
+	SysConfigSeverityCritical SysConfigSeverity = "critical"
+)
+
+// sysctlCheck is the recommended value of a single kernel tunable.
+type sysctlCheck struct {
+	key         string
+	recommended string
+	severity    SysConfigSeverity
+	description string
+	// ok returns whether the collected value matches the recommendation.
+	ok func(value string) bool
+}
+
+// atLeast - the value, or the field-th whitespace separated field of it, is at least min.
+func atLeast(min int64, field int) func(string) bool {
+	return func(v string) bool {
+		fields := strings.Fields(v)
+		if field >= len(fields) {
+			return false
+		}
+		n, err := strconv.ParseInt(fields[field], 10, 64)
+		return err == nil && n >= min
+	}
+}
+
+// atMost - the value is at most max.
+func atMost(max int64) func(string) bool {
+	return func(v string) bool {
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return err == nil && n <= max
+	}
+}
+
+// selectedOneOf - the selected option, e.g. "always [madvise] never", is one of options.
+func selectedOneOf(options ...string) func(string) bool {
+	return func(v string) bool {
+		selected := strings.TrimSpace(v)
+		if i := strings.IndexByte(selected, '['); i >= 0 {
+			if j := strings.IndexByte(selected[i:], ']'); j > 0 {
+				selected = selected[i+1 : i+j]
+			}
+		}
+		for _, o := range options {
+			if selected == o {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// sysctlBaseline is the MinIO recommended baseline of kernel tunables.
+var sysctlBaseline = []sysctlCheck{
+	{
+		key:         "net.core.somaxconn",
+		recommended: ">= 65535",
+		severity:    SysConfigSeverityWarning,
+		description: "small listen backlog drops connections under load",
+		ok:          atLeast(65535, 0),
+	},
+	{
+		key:         "net.ipv4.tcp_max_syn_backlog",
+		recommended: ">= 16384",
+		severity:    SysConfigSeverityInfo,
+		description: "small SYN backlog drops connections during bursts",
+		ok:          atLeast(16384, 0),
+	},
+	{
+		key:         "net.ipv4.tcp_rmem",
+		recommended: "max >= 16777216",
+		severity:    SysConfigSeverityWarning,
+		description: "small TCP receive buffers limit throughput on fast links",
+		ok:          atLeast(16777216, 2),
+	},
+	{
+		key:         "net.ipv4.tcp_wmem",
+		recommended: "max >= 16777216",
+		severity:    SysConfigSeverityWarning,
+		description: "small TCP send buffers limit throughput on fast links",
+		ok:          atLeast(16777216, 2),
+	},
+	{
+		key:         "vm.swappiness",
+		recommended: "<= 10",
+		severity:    SysConfigSeverityWarning,
+		description: "swapping out server memory causes latency spikes",
+		ok:          atMost(10),
+	},
+	{
+		key:         "vm.max_map_count",
+		recommended: ">= 262144",
+		severity:    SysConfigSeverityInfo,
+		description: "low memory map limit can fail allocations with many drives",
+		ok:          atLeast(262144, 0),
+	},
+	{
+		key:         SysctlTHPEnabled,
+		recommended: "madvise or never",
+		severity:    SysConfigSeverityCritical,
+		description: "transparent hugepages set to always cause memory bloat and latency stalls",
+		ok:          selectedOneOf("madvise", "never"),
+	},
+}
+
+// sysctlKeys returns the kernel tunables to collect for the audit.
+func sysctlKeys() []string {
+	keys := make([]string, 0, len(sysctlBaseline))
+	for _, c := range sysctlBaseline {
+		keys = append(keys, c.key)
+	}
+	return keys
+}
+
+// SysConfigDeviation is a kernel tunable of a node deviating
+// from the MinIO recommended baseline.
+type SysConfigDeviation struct {
+	Addr        string            `json:"addr"`
+	Key         string            `json:"key"`
+	Value       string            `json:"value"`
+	Recommended string            `json:"recommended"`
+	Severity    SysConfigSeverity `json:"severity"`
+	Description string            `json:"description"`
+}
+
+// String returns a human readable description of the deviation.
+func (d SysConfigDeviation) String() string {
+	return fmt.Sprintf("%s: %s %s=%q, recommended %s: %s", d.Addr, d.Severity, d.Key, d.Value, d.Recommended, d.Description)
+}
+
+// AnalyzeSysConfig compares the kernel tunables collected in sysConfigs
+// against the MinIO recommended baseline and returns the deviations,
+// ordered by node and key. Tunables which were not collected are skipped.
+func AnalyzeSysConfig(sysConfigs []SysConfig) []SysConfigDeviation {
+	var deviations []SysConfigDeviation
+	for _, sc := range sysConfigs {
+		values := sysctlValues(sc.Config[SysConfigSysctlKey])
+		for _, c := range sysctlBaseline {
+			v, ok := values[c.key]
+			if !ok || c.ok(v) {
+				continue
+			}
+			deviations = append(deviations, SysConfigDeviation{
+				Addr:        sc.Addr,
+				Key:         c.key,
+				Value:       v,
+				Recommended: c.recommended,
+				Severity:    c.severity,
+				Description: c.description,
+			})
+		}
+	}
+	sort.SliceStable(deviations, func(i, j int) bool {
+		if deviations[i].Addr != deviations[j].Addr {
+			return deviations[i].Addr < deviations[j].Addr
+		}
+		return deviations[i].Key < deviations[j].Key
+	})
+	return deviations
+}
+
+// sysctlValues returns the collected sysctl values, which are a
+// map[string]interface{} once the report was JSON decoded.
+func sysctlValues(v interface{}) map[string]string {
+	switch m := v.(type) {
+	case map[string]string:
+		return m
+	case map[string]interface{}:
+		values := make(map[string]string, len(m))
+		for k, v := range m {
+			if s, ok := v.(string); ok {
+				values[k] = s
+			}
+		}
+		return values
+	}
+	return nil
+}
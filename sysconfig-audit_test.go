@@ -0,0 +1,71 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAnalyzeSysConfig(t *testing.T) {
+	sc := SysConfig{
+		NodeCommon: NodeCommon{Addr: "node1:9000"},
+		Config: map[string]interface{}{
+			SysConfigSysctlKey: map[string]string{
+				"net.core.somaxconn": "4096",
+				"net.ipv4.tcp_rmem":  "4096 131072 33554432",
+				"net.ipv4.tcp_wmem":  "4096 16384 4194304",
+				"vm.swappiness":      "60",
+				SysctlTHPEnabled:     "[always] madvise never",
+			},
+		},
+	}
+
+	// Analyze the report as decoded from JSON.
+	buf, err := json.Marshal([]SysConfig{sc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []SysConfig
+	if err = json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]SysConfigSeverity{
+		SysctlTHPEnabled:     SysConfigSeverityCritical,
+		"net.core.somaxconn": SysConfigSeverityWarning,
+		"net.ipv4.tcp_wmem":  SysConfigSeverityWarning,
+		"vm.swappiness":      SysConfigSeverityWarning,
+	}
+	deviations := AnalyzeSysConfig(decoded)
+	if len(deviations) != len(expected) {
+		t.Fatalf("expected %d deviations, got %v", len(expected), deviations)
+	}
+	for _, d := range deviations {
+		if severity, ok := expected[d.Key]; !ok || severity != d.Severity || d.Addr != sc.Addr {
+			t.Errorf("unexpected deviation %v", d)
+		}
+	}
+
+	sc.Config[SysConfigSysctlKey] = map[string]string{
+		"vm.swappiness":  "0",
+		SysctlTHPEnabled: "always [madvise] never",
+	}
+	if deviations = AnalyzeSysConfig([]SysConfig{sc}); len(deviations) != 0 {
+		t.Fatalf("expected no deviations, got %v", deviations)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+//
+// MinIO Object Storage (c) 2021-2022 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// getSysctls reads the given kernel tunables, tunables which
+// cannot be read, e.g. in containers, are left out.
+func getSysctls(keys []string) map[string]string {
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		path := filepath.Join("/proc/sys", strings.ReplaceAll(key, ".", "/"))
+		if key == SysctlTHPEnabled {
+			path = "/sys/kernel/mm/transparent_hugepage/enabled"
+		}
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		values[key] = strings.Join(strings.Fields(string(buf)), " ")
+	}
+	return values
+}
@@ -0,0 +1,64 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// newTestClient returns a client of a test server answering
+// all requests with handler, requests are not retried.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *AdminClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	adm, err := NewWithOptions(strings.TrimPrefix(srv.URL, "http://"), &Options{
+		Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy: &RetryPolicy{MaxRetry: 1, Unit: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return adm
+}
+
+// writeTestError writes an admin API error response.
+func writeTestError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(`{"Code":"` + code + `","Message":"test error"}`))
+}
+
+// writeEncryptedJSON writes v encrypted with the test client secret key.
+func writeEncryptedJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Error(err)
+	}
+	if data, err = EncryptData("minio123", data); err != nil {
+		t.Error(err)
+	}
+	w.Write(data)
+}
@@ -30,6 +30,8 @@ type TierGCS struct {
 	Prefix       string `json:",omitempty"`
 	Region       string `json:",omitempty"`
 	StorageClass string `json:",omitempty"`
+
+	WorkloadIdentity bool `json:",omitempty"` // use the GKE workload identity instead of Creds
 }
 
 // GCSOptions supports NewTierGCS to take variadic options
@@ -59,6 +61,16 @@ func GCSStorageClass(sc string) func(*TierGCS) error {
 	}
 }
 
+// GCSWorkloadIdentity helper to use the GKE workload identity of the
+// MinIO servers instead of a credentials file in NewTierGCS
+func GCSWorkloadIdentity() func(*TierGCS) error {
+	return func(gcs *TierGCS) error {
+		gcs.Creds = ""
+		gcs.WorkloadIdentity = true
+		return nil
+	}
+}
+
 // GetCredentialJSON method returns the credentials JSON bytes.
 func (gcs *TierGCS) GetCredentialJSON() ([]byte, error) {
 	return base64.URLEncoding.DecodeString(gcs.Creds)
@@ -28,6 +28,9 @@ type TierS3 struct {
 	Region       string `json:",omitempty"`
 	StorageClass string `json:",omitempty"`
 	AWSRole      bool   `json:",omitempty"`
+
+	AWSRoleWebIdentityTokenFile string `json:",omitempty"`
+	AWSRoleARN                  string `json:",omitempty"`
 }
 
 // S3Options supports NewTierS3 to take variadic options
@@ -73,6 +76,24 @@ func S3AWSRole() func(s3 *TierS3) error {
 	}
 }
 
+// S3AWSRoleWebIdentityTokenFile helper to use optional AWS Role token file to NewTierS3
+func S3AWSRoleWebIdentityTokenFile(tokenFile string) func(s3 *TierS3) error {
+	return func(s3 *TierS3) error {
+		s3.AWSRole = true
+		s3.AWSRoleWebIdentityTokenFile = tokenFile
+		return nil
+	}
+}
+
+// S3AWSRoleARN helper to use optional AWS RoleARN to NewTierS3
+func S3AWSRoleARN(roleARN string) func(s3 *TierS3) error {
+	return func(s3 *TierS3) error {
+		s3.AWSRole = true
+		s3.AWSRoleARN = roleARN
+		return nil
+	}
+}
+
 // NewTierS3 returns a TierConfig of S3 type. Returns error if the given
 // parameters are invalid like name is empty etc.
 func NewTierS3(name, accessKey, secretKey, bucket string, options ...S3Options) (*TierConfig, error) {
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path"
 	"time"
 )
@@ -35,14 +36,10 @@ func (adm *AdminClient) AddTier(ctx context.Context, cfg *TierConfig) error {
 		return err
 	}
 
-	encData, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return err
-	}
-
 	reqData := requestData{
 		relPath: path.Join(adminAPIPrefix, tierAPI),
-		content: encData,
+		content: data,
+		encrypt: true,
 	}
 
 	// Execute PUT on /minio/admin/v3/tier to add a remote tier
@@ -95,24 +92,81 @@ type TierCreds struct {
 	SecretKey string `json:"secret,omitempty"`
 	CredsJSON []byte `json:"creds,omitempty"`
 	AWSRole   bool   `json:"awsrole"`
+
+	AWSRoleWebIdentityTokenFile string `json:"awsroleWebIdentity,omitempty"`
+	AWSRoleARN                  string `json:"awsroleARN,omitempty"`
+	GCSWorkloadIdentity         bool   `json:"gcsWorkloadIdentity,omitempty"`
+}
+
+// TierStaticCreds returns tier credentials with a static access and secret key.
+func TierStaticCreds(accessKey, secretKey string) TierCreds {
+	return TierCreds{AccessKey: accessKey, SecretKey: secretKey}
+}
+
+// TierGCSCreds returns tier credentials with a GCS credentials.json.
+func TierGCSCreds(credsJSON []byte) TierCreds {
+	return TierCreds{CredsJSON: credsJSON}
+}
+
+// TierAWSRoleCreds returns tier credentials assuming roleARN with the web
+// identity token in tokenFile, if both are empty the AWS role of the MinIO
+// servers' environment is used.
+func TierAWSRoleCreds(roleARN, tokenFile string) TierCreds {
+	return TierCreds{
+		AWSRole:                     true,
+		AWSRoleARN:                  roleARN,
+		AWSRoleWebIdentityTokenFile: tokenFile,
+	}
+}
+
+// TierGCSWorkloadIdentityCreds returns tier credentials using the GKE
+// workload identity of the MinIO servers.
+func TierGCSWorkloadIdentityCreds() TierCreds {
+	return TierCreds{GCSWorkloadIdentity: true}
+}
+
+func (creds TierCreds) validate() error {
+	kinds := 0
+	if creds.AccessKey != "" || creds.SecretKey != "" {
+		if creds.AccessKey == "" || creds.SecretKey == "" {
+			return ErrInvalidArgument("access key and secret key must both be set")
+		}
+		kinds++
+	}
+	if len(creds.CredsJSON) > 0 {
+		kinds++
+	}
+	if creds.AWSRole {
+		kinds++
+	} else if creds.AWSRoleARN != "" || creds.AWSRoleWebIdentityTokenFile != "" {
+		return ErrInvalidArgument("AWS role ARN and web identity token file require an AWS role")
+	}
+	if creds.GCSWorkloadIdentity {
+		kinds++
+	}
+	if kinds != 1 {
+		return ErrInvalidArgument("exactly one kind of tier credentials must be set")
+	}
+	return nil
 }
 
 // EditTier supports updating credentials for the remote tier identified by tierName.
 func (adm *AdminClient) EditTier(ctx context.Context, tierName string, creds TierCreds) error {
-	data, err := json.Marshal(creds)
-	if err != nil {
+	if tierName == "" {
+		return ErrTierNameEmpty
+	}
+	if err := creds.validate(); err != nil {
 		return err
 	}
-
-	var encData []byte
-	encData, err = EncryptData(adm.getSecretKey(), data)
+	data, err := json.Marshal(creds)
 	if err != nil {
 		return err
 	}
 
 	reqData := requestData{
 		relPath: path.Join(adminAPIPrefix, tierAPI, tierName),
-		content: encData,
+		content: data,
+		encrypt: true,
 	}
 
 	// Execute POST on /minio/admin/v3/tier/tierName to edit a tier
@@ -153,13 +207,66 @@ func (adm *AdminClient) RemoveTier(ctx context.Context, tierName string) error {
 	return nil
 }
 
-// VerifyTier verifies tierName's remote tier config
+// TierCheck names a check performed when verifying a remote tier
+type TierCheck string
+
+// Checks performed by VerifyTier
+const (
+	TierCheckConnectivity TierCheck = "connectivity"
+	TierCheckCredentials  TierCheck = "credentials"
+	TierCheckBucket       TierCheck = "bucket"
+	TierCheckPutObject    TierCheck = "put-object"
+	TierCheckGetObject    TierCheck = "get-object"
+	TierCheckDeleteObject TierCheck = "delete-object"
+)
+
+// TierCheckFailure describes why a remote tier check failed
+type TierCheckFailure struct {
+	Check  TierCheck `json:"check"`
+	Reason string    `json:"reason"`
+}
+
+// TierVerifyError is returned by VerifyTier when the remote tier
+// failed one or more checks
+type TierVerifyError struct {
+	Tier     string             `json:"tier"`
+	Failures []TierCheckFailure `json:"failures"`
+}
+
+func (e *TierVerifyError) Error() string {
+	msg := "tier " + e.Tier + " verification failed"
+	for i, f := range e.Failures {
+		if i == 0 {
+			msg += ": "
+		} else {
+			msg += ", "
+		}
+		msg += string(f.Check) + ": " + f.Reason
+	}
+	return msg
+}
+
+// Failed returns true if check failed
+func (e *TierVerifyError) Failed(check TierCheck) bool {
+	for _, f := range e.Failures {
+		if f.Check == check {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyTier performs a live check of tierName's connectivity and permissions
+// on the remote tier, a *TierVerifyError is returned listing the failed checks.
 func (adm *AdminClient) VerifyTier(ctx context.Context, tierName string) error {
 	if tierName == "" {
 		return ErrTierNameEmpty
 	}
+	queryValues := url.Values{}
+	queryValues.Set("detailed", "true")
 	reqData := requestData{
-		relPath: path.Join(adminAPIPrefix, tierAPI, tierName),
+		relPath:     path.Join(adminAPIPrefix, tierAPI, tierName),
+		queryValues: queryValues,
 	}
 
 	// Execute GET on /minio/admin/v3/tier/tierName to verify tierName's config.
@@ -169,11 +276,24 @@ func (adm *AdminClient) VerifyTier(ctx context.Context, tierName string) error {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return httpRespToErrorResponse(resp)
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusOK:
+		// Servers supporting detailed verification report the failed checks.
+		verifyErr := &TierVerifyError{}
+		if err = json.NewDecoder(resp.Body).Decode(verifyErr); err != nil {
+			return err
+		}
+		if verifyErr.Tier == "" {
+			verifyErr.Tier = tierName
+		}
+		if len(verifyErr.Failures) == 0 {
+			return nil
+		}
+		return verifyErr
 	}
-
-	return nil
+	return httpRespToErrorResponse(resp)
 }
 
 // TierInfo contains tier name, type and statistics
@@ -182,15 +302,37 @@ type TierInfo struct {
 	Type       string
 	Stats      TierStats
 	DailyStats DailyTierStats
+	DailyRates TierRates
 }
 
+// TierRates contains the number of objects and bytes transitioned to and
+// restored from a tier by the scanner over the last 24 hours
+type TierRates struct {
+	TransitionedObjects uint64
+	TransitionedBytes   uint64
+	RestoredObjects     uint64
+	RestoredBytes       uint64
+}
+
+// DailyTierStats contains the tier stats of the last 24 hours in hourly bins
 type DailyTierStats struct {
 	Bins      [24]TierStats
 	UpdatedAt time.Time
 }
 
+// Total returns the sum of the hourly bins
+func (d DailyTierStats) Total() (total TierStats) {
+	for _, bin := range d.Bins {
+		total.TotalSize += bin.TotalSize
+		total.NumVersions += bin.NumVersions
+		total.NumObjects += bin.NumObjects
+	}
+	return total
+}
+
 // TierStats returns per-tier stats of all configured tiers (incl. internal
-// hot-tier)
+// hot-tier), i.e the object count and total size along with the daily
+// transition and restore rates collected by the scanner
 func (adm *AdminClient) TierStats(ctx context.Context) ([]TierInfo, error) {
 	reqData := requestData{
 		relPath: path.Join(adminAPIPrefix, "tier-stats"),
@@ -220,3 +362,63 @@ func (adm *AdminClient) TierStats(ctx context.Context) ([]TierInfo, error) {
 
 	return tierInfos, nil
 }
+
+// TransitionBacklogEntry contains the objects pending transition from
+// a bucket to a remote tier
+type TransitionBacklogEntry struct {
+	Bucket        string    `json:"bucket"`
+	Tier          string    `json:"tier"`
+	Pending       uint64    `json:"pending"`
+	PendingBytes  uint64    `json:"pendingBytes"`
+	OldestPending time.Time `json:"oldestPending"`
+}
+
+// TransitionBacklog contains the pending transition queue depth per
+// bucket and tier
+type TransitionBacklog struct {
+	Entries   []TransitionBacklogEntry `json:"entries"`
+	UpdatedAt time.Time                `json:"updatedAt"`
+}
+
+// Lagging returns the entries whose oldest pending transition is older
+// than maxAge, so alerts can be raised when tiering falls behind
+func (b TransitionBacklog) Lagging(maxAge time.Duration) []TransitionBacklogEntry {
+	var lagging []TransitionBacklogEntry
+	for _, e := range b.Entries {
+		if e.Pending > 0 && !e.OldestPending.IsZero() && b.UpdatedAt.Sub(e.OldestPending) > maxAge {
+			lagging = append(lagging, e)
+		}
+	}
+	return lagging
+}
+
+// TransitionBacklog returns the objects pending transition per bucket and
+// tier, bucket and tier optionally filter the returned entries
+func (adm *AdminClient) TransitionBacklog(ctx context.Context, bucket, tier string) (TransitionBacklog, error) {
+	queryValues := url.Values{}
+	if bucket != "" {
+		queryValues.Set("bucket", bucket)
+	}
+	if tier != "" {
+		queryValues.Set("tier", tier)
+	}
+	reqData := requestData{
+		relPath:     path.Join(adminAPIPrefix, "tier-backlog"),
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/tier-backlog to get the transition backlog.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return TransitionBacklog{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return TransitionBacklog{}, httpRespToErrorResponse(resp)
+	}
+
+	var backlog TransitionBacklog
+	err = json.NewDecoder(resp.Body).Decode(&backlog)
+	return backlog, err
+}
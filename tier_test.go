@@ -17,11 +17,18 @@
 package madmin
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func ExampleNewTierS3() {
@@ -109,6 +116,41 @@ func TestS3Tier(t *testing.T) {
 	}
 }
 
+// TestS3TierAWSRole tests the AWS role helpers of S3Options
+func TestS3TierAWSRole(t *testing.T) {
+	tokenFile := "/var/run/secrets/eks.amazonaws.com/serviceaccount/token"
+	roleARN := "arn:aws:iam::123456789012:role/minio-tier"
+
+	want := &TierS3{
+		Bucket:                      "testbucket",
+		Endpoint:                    "https://s3.amazonaws.com",
+		AWSRole:                     true,
+		AWSRoleWebIdentityTokenFile: tokenFile,
+		AWSRoleARN:                  roleARN,
+	}
+	optionSets := [][]S3Options{
+		{S3AWSRoleWebIdentityTokenFile(tokenFile), S3AWSRoleARN(roleARN)},
+		{S3AWSRole(), S3AWSRoleWebIdentityTokenFile(tokenFile), S3AWSRoleARN(roleARN)},
+	}
+	for i, options := range optionSets {
+		got, err := NewTierS3("test-s3", "", "", "testbucket", options...)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create s3 tier %s", i+1, err)
+		}
+		if !reflect.DeepEqual(got.S3, want) {
+			t.Fatalf("Test %d: got != want, got = %v want = %v", i+1, *got.S3, *want)
+		}
+	}
+
+	got, err := NewTierS3("test-s3", "", "", "testbucket", S3AWSRoleARN(roleARN))
+	if err != nil {
+		t.Fatalf("Failed to create s3 tier %s", err)
+	}
+	if !got.S3.AWSRole || got.S3.AWSRoleWebIdentityTokenFile != "" {
+		t.Fatalf("expected AWS role without token file, got %v", *got.S3)
+	}
+}
+
 // TestAzTier tests AzureOptions helpers
 func TestAzTier(t *testing.T) {
 	scName := "test-az"
@@ -184,3 +226,164 @@ func TestGCSStorageClass(t *testing.T) {
 		t.Fatalf("got != want, got = %v want = %v", *got, *want)
 	}
 }
+
+func TestVerifyTier(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Query().Get("detailed") != "true" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		switch r.URL.Path {
+		case "/minio/admin/v3/tier/HEALTHY":
+			w.WriteHeader(http.StatusNoContent)
+		case "/minio/admin/v3/tier/PASSED":
+			w.Write([]byte(`{"failures":[]}`))
+		case "/minio/admin/v3/tier/BROKEN":
+			json.NewEncoder(w).Encode(TierVerifyError{Failures: []TierCheckFailure{
+				{Check: TierCheckCredentials, Reason: "invalid access key"},
+				{Check: TierCheckPutObject, Reason: "access denied"},
+			}})
+		default:
+			writeTestError(w, http.StatusNotFound, "XMinioAdminTierNotFound")
+		}
+	})
+
+	ctx := context.Background()
+	if err := adm.VerifyTier(ctx, "HEALTHY"); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.VerifyTier(ctx, "PASSED"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := adm.VerifyTier(ctx, "BROKEN")
+	var verifyErr *TierVerifyError
+	if !errors.As(err, &verifyErr) {
+		t.Fatalf("expected *TierVerifyError, got %v", err)
+	}
+	if verifyErr.Tier != "BROKEN" || !verifyErr.Failed(TierCheckCredentials) || verifyErr.Failed(TierCheckBucket) {
+		t.Errorf("unexpected verification error %+v", verifyErr)
+	}
+
+	if err = adm.VerifyTier(ctx, "MISSING"); ToErrorResponse(err).Code != "XMinioAdminTierNotFound" {
+		t.Errorf("expected XMinioAdminTierNotFound, got %v", err)
+	}
+	if err = adm.VerifyTier(ctx, ""); err != ErrTierNameEmpty {
+		t.Errorf("expected %v, got %v", ErrTierNameEmpty, err)
+	}
+}
+
+func TestEditTierCreds(t *testing.T) {
+	var got TierCreds
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/tier/WARM" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminTierNotFound")
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		data, err := DecryptData("minio123", bytes.NewReader(data))
+		if err != nil || json.Unmarshal(data, &got) != nil {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	creds := TierAWSRoleCreds("arn:aws:iam::123456789012:role/tier", "/var/run/token")
+	if err := adm.EditTier(ctx, "WARM", creds); err != nil {
+		t.Fatal(err)
+	}
+	if !got.AWSRole || got.AWSRoleARN != creds.AWSRoleARN || got.AWSRoleWebIdentityTokenFile != creds.AWSRoleWebIdentityTokenFile {
+		t.Errorf("unexpected credentials %+v", got)
+	}
+
+	testCases := []TierCreds{
+		{},
+		{AccessKey: "access"},
+		{AccessKey: "access", SecretKey: "secret", GCSWorkloadIdentity: true},
+		{AWSRoleARN: "arn:aws:iam::123456789012:role/tier"},
+	}
+	for i, tc := range testCases {
+		if err := adm.EditTier(ctx, "WARM", tc); ToErrorResponse(err).Code != "InvalidArgument" {
+			t.Errorf("Test %d: expected InvalidArgument, got %v", i+1, err)
+		}
+	}
+	if err := adm.EditTier(ctx, "COLD", TierGCSWorkloadIdentityCreds()); ToErrorResponse(err).Code != "XMinioAdminTierNotFound" {
+		t.Errorf("expected XMinioAdminTierNotFound, got %v", err)
+	}
+}
+
+func TestTierStats(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/tier-stats" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		info := TierInfo{
+			Name:       "WARM",
+			Type:       "s3",
+			DailyRates: TierRates{TransitionedObjects: 10, TransitionedBytes: 1 << 20, RestoredObjects: 1},
+		}
+		info.DailyStats.Bins[0] = TierStats{NumObjects: 4}
+		info.DailyStats.Bins[23] = TierStats{NumObjects: 6}
+		json.NewEncoder(w).Encode([]TierInfo{info})
+	})
+
+	infos, err := adm.TierStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].Name != "WARM" {
+		t.Fatalf("unexpected tier stats %+v", infos)
+	}
+	if rates := infos[0].DailyRates; rates.TransitionedObjects != 10 || rates.TransitionedBytes != 1<<20 || rates.RestoredObjects != 1 {
+		t.Errorf("unexpected daily rates %+v", rates)
+	}
+	if total := infos[0].DailyStats.Total(); total.NumObjects != 10 {
+		t.Errorf("expected 10 objects in the last 24 hours, got %d", total.NumObjects)
+	}
+}
+
+func TestTierStatsError(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	if _, err := adm.TierStats(context.Background()); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %v", err)
+	}
+}
+
+func TestTransitionBacklog(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/tier-backlog" || q.Get("bucket") != "photos" || q.Get("tier") != "WARM" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		json.NewEncoder(w).Encode(TransitionBacklog{
+			UpdatedAt: now,
+			Entries: []TransitionBacklogEntry{
+				{Bucket: "photos", Tier: "WARM", Pending: 10, OldestPending: now.Add(-2 * time.Hour)},
+				{Bucket: "photos", Tier: "WARM", Pending: 1, OldestPending: now.Add(-time.Minute)},
+				{Bucket: "photos", Tier: "WARM", OldestPending: now.Add(-3 * time.Hour)},
+			},
+		})
+	})
+
+	backlog, err := adm.TransitionBacklog(context.Background(), "photos", "WARM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backlog.Entries) != 3 || !backlog.UpdatedAt.Equal(now) {
+		t.Fatalf("unexpected backlog %+v", backlog)
+	}
+	if lagging := backlog.Lagging(time.Hour); len(lagging) != 1 || lagging[0].Pending != 10 {
+		t.Errorf("expected one lagging entry, got %+v", lagging)
+	}
+
+	if _, err = adm.TransitionBacklog(context.Background(), "", ""); ToErrorResponse(err).Code != "InvalidRequest" {
+		t.Errorf("expected InvalidRequest, got %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -40,6 +41,23 @@ type LockEntry struct {
 	ID         string    `json:"id"`         // UID to uniquely identify request of client.
 	// Represents quorum number of servers required to hold this lock, used to look for stale locks.
 	Quorum int `json:"quorum"`
+	// Requests blocked behind this lock, only set when requested with TopLockOpts.Waiters.
+	Waiters []LockWaiter `json:"waiters,omitempty"`
+}
+
+// LockWaiter holds information about a request
+// waiting to acquire a lock held by another one.
+type LockWaiter struct {
+	Since  time.Time `json:"since"`  // When the request started waiting
+	Type   string    `json:"type"`   // Type indicates if 'Write' or 'Read' lock is requested
+	Source string    `json:"source"` // Source at which lock was requested
+	Owner  string    `json:"owner"`  // Owner UUID indicates server the request came from.
+	ID     string    `json:"id"`     // UID to uniquely identify request of client.
+}
+
+// Elapsed returns how long the request has been waiting for.
+func (w LockWaiter) Elapsed() time.Duration {
+	return time.Since(w.Since)
 }
 
 // LockEntries - To sort the locks
@@ -59,12 +77,23 @@ func (l LockEntries) Swap(i, j int) {
 
 // TopLockOpts top lock options
 type TopLockOpts struct {
-	Count int
-	Stale bool
+	Count   int
+	Stale   bool
+	Waiters bool // Include the requests waiting on each lock.
 }
 
-// ForceUnlock force unlocks input paths...
+// ForceUnlock force unlocks input paths, e.g. locks returned by TopLocks
+// which are stuck. Requests waiting on the paths are granted the lock next.
 func (adm *AdminClient) ForceUnlock(ctx context.Context, paths ...string) error {
+	if len(paths) == 0 {
+		return ErrInvalidArgument("no paths to unlock")
+	}
+	for _, p := range paths {
+		if err := validateLockPath(p); err != nil {
+			return err
+		}
+	}
+
 	// Execute POST on /minio/admin/v3/force-unlock
 	queryVals := make(url.Values)
 	queryVals.Set("paths", strings.Join(paths, ","))
@@ -87,6 +116,25 @@ func (adm *AdminClient) ForceUnlock(ctx context.Context, paths ...string) error
 	return nil
 }
 
+// validateLockPath - checks that p is a lock resource as reported by
+// TopLocks, i.e. a clean relative "bucket/object" path, directory objects
+// end with a single slash. Paths are sent comma separated so they must
+// not contain a comma.
+func validateLockPath(p string) error {
+	clean := strings.TrimSuffix(p, "/")
+	switch {
+	case p == "":
+		return ErrInvalidArgument("lock path cannot be empty")
+	case strings.ContainsAny(p, ",\x00"):
+		return ErrInvalidArgument("lock path " + strconv.Quote(p) + " contains invalid characters")
+	case path.IsAbs(p):
+		return ErrInvalidArgument("lock path " + strconv.Quote(p) + " must be relative")
+	case path.Clean(clean) != clean, clean == ".", clean == "..", strings.HasPrefix(clean, "../"):
+		return ErrInvalidArgument("lock path " + strconv.Quote(p) + " is not clean")
+	}
+	return nil
+}
+
 // TopLocksWithOpts - returns the count number of oldest locks currently active on the server.
 // additionally we can also enable `stale` to get stale locks currently present on server.
 func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts) (LockEntries, error) {
@@ -96,6 +144,9 @@ func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts)
 	queryVals := make(url.Values)
 	queryVals.Set("count", strconv.Itoa(opts.Count))
 	queryVals.Set("stale", strconv.FormatBool(opts.Stale))
+	if opts.Waiters {
+		queryVals.Set("waiters", "true")
+	}
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet,
 		requestData{
@@ -126,3 +177,51 @@ func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts)
 func (adm *AdminClient) TopLocks(ctx context.Context) (LockEntries, error) {
 	return adm.TopLocksWithOpts(ctx, TopLockOpts{Count: 10})
 }
+
+// TopAPIEntry holds the request statistics of a bucket
+// or object over the sliding window of the server.
+type TopAPIEntry struct {
+	Bucket   string            `json:"bucket"`
+	Object   string            `json:"object,omitempty"` // Empty for bucket level entries
+	Requests uint64            `json:"requests"`         // Requests in the window
+	Rate     float64           `json:"rate"`             // Requests per second
+	APIs     map[string]uint64 `json:"apis,omitempty"`   // Requests by API, e.g. "GetObject"
+}
+
+// TopAPIs holds the most frequently accessed buckets and objects.
+type TopAPIs struct {
+	Window  time.Duration `json:"window"` // Window the statistics were collected over
+	Buckets []TopAPIEntry `json:"buckets,omitempty"`
+	Objects []TopAPIEntry `json:"objects,omitempty"`
+}
+
+// TopAPIs - returns the 'n' most frequently accessed buckets and objects
+// over the last 'window' of the sliding window maintained by the server,
+// the full window of the server is used when window is 0.
+func (adm *AdminClient) TopAPIs(ctx context.Context, window time.Duration, n int) (TopAPIs, error) {
+	// Execute GET on /minio/admin/v3/top/apis?window=1m&count=10
+	queryVals := make(url.Values)
+	queryVals.Set("count", strconv.Itoa(n))
+	if window > 0 {
+		queryVals.Set("window", window.String())
+	}
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/top/apis",
+			queryValues: queryVals,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return TopAPIs{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return TopAPIs{}, httpRespToErrorResponse(resp)
+	}
+
+	var top TopAPIs
+	err = json.NewDecoder(resp.Body).Decode(&top)
+	return top, err
+}
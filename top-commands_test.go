@@ -0,0 +1,147 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestForceUnlockPaths(t *testing.T) {
+	var paths string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/minio/admin/v3/force-unlock" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		paths = r.URL.Query().Get("paths")
+	})
+
+	ctx := context.Background()
+	if err := adm.ForceUnlock(ctx, "photos/2021/cat.jpg", "photos/.minio.sys", "photos/2021/"); err != nil {
+		t.Fatal(err)
+	}
+	if paths != "photos/2021/cat.jpg,photos/.minio.sys,photos/2021/" {
+		t.Fatalf("unexpected paths %q", paths)
+	}
+
+	invalid := [][]string{
+		nil,
+		{""},
+		{"photos/a,b"},
+		{"photos/a\x00b"},
+		{"/photos/cat.jpg"},
+		{"photos//cat.jpg"},
+		{"photos/dir//"},
+		{"/"},
+		{"../"},
+		{"photos/../cat.jpg"},
+		{"./photos"},
+		{".."},
+		{"../photos"},
+		{"photos/ok.jpg", "photos/./cat.jpg"},
+	}
+	for _, p := range invalid {
+		paths = ""
+		if err := adm.ForceUnlock(ctx, p...); ToErrorResponse(err).Code != "InvalidArgument" {
+			t.Errorf("%q: expected InvalidArgument, got %v", p, err)
+		}
+		if paths != "" {
+			t.Errorf("%q: request must not be sent", p)
+		}
+	}
+}
+
+func TestTopLocksWaiters(t *testing.T) {
+	since := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/minio/admin/v3/top/locks" || q.Get("count") != "5" {
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+			return
+		}
+		entry := LockEntry{Resource: "photos/cat.jpg", Type: "WRITE", Owner: "node1"}
+		if q.Get("waiters") == "true" {
+			entry.Waiters = []LockWaiter{{Since: since, Type: "READ", Source: "[object-handlers.go:100:GetObject()]", ID: "req2"}}
+		}
+		json.NewEncoder(w).Encode(LockEntries{entry})
+	})
+
+	ctx := context.Background()
+	locks, err := adm.TopLocksWithOpts(ctx, TopLockOpts{Count: 5, Waiters: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || len(locks[0].Waiters) != 1 {
+		t.Fatalf("unexpected locks %+v", locks)
+	}
+	waiter := locks[0].Waiters[0]
+	if waiter.ID != "req2" || waiter.Type != "READ" || !waiter.Since.Equal(since) || waiter.Elapsed() < time.Minute {
+		t.Fatalf("unexpected waiter %+v", waiter)
+	}
+
+	if locks, err = adm.TopLocksWithOpts(ctx, TopLockOpts{Count: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks[0].Waiters != nil {
+		t.Fatalf("expected no waiters, got %+v", locks)
+	}
+}
+
+func TestTopAPIs(t *testing.T) {
+	expected := TopAPIs{
+		Window: time.Minute,
+		Buckets: []TopAPIEntry{
+			{Bucket: "photos", Requests: 600, Rate: 10, APIs: map[string]uint64{"GetObject": 550, "PutObject": 50}},
+		},
+		Objects: []TopAPIEntry{
+			{Bucket: "photos", Object: "2021/cat.jpg", Requests: 300, Rate: 5, APIs: map[string]uint64{"GetObject": 300}},
+		},
+	}
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/top/apis" || q.Get("count") != "1" {
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		switch q.Get("window") {
+		case "", "1m0s":
+			json.NewEncoder(w).Encode(expected)
+		default:
+			writeTestError(w, http.StatusBadRequest, "XMinioInvalidWindow")
+		}
+	})
+
+	for _, window := range []time.Duration{0, time.Minute} {
+		top, err := adm.TopAPIs(context.Background(), window, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(top, expected) {
+			t.Fatalf("window %v: expected %+v, got %+v", window, expected, top)
+		}
+	}
+
+	_, err := adm.TopAPIs(context.Background(), time.Hour, 1)
+	if ToErrorResponse(err).Code != "XMinioInvalidWindow" {
+		t.Fatalf("expected XMinioInvalidWindow, got %v", err)
+	}
+}
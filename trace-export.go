@@ -0,0 +1,333 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteTraceJSONLines writes each trace received from traceCh to w as
+// a single line of JSON until traceCh is closed. It returns the first
+// trace or write error.
+func WriteTraceJSONLines(w io.Writer, traceCh <-chan ServiceTraceInfo) error {
+	enc := json.NewEncoder(w)
+	for t := range traceCh {
+		if t.Err != nil {
+			return t.Err
+		}
+		if err := enc.Encode(t.Trace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OTLP span kinds and status codes, see
+// https://github.com/open-telemetry/opentelemetry-proto
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpTracesData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func otlpString(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpAttribute {
+	v := strconv.FormatInt(value, 10)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &v}}
+}
+
+func otlpID(n int) string {
+	id := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// otlpSpanFromTrace converts a trace into an OTLP span, every
+// trace is exported as the root span of its own trace.
+func otlpSpanFromTrace(t TraceInfo) otlpSpan {
+	span := otlpSpan{
+		TraceID:           otlpID(16),
+		SpanID:            otlpID(8),
+		Name:              t.FuncName,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(t.Time.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(t.Time.Add(t.Duration).UnixNano(), 10),
+		Status:            otlpStatus{Code: otlpStatusCodeOk},
+		Attributes: []otlpAttribute{
+			otlpString("minio.trace.type", t.TraceType.String()),
+			otlpString("minio.node", t.NodeName),
+		},
+	}
+	if t.Path != "" {
+		span.Attributes = append(span.Attributes, otlpString("minio.path", t.Path))
+	}
+	if t.Message != "" {
+		span.Attributes = append(span.Attributes, otlpString("minio.message", t.Message))
+	}
+	if h := t.HTTP; h != nil {
+		span.Kind = otlpSpanKindServer
+		target := h.ReqInfo.Path
+		if h.ReqInfo.RawQuery != "" {
+			target += "?" + h.ReqInfo.RawQuery
+		}
+		span.Attributes = append(span.Attributes,
+			otlpString("http.method", h.ReqInfo.Method),
+			otlpString("http.target", target),
+			otlpString("http.flavor", strings.TrimPrefix(h.ReqInfo.Proto, "HTTP/")),
+			otlpString("net.peer.ip", h.ReqInfo.Client),
+			otlpInt("http.status_code", int64(h.RespInfo.StatusCode)),
+			otlpInt("http.request_content_length", int64(h.CallStats.InputBytes)),
+			otlpInt("http.response_content_length", int64(h.CallStats.OutputBytes)),
+		)
+		if h.RespInfo.StatusCode >= http.StatusInternalServerError {
+			span.Status = otlpStatus{Code: otlpStatusCodeError, Message: http.StatusText(h.RespInfo.StatusCode)}
+		}
+	}
+	if t.Error != "" {
+		span.Status = otlpStatus{Code: otlpStatusCodeError, Message: t.Error}
+	}
+	return span
+}
+
+// EncodeTracesOTLP encodes traces as an OTLP/JSON export request of the
+// service serviceName, as accepted by the /v1/traces endpoint of OTLP
+// HTTP receivers.
+func EncodeTracesOTLP(serviceName string, traces []TraceInfo) ([]byte, error) {
+	var scope otlpScopeSpans
+	scope.Scope.Name = "github.com/minio/madmin-go"
+	scope.Spans = make([]otlpSpan, 0, len(traces))
+	for _, t := range traces {
+		scope.Spans = append(scope.Spans, otlpSpanFromTrace(t))
+	}
+
+	var resource otlpResourceSpans
+	resource.Resource.Attributes = []otlpAttribute{otlpString("service.name", serviceName)}
+	resource.ScopeSpans = []otlpScopeSpans{scope}
+
+	return json.Marshal(otlpTracesData{ResourceSpans: []otlpResourceSpans{resource}})
+}
+
+// OTLPTraceExporter exports traces as spans to an OTLP HTTP endpoint
+// such as an OpenTelemetry collector, Jaeger or Tempo.
+type OTLPTraceExporter struct {
+	// Endpoint is the URL of the OTLP receiver traces are posted
+	// to, e.g. http://localhost:4318/v1/traces
+	Endpoint string
+	// ServiceName is reported as the service.name of the spans,
+	// defaults to "minio".
+	ServiceName string
+	// Headers are added to every export request.
+	Headers http.Header
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+
+	// BatchSize is the maximum number of spans per export
+	// request of ExportStream, defaults to 512.
+	BatchSize int
+	// FlushInterval is the maximum time ExportStream holds
+	// spans before exporting them, defaults to 5 seconds.
+	FlushInterval time.Duration
+	// RetryPolicy configures how ExportStream retries failed
+	// export requests, unset fields are filled in from
+	// DefaultRetryPolicy. Jitter is not supported.
+	RetryPolicy RetryPolicy
+}
+
+// Export sends traces to the OTLP endpoint in a single request.
+func (e *OTLPTraceExporter) Export(ctx context.Context, traces []TraceInfo) error {
+	if len(traces) == 0 {
+		return nil
+	}
+	serviceName := e.ServiceName
+	if serviceName == "" {
+		serviceName = "minio"
+	}
+	data, err := EncodeTracesOTLP(serviceName, traces)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.Headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("otlp export failed: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// ExportStream exports the traces received from traceCh in batches
+// until traceCh is closed, a trace error is received or ctx is canceled,
+// exporting the pending traces before returning. Failed exports are
+// retried with exponential backoff, batches still failing are dropped
+// without stopping the stream. It returns the trace error or ctx error,
+// or otherwise an error counting the dropped traces if any.
+func (e *OTLPTraceExporter) ExportStream(ctx context.Context, traceCh <-chan ServiceTraceInfo) error {
+	batchSize := e.BatchSize
+	if batchSize <= 0 {
+		batchSize = 512
+	}
+	interval := e.FlushInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	policy := e.RetryPolicy.withDefaults()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		dropped int
+		lastErr error
+	)
+	batch := make([]TraceInfo, 0, batchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.exportWithRetry(ctx, policy, batch); err != nil {
+			dropped += len(batch)
+			lastErr = err
+		}
+		batch = batch[:0]
+	}
+	// finish exports the pending traces, even if ctx is canceled.
+	finish := func(err error) error {
+		flushCtx, cancel := context.WithTimeout(context.Background(), interval)
+		defer cancel()
+		flush(flushCtx)
+		if err == nil && dropped > 0 {
+			err = fmt.Errorf("madmin: dropped %d traces: %w", dropped, lastErr)
+		}
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(ctx.Err())
+		case <-ticker.C:
+			flush(ctx)
+		case t, ok := <-traceCh:
+			if !ok {
+				return finish(nil)
+			}
+			if t.Err != nil {
+				return finish(t.Err)
+			}
+			batch = append(batch, t.Trace)
+			if len(batch) >= batchSize {
+				flush(ctx)
+			}
+		}
+	}
+}
+
+// exportWithRetry exports traces, retrying failed exports
+// with exponential backoff according to policy.
+func (e *OTLPTraceExporter) exportWithRetry(ctx context.Context, policy RetryPolicy, traces []TraceInfo) (err error) {
+	for attempt := 0; attempt < policy.MaxRetry; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(exponentialBackoffWait(nil, attempt-1, policy.Unit, policy.Cap, NoJitter)):
+			}
+		}
+		if err = e.Export(ctx, traces); err == nil {
+			return nil
+		}
+	}
+	return err
+}
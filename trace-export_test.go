@@ -0,0 +1,158 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteTraceJSONLines(t *testing.T) {
+	traceCh := make(chan ServiceTraceInfo, 2)
+	traceCh <- ServiceTraceInfo{Trace: TraceInfo{TraceType: TraceS3, FuncName: "s3.GetObject"}}
+	traceCh <- ServiceTraceInfo{Trace: TraceInfo{TraceType: TraceOS, FuncName: "os.Stat"}}
+	close(traceCh)
+
+	var buf bytes.Buffer
+	if err := WriteTraceJSONLines(&buf, traceCh); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	var trace TraceInfo
+	if err := json.Unmarshal([]byte(lines[1]), &trace); err != nil || trace.FuncName != "os.Stat" {
+		t.Fatalf("unexpected second line %q: %v", lines[1], err)
+	}
+}
+
+func TestOTLPTraceExporter(t *testing.T) {
+	var batches []otlpTracesData
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data otlpTracesData
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, data)
+	}))
+	defer srv.Close()
+
+	traceCh := make(chan ServiceTraceInfo, 3)
+	for i := 0; i < 3; i++ {
+		traceCh <- ServiceTraceInfo{Trace: TraceInfo{
+			TraceType: TraceS3,
+			FuncName:  "s3.PutObject",
+			Time:      time.Unix(1, 0),
+			Duration:  time.Second,
+			Error:     "failed",
+		}}
+	}
+	close(traceCh)
+
+	exporter := OTLPTraceExporter{Endpoint: srv.URL, BatchSize: 2}
+	if err := exporter.ExportStream(context.Background(), traceCh); err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 export requests, got %d", len(batches))
+	}
+	span := batches[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.Name != "s3.PutObject" || span.EndTimeUnixNano != "2000000000" || span.Status.Code != otlpStatusCodeError {
+		t.Errorf("unexpected span %+v", span)
+	}
+	if len(span.TraceID) != 32 || len(span.SpanID) != 16 {
+		t.Errorf("unexpected span IDs %q %q", span.TraceID, span.SpanID)
+	}
+}
+
+func TestOTLPTraceExporterRetry(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		spans    int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		// Fail every other request.
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var data otlpTracesData
+		json.NewDecoder(r.Body).Decode(&data)
+		spans += len(data.ResourceSpans[0].ScopeSpans[0].Spans)
+	}))
+	defer srv.Close()
+
+	// A trace error stops the stream after the pending traces are exported.
+	traceCh := make(chan ServiceTraceInfo, 4)
+	for i := 0; i < 3; i++ {
+		traceCh <- ServiceTraceInfo{Trace: TraceInfo{TraceType: TraceS3, FuncName: "s3.GetObject"}}
+	}
+	traceErr := errors.New("trace stream failed")
+	traceCh <- ServiceTraceInfo{Err: traceErr}
+
+	exporter := OTLPTraceExporter{
+		Endpoint:    srv.URL,
+		BatchSize:   2,
+		RetryPolicy: RetryPolicy{MaxRetry: 2, Unit: time.Millisecond},
+	}
+	if err := exporter.ExportStream(context.Background(), traceCh); err != traceErr {
+		t.Fatalf("expected the trace error, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if spans != 3 || requests != 4 {
+		t.Fatalf("expected 3 spans in 4 requests, got %d spans in %d requests", spans, requests)
+	}
+}
+
+func TestOTLPTraceExporterDrop(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	traceCh := make(chan ServiceTraceInfo, 3)
+	for i := 0; i < 3; i++ {
+		traceCh <- ServiceTraceInfo{Trace: TraceInfo{TraceType: TraceS3, FuncName: "s3.GetObject"}}
+	}
+	close(traceCh)
+
+	// Failing batches are dropped without stopping the stream.
+	exporter := OTLPTraceExporter{
+		Endpoint:    srv.URL,
+		BatchSize:   1,
+		RetryPolicy: RetryPolicy{MaxRetry: 2, Unit: time.Millisecond},
+	}
+	err := exporter.ExportStream(context.Background(), traceCh)
+	if err == nil || !strings.Contains(err.Error(), "dropped 3 traces") {
+		t.Fatalf("expected dropped traces error, got %v", err)
+	}
+}
@@ -0,0 +1,360 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultTraceBufferSize        = 10000
+	defaultTraceHeartbeatInterval = 10 * time.Second
+)
+
+// ServiceTraceStreamOpts holds the options for streaming
+// trace notifications over a websocket.
+type ServiceTraceStreamOpts struct {
+	ServiceTraceOpts
+
+	// BufferSize is the number of trace notifications buffered
+	// on the client when the caller is not reading fast enough.
+	// Defaults to 10000.
+	BufferSize int
+
+	// HeartbeatInterval is the interval at which the server sends
+	// heartbeats. The connection is re-established when nothing was
+	// received for twice this interval. Defaults to 10 seconds.
+	HeartbeatInterval time.Duration
+}
+
+// traceStreamMessage is a single message sent by the server
+// on the trace websocket, heartbeats do not carry a trace.
+type traceStreamMessage struct {
+	Seq       uint64     `json:"seq"`
+	Heartbeat bool       `json:"heartbeat,omitempty"`
+	Trace     *TraceInfo `json:"trace,omitempty"`
+}
+
+// ServiceTraceStream - listen on http trace notifications over a websocket.
+// Unlike ServiceTrace the connection is automatically re-established when
+// it is lost, resuming right after the last received trace notification.
+// An error is only sent on the returned channel when the connection cannot
+// be re-established according to the retry policy of the client.
+func (adm AdminClient) ServiceTraceStream(ctx context.Context, opts ServiceTraceStreamOpts) <-chan ServiceTraceInfo {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultTraceBufferSize
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = defaultTraceHeartbeatInterval
+	}
+
+	traceInfoCh := make(chan ServiceTraceInfo, opts.BufferSize)
+	go func(traceInfoCh chan<- ServiceTraceInfo) {
+		defer close(traceInfoCh)
+
+		policy := adm.getRetryPolicy()
+
+		var (
+			lastSeq    uint64
+			reconnects int
+		)
+		for {
+			ws, err := adm.connectTraceStream(ctx, opts, lastSeq)
+			if err != nil {
+				if ctx.Err() == nil {
+					select {
+					case <-ctx.Done():
+					case traceInfoCh <- ServiceTraceInfo{Err: err}:
+					}
+				}
+				return
+			}
+
+			connected := time.Now()
+			lastSeq = readTraceStream(ctx, ws, opts.HeartbeatInterval, lastSeq, traceInfoCh)
+			ws.Close()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Connection lost, reconnect. Back off exponentially when
+			// connections keep dropping, e.g. while a node restarts.
+			if time.Since(connected) > 2*opts.HeartbeatInterval {
+				reconnects = 0
+			}
+			if reconnects < policy.MaxRetry {
+				reconnects++
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(exponentialBackoffWait(adm.random, reconnects-1, policy.Unit, policy.Cap, policy.Jitter)):
+			}
+		}
+	}(traceInfoCh)
+
+	// Returns the trace info channel, for caller to start reading from.
+	return traceInfoCh
+}
+
+// connectTraceStream - connects the trace websocket, retrying
+// in a binomially delayed manner upon any error.
+func (adm AdminClient) connectTraceStream(ctx context.Context, opts ServiceTraceStreamOpts, lastSeq uint64) (ws *websocket.Conn, err error) {
+	policy := adm.getRetryPolicy()
+
+	// Create cancel context to control 'newRetryTimer' go routine.
+	retryCtx, cancel := context.WithCancel(ctx)
+
+	// Indicate to our routine to exit cleanly upon return.
+	defer cancel()
+
+	for range adm.newRetryTimer(retryCtx, policy.MaxRetry, policy.Unit, policy.Cap, policy.Jitter) {
+		ws, err = adm.dialTraceStream(ctx, opts, lastSeq)
+		if err == nil {
+			return ws, nil
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return nil, err
+}
+
+// dialTraceStream - dials the server and performs the websocket
+// handshake using a signed request.
+func (adm AdminClient) dialTraceStream(ctx context.Context, opts ServiceTraceStreamOpts, lastSeq uint64) (*websocket.Conn, error) {
+	urlValues := make(url.Values)
+	opts.AddParams(urlValues)
+	urlValues.Set("heartbeat", opts.HeartbeatInterval.String())
+	if lastSeq > 0 {
+		urlValues.Set("after", strconv.FormatUint(lastSeq, 10))
+	}
+
+	req, err := adm.newRequest(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/trace/ws",
+		queryValues: urlValues,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	location := *req.URL
+	location.Scheme = "ws"
+	port := "80"
+	if adm.secure {
+		location.Scheme = "wss"
+		port = "443"
+	}
+	if location.Port() != "" {
+		port = location.Port()
+	}
+
+	config := &websocket.Config{
+		Location: &location,
+		Origin:   adm.endpointURL,
+		Version:  websocket.ProtocolVersionHybi13,
+		Header:   req.Header,
+	}
+
+	conn, err := adm.dialTraceConn(ctx, net.JoinHostPort(location.Hostname(), port), location.Hostname(), 2*opts.HeartbeatInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+// dialTraceConn - dials addr honoring the dialer, proxy and TLS settings,
+// e.g. root CAs and client certificates, of the client transport. The
+// returned connection has a deadline of timeout for the handshakes.
+func (adm AdminClient) dialTraceConn(ctx context.Context, addr, serverName string, timeout time.Duration) (net.Conn, error) {
+	dial := (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 15 * time.Second,
+	}).DialContext
+
+	var (
+		proxyURL  *url.URL
+		tlsConfig *tls.Config
+	)
+	if tr, ok := adm.transport.(*http.Transport); ok {
+		if tr.DialContext != nil {
+			dial = tr.DialContext
+		}
+		if tr.TLSClientConfig != nil {
+			tlsConfig = tr.TLSClientConfig.Clone()
+		}
+		if tr.Proxy != nil {
+			// Proxies are selected for the http(s) endpoint URL.
+			endpoint := *adm.endpointURL
+			var err error
+			proxyURL, err = tr.Proxy(&http.Request{Method: http.MethodGet, URL: &endpoint, Header: make(http.Header)})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	dialAddr := addr
+	if proxyURL != nil {
+		dialAddr = proxyURL.Host
+		if proxyURL.Port() == "" {
+			port := "80"
+			if proxyURL.Scheme == "https" {
+				port = "443"
+			}
+			dialAddr = net.JoinHostPort(proxyURL.Hostname(), port)
+		}
+	}
+
+	conn, err := dial(ctx, "tcp", dialAddr)
+	if err != nil {
+		return nil, err
+	}
+	// Do not wait forever on the proxy, TLS and websocket handshakes.
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if proxyURL != nil {
+		if conn, err = proxyConnect(ctx, conn, proxyURL, addr); err != nil {
+			return nil, err
+		}
+	}
+
+	if adm.secure {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = serverName
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+	return conn, nil
+}
+
+// proxyConnect - establishes a tunnel to addr through the
+// HTTP(S) proxy connected on conn, closing conn on failure.
+func proxyConnect(ctx context.Context, conn net.Conn, proxyURL *url.URL, addr string) (net.Conn, error) {
+	switch proxyURL.Scheme {
+	case "http":
+	case "https":
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: proxyURL.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("madmin: unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// The server does not send anything before the
+	// client, nothing is lost by buffering the reader.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("madmin: proxy refused connection to %s: %s", addr, resp.Status)
+	}
+	return conn, nil
+}
+
+// readTraceStream - reads trace notifications from the websocket until
+// the connection is lost or ctx is canceled. It returns the sequence
+// number of the last trace notification sent on traceInfoCh.
+func readTraceStream(ctx context.Context, ws *websocket.Conn, heartbeat time.Duration, lastSeq uint64, traceInfoCh chan<- ServiceTraceInfo) uint64 {
+	// Unblock the reader when ctx is canceled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		ws.SetReadDeadline(time.Now().Add(2 * heartbeat))
+
+		var msg traceStreamMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return lastSeq
+		}
+		if msg.Heartbeat || msg.Trace == nil {
+			continue
+		}
+		// Skip notifications already sent before reconnecting.
+		if lastSeq > 0 && msg.Seq <= lastSeq {
+			continue
+		}
+		lastSeq = msg.Seq
+
+		select {
+		case <-ctx.Done():
+			return lastSeq
+		case traceInfoCh <- ServiceTraceInfo{Trace: *msg.Trace}:
+		}
+	}
+}
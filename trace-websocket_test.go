@@ -0,0 +1,159 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"golang.org/x/net/websocket"
+)
+
+// newTraceStreamServer returns a test server sending count trace
+// notifications per connection, numbered after the "after" query
+// value, before dropping the connection.
+func newTraceStreamServer(t *testing.T, count int, connected func(r *http.Request)) *httptest.Server {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		r := ws.Request()
+		connected(r)
+		if r.URL.Path != "/minio/admin/v3/trace/ws" {
+			return
+		}
+		after, _ := strconv.ParseUint(r.URL.Query().Get("after"), 10, 64)
+		for i := uint64(1); i <= uint64(count); i++ {
+			websocket.JSON.Send(ws, traceStreamMessage{Seq: after + i, Trace: &TraceInfo{FuncName: "f"}})
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTraceStreamClient(t *testing.T, endpoint string, unit time.Duration) *AdminClient {
+	adm, err := NewWithOptions(strings.TrimPrefix(endpoint, "http://"), &Options{
+		Creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		RetryPolicy: &RetryPolicy{MaxRetry: 3, Unit: unit},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return adm
+}
+
+func TestServiceTraceStreamReconnect(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		conns    []time.Time
+		afterArg []string
+	)
+	srv := newTraceStreamServer(t, 2, func(r *http.Request) {
+		mu.Lock()
+		conns = append(conns, time.Now())
+		afterArg = append(afterArg, r.URL.Query().Get("after"))
+		mu.Unlock()
+	})
+
+	const unit = 100 * time.Millisecond
+	adm := newTraceStreamClient(t, srv.URL, unit)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	traceCh := adm.ServiceTraceStream(ctx, ServiceTraceStreamOpts{HeartbeatInterval: time.Second})
+	for i := 0; i < 4; i++ {
+		select {
+		case info := <-traceCh:
+			if info.Err != nil {
+				t.Fatal(info.Err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for trace %d", i+1)
+		}
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(conns) < 2 {
+		t.Fatalf("expected a reconnect, got %d connections", len(conns))
+	}
+	if afterArg[0] != "" || afterArg[1] != "2" {
+		t.Errorf("expected to resume after the last trace, got %q", afterArg)
+	}
+	// The reconnect is delayed by the retry unit, jitter is disabled.
+	if d := conns[1].Sub(conns[0]); d < unit {
+		t.Errorf("reconnected after %v, expected a backoff of at least %v", d, unit)
+	}
+}
+
+func TestServiceTraceStreamProxy(t *testing.T) {
+	srv := newTraceStreamServer(t, 1, func(*http.Request) {})
+
+	var tunnels int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		atomic.AddInt32(&tunnels, 1)
+		backend, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer backend.Close()
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		go io.Copy(backend, rw)
+		io.Copy(conn, backend)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm := newTraceStreamClient(t, srv.URL, time.Millisecond)
+	adm.SetCustomTransport(&http.Transport{Proxy: http.ProxyURL(proxyURL)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	select {
+	case info := <-adm.ServiceTraceStream(ctx, ServiceTraceStreamOpts{HeartbeatInterval: time.Second}):
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a trace")
+	}
+	if atomic.LoadInt32(&tunnels) == 0 {
+		t.Fatal("expected the connection to go through the proxy")
+	}
+}
@@ -23,22 +23,88 @@ import (
 	"time"
 )
 
-// DefaultTransport - this default transport is similar to
-// http.DefaultTransport but with additional param  DisableCompression
-// is set to true to avoid decompressing content with 'gzip' encoding.
-var DefaultTransport = func(secure bool) http.RoundTripper {
+// TransportOptions tunes the HTTP transport of the admin client,
+// unset fields are filled in from DefaultTransportOptions.
+type TransportOptions struct {
+	// DialTimeout is the maximum time to establish a connection.
+	DialTimeout time.Duration
+	// DialKeepAlive is the interval of TCP keep-alive probes.
+	DialKeepAlive time.Duration
+	// MaxIdleConns is the maximum number of idle connections in total.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections
+	// kept per host.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept.
+	IdleConnTimeout time.Duration
+	// ResponseHeaderTimeout is the maximum time to wait for the
+	// response headers after the request is written.
+	ResponseHeaderTimeout time.Duration
+	// TLSHandshakeTimeout is the maximum time for the TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// EnableHTTP2 negotiates HTTP/2 on TLS connections, the
+	// default is HTTP/1.1 only.
+	EnableHTTP2 bool
+}
+
+// DefaultTransportOptions returns the transport options
+// used by DefaultTransport.
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		DialTimeout:           5 * time.Second,
+		DialKeepAlive:         15 * time.Second,
+		MaxIdleConns:          1024,
+		MaxIdleConnsPerHost:   1024,
+		IdleConnTimeout:       60 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+	}
+}
+
+// withDefaults returns a copy of the options where all
+// unset fields are filled in from DefaultTransportOptions.
+func (o TransportOptions) withDefaults() TransportOptions {
+	def := DefaultTransportOptions()
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = def.DialTimeout
+	}
+	if o.DialKeepAlive <= 0 {
+		o.DialKeepAlive = def.DialKeepAlive
+	}
+	if o.MaxIdleConns <= 0 {
+		o.MaxIdleConns = def.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost <= 0 {
+		o.MaxIdleConnsPerHost = def.MaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout <= 0 {
+		o.IdleConnTimeout = def.IdleConnTimeout
+	}
+	if o.ResponseHeaderTimeout <= 0 {
+		o.ResponseHeaderTimeout = def.ResponseHeaderTimeout
+	}
+	if o.TLSHandshakeTimeout <= 0 {
+		o.TLSHandshakeTimeout = def.TLSHandshakeTimeout
+	}
+	return o
+}
+
+// NewTransport - returns a transport built from the given options,
+// unset fields are filled in from DefaultTransportOptions.
+func NewTransport(secure bool, opts TransportOptions) *http.Transport {
+	opts = opts.withDefaults()
 	tr := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
-			Timeout:       5 * time.Second,
-			KeepAlive:     15 * time.Second,
+			Timeout:       opts.DialTimeout,
+			KeepAlive:     opts.DialKeepAlive,
 			FallbackDelay: 100 * time.Millisecond,
 		}).DialContext,
-		MaxIdleConns:          1024,
-		MaxIdleConnsPerHost:   1024,
-		ResponseHeaderTimeout: 60 * time.Second,
-		IdleConnTimeout:       60 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
 		ExpectContinueTimeout: 1 * time.Second,
 		// Set this value so that the underlying transport round-tripper
 		// doesn't try to auto decode the body of objects with
@@ -47,6 +113,12 @@ var DefaultTransport = func(secure bool) http.RoundTripper {
 		// Refer:
 		//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
 		DisableCompression: true,
+		ForceAttemptHTTP2:  opts.EnableHTTP2,
+	}
+
+	if !opts.EnableHTTP2 {
+		// A non-nil empty map disables HTTP/2 negotiation.
+		tr.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
 	}
 
 	if secure {
@@ -59,3 +131,10 @@ var DefaultTransport = func(secure bool) http.RoundTripper {
 	}
 	return tr
 }
+
+// DefaultTransport - this default transport is similar to
+// http.DefaultTransport but with additional param  DisableCompression
+// is set to true to avoid decompressing content with 'gzip' encoding.
+var DefaultTransport = func(secure bool) http.RoundTripper {
+	return NewTransport(secure, DefaultTransportOptions())
+}
@@ -0,0 +1,44 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr := NewTransport(true, TransportOptions{
+		MaxIdleConnsPerHost: 16,
+		TLSHandshakeTimeout: time.Second,
+	})
+	if tr.MaxIdleConnsPerHost != 16 || tr.TLSHandshakeTimeout != time.Second {
+		t.Errorf("options not applied: %d, %s", tr.MaxIdleConnsPerHost, tr.TLSHandshakeTimeout)
+	}
+	def := DefaultTransportOptions()
+	if tr.MaxIdleConns != def.MaxIdleConns || tr.IdleConnTimeout != def.IdleConnTimeout {
+		t.Errorf("defaults not applied: %d, %s", tr.MaxIdleConns, tr.IdleConnTimeout)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Error("HTTP/2 should be disabled by default")
+	}
+
+	tr = NewTransport(true, TransportOptions{EnableHTTP2: true})
+	if !tr.ForceAttemptHTTP2 || tr.TLSNextProto != nil {
+		t.Error("HTTP/2 should be enabled")
+	}
+}
@@ -19,8 +19,12 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // ServerUpdateStatus - contains the response of service update API
@@ -57,3 +61,130 @@ func (adm *AdminClient) ServerUpdate(ctx context.Context, updateURL string) (us
 
 	return us, nil
 }
+
+// ServerUpdateOpts - options of a staged server update
+type ServerUpdateOpts struct {
+	UpdateURL string // Custom update binary link, optional
+	// BatchSize is the number of nodes updated and restarted at once,
+	// defaults to 1.
+	BatchSize int
+	// RejoinTimeout is how long to wait for the nodes of a batch to come
+	// back online before the update is aborted, defaults to 5 minutes.
+	RejoinTimeout time.Duration
+	// WaitForHeal waits for the heal backlog to drain after every batch
+	// before the next batch is updated.
+	WaitForHeal bool
+}
+
+// ServerUpdateNodeState - state of a node during a staged update
+type ServerUpdateNodeState string
+
+// Node states reported by ServerUpdateStaged
+const (
+	ServerUpdatePending    ServerUpdateNodeState = "pending"
+	ServerUpdateUpdating   ServerUpdateNodeState = "updating"
+	ServerUpdateRestarting ServerUpdateNodeState = "restarting"
+	ServerUpdateHealing    ServerUpdateNodeState = "healing"
+	ServerUpdateDone       ServerUpdateNodeState = "done"
+	ServerUpdateFailed     ServerUpdateNodeState = "failed"
+)
+
+// ServerUpdateProgress - progress of a node during a staged update
+type ServerUpdateProgress struct {
+	Node           string                `json:"node"`
+	Batch          int                   `json:"batch"`
+	State          ServerUpdateNodeState `json:"state"`
+	CurrentVersion string                `json:"currentVersion,omitempty"`
+	UpdatedVersion string                `json:"updatedVersion,omitempty"`
+	Time           time.Time             `json:"time"`
+	Error          string                `json:"error,omitempty"`
+}
+
+// ServerUpdateProgressResult - contains the progress of a node or an error
+type ServerUpdateProgressResult struct {
+	Progress ServerUpdateProgress
+	Err      error
+}
+
+// errStagedUpdateUnsupported is returned when the server answers
+// a staged update without reporting the progress of nodes.
+var errStagedUpdateUnsupported = errors.New("madmin: server does not support staged updates")
+
+// ServerUpdateStaged - performs a rolling update of the MinIO cluster, nodes
+// are updated and restarted in batches, waiting for every batch to rejoin and
+// optionally for the heal backlog to drain before continuing. The progress of
+// every node is reported on the returned channel, which is closed once the
+// update completes or fails.
+//
+// Staged updates use a dedicated endpoint so that servers not supporting
+// them reject the request instead of restarting all nodes at once.
+func (adm *AdminClient) ServerUpdateStaged(ctx context.Context, opts ServerUpdateOpts) <-chan ServerUpdateProgressResult {
+	progressCh := make(chan ServerUpdateProgressResult)
+
+	go func(progressCh chan<- ServerUpdateProgressResult) {
+		defer close(progressCh)
+
+		sendErr := func(err error) {
+			select {
+			case <-ctx.Done():
+			case progressCh <- ServerUpdateProgressResult{Err: err}:
+			}
+		}
+
+		if opts.BatchSize < 0 || opts.RejoinTimeout < 0 {
+			sendErr(ErrInvalidArgument("batch size and rejoin timeout must not be negative"))
+			return
+		}
+
+		queryValues := url.Values{}
+		queryValues.Set("updateURL", opts.UpdateURL)
+		if opts.BatchSize > 0 {
+			queryValues.Set("batchSize", strconv.Itoa(opts.BatchSize))
+		}
+		if opts.RejoinTimeout > 0 {
+			queryValues.Set("rejoinTimeout", opts.RejoinTimeout.String())
+		}
+		if opts.WaitForHeal {
+			queryValues.Set("waitForHeal", "true")
+		}
+
+		resp, err := adm.executeMethod(ctx,
+			http.MethodPost, requestData{
+				relPath:     adminAPIPrefix + "/update-staged",
+				queryValues: queryValues,
+				category:    RequestCategoryStreaming,
+			},
+		)
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			sendErr(httpRespToErrorResponse(resp))
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var progress ServerUpdateProgress
+			if err = dec.Decode(&progress); err == nil && (progress.Node == "" || progress.State == "") {
+				err = errStagedUpdateUnsupported
+			}
+			if err != nil {
+				if err != io.EOF {
+					sendErr(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case progressCh <- ServerUpdateProgressResult{Progress: progress}:
+			}
+		}
+	}(progressCh)
+
+	return progressCh
+}
@@ -0,0 +1,63 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestServerUpdateStaged(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/update-staged" || r.URL.Query().Get("batchSize") != "2" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(ServerUpdateProgress{Node: "node1", Batch: 1, State: ServerUpdateDone})
+		enc.Encode(ServerUpdateProgress{Node: "node2", Batch: 1, State: ServerUpdateDone})
+	})
+
+	var n int
+	for res := range adm.ServerUpdateStaged(context.Background(), ServerUpdateOpts{BatchSize: 2}) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("expected 2 progress events, got %d", n)
+	}
+}
+
+func TestServerUpdateStagedUnsupported(t *testing.T) {
+	// A server answering with the status of a regular update.
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ServerUpdateStatus{CurrentVersion: "v1", UpdatedVersion: "v2"})
+	})
+
+	var err error
+	for res := range adm.ServerUpdateStaged(context.Background(), ServerUpdateOpts{}) {
+		err = res.Err
+	}
+	if !errors.Is(err, errStagedUpdateUnsupported) {
+		t.Fatalf("expected unsupported staged update, got %v", err)
+	}
+}
@@ -18,10 +18,16 @@ package madmin
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/tags"
@@ -125,6 +131,16 @@ type UserInfo struct {
 	Status     AccountStatus `json:"status"`
 	MemberOf   []string      `json:"memberOf,omitempty"`
 	UpdatedAt  time.Time     `json:"updatedAt,omitempty"`
+
+	CredentialMetadata
+}
+
+// CredentialMetadata describes the owner and purpose of a user or
+// service account, it is stored on the server and returned in listings.
+type CredentialMetadata struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
 }
 
 // RemoveUser - remove a user.
@@ -183,6 +199,131 @@ func (adm *AdminClient) ListUsers(ctx context.Context) (map[string]UserInfo, err
 	return users, nil
 }
 
+// ListUsersPage is a single page of users returned by ListUsersPaginated.
+type ListUsersPage struct {
+	Users map[string]UserInfo `json:"users"`
+
+	// IsTruncated is set when more users are available,
+	// NextMarker must be used to fetch the next page.
+	IsTruncated bool   `json:"isTruncated"`
+	NextMarker  string `json:"nextMarker,omitempty"`
+}
+
+// ListUsersPaginated - list at most maxItems users, in lexical order,
+// starting after marker. An empty marker starts from the beginning.
+// Servers not supporting pagination return all users in a single page.
+func (adm *AdminClient) ListUsersPaginated(ctx context.Context, marker string, maxItems int) (ListUsersPage, error) {
+	queryValues := url.Values{}
+	queryValues.Set("paginated", "true")
+	if marker != "" {
+		queryValues.Set("marker", marker)
+	}
+	if maxItems > 0 {
+		queryValues.Set("max-items", strconv.Itoa(maxItems))
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-users",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/list-users
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return ListUsersPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ListUsersPage{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return ListUsersPage{}, err
+	}
+
+	if !isPaginatedResponse(data) {
+		// Older servers ignore the marker and return all users.
+		users := make(map[string]UserInfo)
+		if err = json.Unmarshal(data, &users); err != nil {
+			return ListUsersPage{}, err
+		}
+		return ListUsersPage{Users: users}, nil
+	}
+
+	var page ListUsersPage
+	if err = json.Unmarshal(data, &page); err != nil {
+		return ListUsersPage{}, err
+	}
+	return page, nil
+}
+
+// isPaginatedResponse returns true if data is a JSON object
+// holding the isTruncated field of a paginated listing.
+func isPaginatedResponse(data []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["isTruncated"]
+	return ok
+}
+
+// UserListResult holds a single user returned by ListUsersIter,
+// or the error encountered while listing.
+type UserListResult struct {
+	AccessKey string
+	Info      UserInfo
+	Err       error
+}
+
+// ListUsersIter - lists all users, transparently fetching pages of
+// maxItems users using ListUsersPaginated. Users are sent on the
+// returned channel in lexical order, the channel is closed once all
+// users are listed, an error is encountered or ctx is canceled.
+func (adm *AdminClient) ListUsersIter(ctx context.Context, maxItems int) <-chan UserListResult {
+	userCh := make(chan UserListResult)
+
+	go func(userCh chan<- UserListResult) {
+		defer close(userCh)
+
+		var marker string
+		for {
+			page, err := adm.ListUsersPaginated(ctx, marker, maxItems)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+				case userCh <- UserListResult{Err: err}:
+				}
+				return
+			}
+
+			accessKeys := make([]string, 0, len(page.Users))
+			for accessKey := range page.Users {
+				accessKeys = append(accessKeys, accessKey)
+			}
+			sort.Strings(accessKeys)
+
+			for _, accessKey := range accessKeys {
+				select {
+				case <-ctx.Done():
+					return
+				case userCh <- UserListResult{AccessKey: accessKey, Info: page.Users[accessKey]}:
+				}
+			}
+
+			if !page.IsTruncated || page.NextMarker == "" {
+				return
+			}
+			marker = page.NextMarker
+		}
+	}(userCh)
+
+	return userCh
+}
+
 // GetUserInfo - get info on a user
 func (adm *AdminClient) GetUserInfo(ctx context.Context, name string) (u UserInfo, err error) {
 	queryValues := url.Values{}
@@ -222,29 +363,39 @@ func (adm *AdminClient) GetUserInfo(ctx context.Context, name string) (u UserInf
 type AddOrUpdateUserReq struct {
 	SecretKey string        `json:"secretKey,omitempty"`
 	Status    AccountStatus `json:"status"`
+
+	CredentialMetadata
 }
 
 // SetUser - update user secret key or account status.
 func (adm *AdminClient) SetUser(ctx context.Context, accessKey, secretKey string, status AccountStatus) error {
-	data, err := json.Marshal(AddOrUpdateUserReq{
+	return adm.SetUserWithOpts(ctx, accessKey, AddOrUpdateUserReq{
 		SecretKey: secretKey,
 		Status:    status,
 	})
+}
+
+// SetUserWithOpts - adds or updates a user, including its metadata.
+func (adm *AdminClient) SetUserWithOpts(ctx context.Context, accessKey string, req AddOrUpdateUserReq) error {
+	_, err := adm.setUser(ctx, accessKey, req, "")
+	return err
+}
+
+func (adm *AdminClient) setUser(ctx context.Context, accessKey string, req AddOrUpdateUserReq, idempotencyKey string) (replayed bool, err error) {
+	data, err := json.Marshal(req)
 	if err != nil {
-		return err
-	}
-	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return err
+		return false, err
 	}
 
 	queryValues := url.Values{}
 	queryValues.Set("accessKey", accessKey)
 
 	reqData := requestData{
-		relPath:     adminAPIPrefix + "/add-user",
-		queryValues: queryValues,
-		content:     econfigBytes,
+		relPath:        adminAPIPrefix + "/add-user",
+		queryValues:    queryValues,
+		content:        data,
+		encrypt:        true,
+		idempotencyKey: idempotencyKey,
 	}
 
 	// Execute PUT on /minio/admin/v3/add-user to set a user.
@@ -252,19 +403,36 @@ func (adm *AdminClient) SetUser(ctx context.Context, accessKey, secretKey string
 
 	defer closeResponse(resp)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return httpRespToErrorResponse(resp)
+		return false, httpRespToErrorResponse(resp)
 	}
 
-	return nil
+	return isIdempotencyReplay(resp), nil
 }
 
 // AddUser - adds a user.
 func (adm *AdminClient) AddUser(ctx context.Context, accessKey, secretKey string) error {
-	return adm.SetUser(ctx, accessKey, secretKey, AccountEnabled)
+	_, err := adm.setUser(ctx, accessKey, AddOrUpdateUserReq{
+		SecretKey: secretKey,
+		Status:    AccountEnabled,
+	}, adm.autoIdempotencyKey())
+	return err
+}
+
+// AddUserWithIdempotencyKey - adds a user, the request is applied only once
+// for a given idempotency key so it can be safely retried after a network
+// error. replayed is true if the user was added by an earlier request.
+func (adm *AdminClient) AddUserWithIdempotencyKey(ctx context.Context, idempotencyKey, accessKey, secretKey string) (replayed bool, err error) {
+	if idempotencyKey == "" {
+		return false, ErrInvalidArgument("idempotency key cannot be empty")
+	}
+	return adm.setUser(ctx, accessKey, AddOrUpdateUserReq{
+		SecretKey: secretKey,
+		Status:    AccountEnabled,
+	}, idempotencyKey)
 }
 
 // SetUserStatus - adds a status for a user.
@@ -299,6 +467,9 @@ type AddServiceAccountReq struct {
 	TargetUser string          `json:"targetUser,omitempty"`
 	AccessKey  string          `json:"accessKey,omitempty"`
 	SecretKey  string          `json:"secretKey,omitempty"`
+	Expiration *time.Time      `json:"expiration,omitempty"`
+
+	CredentialMetadata
 }
 
 // AddServiceAccountResp is the response body of the add service account admin call
@@ -314,14 +485,10 @@ func (adm *AdminClient) AddServiceAccount(ctx context.Context, opts AddServiceAc
 		return Credentials{}, err
 	}
 
-	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return Credentials{}, err
-	}
-
 	reqData := requestData{
 		relPath: adminAPIPrefix + "/add-service-account",
-		content: econfigBytes,
+		content: data,
+		encrypt: true,
 	}
 
 	// Execute PUT on /minio/admin/v3/add-service-account to set a user.
@@ -349,9 +516,12 @@ func (adm *AdminClient) AddServiceAccount(ctx context.Context, opts AddServiceAc
 
 // UpdateServiceAccountReq is the request options of the edit service account admin call
 type UpdateServiceAccountReq struct {
-	NewPolicy    json.RawMessage `json:"newPolicy,omitempty"` // Parsed policy from iam/policy.Parse
-	NewSecretKey string          `json:"newSecretKey,omitempty"`
-	NewStatus    string          `json:"newStatus,omitempty"`
+	NewPolicy     json.RawMessage `json:"newPolicy,omitempty"` // Parsed policy from iam/policy.Parse
+	NewSecretKey  string          `json:"newSecretKey,omitempty"`
+	NewStatus     string          `json:"newStatus,omitempty"`
+	NewExpiration *time.Time      `json:"newExpiration,omitempty"`
+	// NewMetadata replaces the metadata of the service account if set.
+	NewMetadata *CredentialMetadata `json:"newMetadata,omitempty"`
 }
 
 // UpdateServiceAccount - edit an existing service account
@@ -361,17 +531,13 @@ func (adm *AdminClient) UpdateServiceAccount(ctx context.Context, accessKey stri
 		return err
 	}
 
-	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
-	if err != nil {
-		return err
-	}
-
 	queryValues := url.Values{}
 	queryValues.Set("accessKey", accessKey)
 
 	reqData := requestData{
 		relPath:     adminAPIPrefix + "/update-service-account",
-		content:     econfigBytes,
+		content:     data,
+		encrypt:     true,
 		queryValues: queryValues,
 	}
 
@@ -389,9 +555,77 @@ func (adm *AdminClient) UpdateServiceAccount(ctx context.Context, accessKey stri
 	return nil
 }
 
+// UpdateServiceAccountExpiry - sets the time after which the service
+// account expires. A zero expiresAt removes the expiry.
+func (adm *AdminClient) UpdateServiceAccountExpiry(ctx context.Context, accessKey string, expiresAt time.Time) error {
+	return adm.UpdateServiceAccount(ctx, accessKey, UpdateServiceAccountReq{
+		NewExpiration: &expiresAt,
+	})
+}
+
+// RotateServiceAccountSecret - generates a new secret key for an existing
+// service account and updates it in place. The new secret key is only
+// returned here and cannot be retrieved afterwards, it is returned even
+// if the expiry of the service account could not be looked up.
+func (adm *AdminClient) RotateServiceAccountSecret(ctx context.Context, accessKey string) (Credentials, error) {
+	secretKey, err := generateSecretKey()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	if err = adm.UpdateServiceAccount(ctx, accessKey, UpdateServiceAccountReq{
+		NewSecretKey: secretKey,
+	}); err != nil {
+		return Credentials{}, err
+	}
+
+	creds := Credentials{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	}
+	// The secret key is already rotated, looking up the
+	// expiry is best effort so the new secret is not lost.
+	if info, err := adm.InfoServiceAccount(ctx, accessKey); err == nil && info.Expiration != nil {
+		creds.Expiration = *info.Expiration
+	}
+	return creds, nil
+}
+
+// generateSecretKey - generates a random secret key of the
+// same length and alphabet as secret keys generated by the server.
+func generateSecretKey() (string, error) {
+	const secretKeyLen = 40
+
+	keyBytes := make([]byte, base64.StdEncoding.DecodedLen(secretKeyLen))
+	if _, err := io.ReadFull(rand.Reader, keyBytes); err != nil {
+		return "", err
+	}
+	secretKey := base64.StdEncoding.EncodeToString(keyBytes)[:secretKeyLen]
+	return strings.ReplaceAll(secretKey, "/", "+"), nil
+}
+
+// ServiceAccountInfo describes a service account returned in listings.
+type ServiceAccountInfo struct {
+	AccessKey     string     `json:"accessKey"`
+	ParentUser    string     `json:"parentUser,omitempty"`
+	AccountStatus string     `json:"accountStatus,omitempty"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+
+	CredentialMetadata
+}
+
 // ListServiceAccountsResp is the response body of the list service accounts call
 type ListServiceAccountsResp struct {
 	Accounts []string `json:"accounts"`
+
+	// AccountsInfo holds the details of the listed service accounts,
+	// it is only set by servers supporting service account metadata.
+	AccountsInfo []ServiceAccountInfo `json:"accountsInfo,omitempty"`
+
+	// Only set by ListServiceAccountsPaginated, when more service
+	// accounts are available NextMarker must be used to fetch them.
+	IsTruncated bool   `json:"isTruncated"`
+	NextMarker  string `json:"nextMarker,omitempty"`
 }
 
 // ListServiceAccounts - list service accounts belonging to the specified user
@@ -427,12 +661,110 @@ func (adm *AdminClient) ListServiceAccounts(ctx context.Context, user string) (L
 	return listResp, nil
 }
 
+// ListServiceAccountsPaginated - list at most maxItems service accounts
+// belonging to the specified user, starting after marker. An empty
+// marker starts from the beginning. Servers not supporting pagination
+// return all service accounts in a single page.
+func (adm *AdminClient) ListServiceAccountsPaginated(ctx context.Context, user, marker string, maxItems int) (ListServiceAccountsResp, error) {
+	queryValues := url.Values{}
+	queryValues.Set("user", user)
+	queryValues.Set("paginated", "true")
+	if marker != "" {
+		queryValues.Set("marker", marker)
+	}
+	if maxItems > 0 {
+		queryValues.Set("max-items", strconv.Itoa(maxItems))
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-service-accounts",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/list-service-accounts
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return ListServiceAccountsResp{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ListServiceAccountsResp{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return ListServiceAccountsResp{}, err
+	}
+
+	var listResp ListServiceAccountsResp
+	if err = json.Unmarshal(data, &listResp); err != nil {
+		return ListServiceAccountsResp{}, err
+	}
+	if !isPaginatedResponse(data) {
+		// Older servers ignore the marker and return all service
+		// accounts, never continue listing after this page.
+		listResp.IsTruncated = false
+		listResp.NextMarker = ""
+	}
+	return listResp, nil
+}
+
+// ServiceAccountListResult holds a single service account returned
+// by ListServiceAccountsIter, or the error encountered while listing.
+type ServiceAccountListResult struct {
+	AccessKey string
+	Err       error
+}
+
+// ListServiceAccountsIter - lists all service accounts belonging to the
+// specified user, transparently fetching pages of maxItems accounts using
+// ListServiceAccountsPaginated. The channel is closed once all service
+// accounts are listed, an error is encountered or ctx is canceled.
+func (adm *AdminClient) ListServiceAccountsIter(ctx context.Context, user string, maxItems int) <-chan ServiceAccountListResult {
+	accountCh := make(chan ServiceAccountListResult)
+
+	go func(accountCh chan<- ServiceAccountListResult) {
+		defer close(accountCh)
+
+		var marker string
+		for {
+			page, err := adm.ListServiceAccountsPaginated(ctx, user, marker, maxItems)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+				case accountCh <- ServiceAccountListResult{Err: err}:
+				}
+				return
+			}
+
+			for _, accessKey := range page.Accounts {
+				select {
+				case <-ctx.Done():
+					return
+				case accountCh <- ServiceAccountListResult{AccessKey: accessKey}:
+				}
+			}
+
+			if !page.IsTruncated || page.NextMarker == "" {
+				return
+			}
+			marker = page.NextMarker
+		}
+	}(accountCh)
+
+	return accountCh
+}
+
 // InfoServiceAccountResp is the response body of the info service account call
 type InfoServiceAccountResp struct {
-	ParentUser    string `json:"parentUser"`
-	AccountStatus string `json:"accountStatus"`
-	ImpliedPolicy bool   `json:"impliedPolicy"`
-	Policy        string `json:"policy"`
+	ParentUser    string     `json:"parentUser"`
+	AccountStatus string     `json:"accountStatus"`
+	ImpliedPolicy bool       `json:"impliedPolicy"`
+	Policy        string     `json:"policy"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+
+	CredentialMetadata
 }
 
 // InfoServiceAccount - returns the info of service account belonging to the specified user
@@ -492,3 +824,79 @@ func (adm *AdminClient) DeleteServiceAccount(ctx context.Context, serviceAccount
 
 	return nil
 }
+
+// STSSession - an active temporary session issued by the STS API.
+type STSSession struct {
+	AccessKey  string    `json:"accessKey"`
+	ParentUser string    `json:"parentUser"`
+	Provider   string    `json:"provider,omitempty"` // e.g. assume-role, ldap, openid or certificate
+	Policies   []string  `json:"policies,omitempty"`
+	SourceIP   string    `json:"sourceIP,omitempty"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// ListSTSSessions - lists the active STS sessions of user.
+func (adm *AdminClient) ListSTSSessions(ctx context.Context, user string) ([]STSSession, error) {
+	if user == "" {
+		return nil, ErrInvalidArgument("user cannot be empty")
+	}
+	queryValues := url.Values{}
+	queryValues.Set("user", user)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-sts-sessions",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/list-sts-sessions
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var sessions []STSSession
+	if err = json.NewDecoder(resp.Body).Decode(&sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSTSSessions - revokes all active STS sessions of user and
+// returns the number of sessions revoked.
+func (adm *AdminClient) RevokeSTSSessions(ctx context.Context, user string) (int, error) {
+	if user == "" {
+		return 0, ErrInvalidArgument("user cannot be empty")
+	}
+	queryValues := url.Values{}
+	queryValues.Set("user", user)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/revoke-sts-sessions",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/revoke-sts-sessions
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, httpRespToErrorResponse(resp)
+	}
+
+	var result struct {
+		Revoked int `json:"revoked"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	return result.Revoked, nil
+}
@@ -0,0 +1,281 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRotateServiceAccountSecret(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/update-service-account":
+			if r.URL.Query().Get("accessKey") != "svc" {
+				writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			// The expiry lookup fails after the update succeeded.
+			writeTestError(w, http.StatusInternalServerError, "InternalError")
+		}
+	})
+
+	creds, err := adm.RotateServiceAccountSecret(context.Background(), "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.AccessKey != "svc" || len(creds.SecretKey) != 40 {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+}
+
+func TestListServiceAccountsInfo(t *testing.T) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/list-service-accounts" || r.URL.Query().Get("user") != "alice" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		data, _ := json.Marshal(ListServiceAccountsResp{
+			Accounts: []string{"svc"},
+			AccountsInfo: []ServiceAccountInfo{{
+				AccessKey:  "svc",
+				ParentUser: "alice",
+				Expiration: &expiry,
+				CredentialMetadata: CredentialMetadata{
+					Name:        "backup",
+					Description: "nightly backups",
+				},
+			}},
+		})
+		data, _ = EncryptData("minio123", data)
+		w.Write(data)
+	})
+
+	resp, err := adm.ListServiceAccounts(context.Background(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.AccountsInfo) != 1 {
+		t.Fatalf("expected 1 service account, got %d", len(resp.AccountsInfo))
+	}
+	info := resp.AccountsInfo[0]
+	if info.AccessKey != "svc" || info.Name != "backup" || info.Description != "nightly backups" ||
+		info.Expiration == nil || !info.Expiration.Equal(expiry) {
+		t.Errorf("unexpected service account %+v", info)
+	}
+
+	if _, err = adm.ListServiceAccounts(context.Background(), "bob"); ToErrorResponse(err).Code != "InvalidRequest" {
+		t.Errorf("expected InvalidRequest, got %v", err)
+	}
+}
+
+func TestSTSSessions(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("user") != "alice" {
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchUser")
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/list-sts-sessions":
+			json.NewEncoder(w).Encode([]STSSession{{
+				AccessKey:  "STSKEY",
+				ParentUser: "alice",
+				Provider:   "ldap",
+				Expiration: expiry,
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/minio/admin/v3/revoke-sts-sessions":
+			w.Write([]byte(`{"revoked":3}`))
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	sessions, err := adm.ListSTSSessions(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].AccessKey != "STSKEY" || sessions[0].Provider != "ldap" || !sessions[0].Expiration.Equal(expiry) {
+		t.Fatalf("unexpected sessions %+v", sessions)
+	}
+
+	n, err := adm.RevokeSTSSessions(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 revoked sessions, got %d", n)
+	}
+
+	if _, err = adm.ListSTSSessions(ctx, "bob"); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+	if _, err = adm.RevokeSTSSessions(ctx, "bob"); ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("expected XMinioAdminNoSuchUser, got %v", err)
+	}
+	if _, err = adm.RevokeSTSSessions(ctx, ""); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestListUsersIter(t *testing.T) {
+	users := map[string]UserInfo{
+		"alice": {Status: AccountEnabled},
+		"bob":   {Status: AccountDisabled},
+		"carol": {Status: AccountEnabled},
+	}
+	var markers []string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/list-users" || q.Get("paginated") != "true" || q.Get("max-items") != "2" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		markers = append(markers, q.Get("marker"))
+		switch q.Get("marker") {
+		case "":
+			writeEncryptedJSON(t, w, ListUsersPage{
+				Users:       map[string]UserInfo{"alice": users["alice"], "bob": users["bob"]},
+				IsTruncated: true,
+				NextMarker:  "bob",
+			})
+		case "bob":
+			writeEncryptedJSON(t, w, ListUsersPage{Users: map[string]UserInfo{"carol": users["carol"]}})
+		default:
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+		}
+	})
+
+	var listed []string
+	for res := range adm.ListUsersIter(context.Background(), 2) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		if res.Info.Status != users[res.AccessKey].Status {
+			t.Errorf("unexpected info of %s: %+v", res.AccessKey, res.Info)
+		}
+		listed = append(listed, res.AccessKey)
+	}
+	if !reflect.DeepEqual(listed, []string{"alice", "bob", "carol"}) {
+		t.Fatalf("unexpected users %v", listed)
+	}
+	if !reflect.DeepEqual(markers, []string{"", "bob"}) {
+		t.Fatalf("unexpected markers %q", markers)
+	}
+}
+
+func TestListUsersPaginatedLegacy(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Older servers ignore the pagination parameters.
+		writeEncryptedJSON(t, w, map[string]UserInfo{
+			"bob":   {Status: AccountEnabled},
+			"alice": {Status: AccountEnabled},
+		})
+	})
+
+	page, err := adm.ListUsersPaginated(context.Background(), "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Users) != 2 || page.IsTruncated {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	var listed []string
+	for res := range adm.ListUsersIter(context.Background(), 1) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		listed = append(listed, res.AccessKey)
+	}
+	if !reflect.DeepEqual(listed, []string{"alice", "bob"}) {
+		t.Fatalf("unexpected users %v", listed)
+	}
+
+	adm = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTestError(w, http.StatusForbidden, "AccessDenied")
+	})
+	for res := range adm.ListUsersIter(context.Background(), 1) {
+		if ToErrorResponse(res.Err).Code != "AccessDenied" {
+			t.Fatalf("expected AccessDenied, got %+v", res)
+		}
+	}
+}
+
+func TestListServiceAccountsIter(t *testing.T) {
+	var markers []string
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/list-service-accounts" || q.Get("user") != "alice" ||
+			q.Get("paginated") != "true" || q.Get("max-items") != "2" {
+			writeTestError(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		markers = append(markers, q.Get("marker"))
+		switch q.Get("marker") {
+		case "":
+			writeEncryptedJSON(t, w, ListServiceAccountsResp{Accounts: []string{"svc1", "svc2"}, IsTruncated: true, NextMarker: "svc2"})
+		case "svc2":
+			writeEncryptedJSON(t, w, ListServiceAccountsResp{Accounts: []string{"svc3"}})
+		default:
+			writeTestError(w, http.StatusBadRequest, "InvalidArgument")
+		}
+	})
+
+	var listed []string
+	for res := range adm.ListServiceAccountsIter(context.Background(), "alice", 2) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		listed = append(listed, res.AccessKey)
+	}
+	if !reflect.DeepEqual(listed, []string{"svc1", "svc2", "svc3"}) {
+		t.Fatalf("unexpected service accounts %v", listed)
+	}
+	if !reflect.DeepEqual(markers, []string{"", "svc2"}) {
+		t.Fatalf("unexpected markers %q", markers)
+	}
+}
+
+func TestListServiceAccountsPaginatedLegacy(t *testing.T) {
+	var calls int
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// Older servers ignore the pagination parameters and
+		// do not know the isTruncated field.
+		writeEncryptedJSON(t, w, json.RawMessage(`{"accounts":["svc1","svc2","svc3"],"nextMarker":"svc3"}`))
+	})
+
+	var listed []string
+	for res := range adm.ListServiceAccountsIter(context.Background(), "alice", 1) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		listed = append(listed, res.AccessKey)
+	}
+	if !reflect.DeepEqual(listed, []string{"svc1", "svc2", "svc3"}) || calls != 1 {
+		t.Fatalf("unexpected service accounts %v after %d calls", listed, calls)
+	}
+}
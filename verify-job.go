@@ -0,0 +1,208 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// VerifyJobOpts - options of a bitrot verification job.
+type VerifyJobOpts struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	// Versions verifies all versions of the objects, not only the latest.
+	Versions bool `json:"versions,omitempty"`
+}
+
+// VerifyJobInfo - returned by StartVerifyJob
+type VerifyJobInfo struct {
+	ID      string    `json:"id"`
+	Bucket  string    `json:"bucket"`
+	Prefix  string    `json:"prefix,omitempty"`
+	Started time.Time `json:"started"`
+}
+
+// VerifyMismatch - a shard whose content does not match its bitrot checksum.
+type VerifyMismatch struct {
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	Part      int    `json:"part"`
+	Endpoint  string `json:"endpoint"`
+	DrivePath string `json:"path"`
+	Algorithm string `json:"algorithm"`
+	Expected  string `json:"expected"`
+	Got       string `json:"got"`
+}
+
+// Verification job states reported by VerifyJobStatus.
+const (
+	VerifyJobCompleted = "completed"
+	VerifyJobFailed    = "failed"
+	VerifyJobCanceled  = "canceled"
+)
+
+// VerifyJobStatus - final state of a verification job, sent by the
+// server as the last entry of the mismatches stream.
+type VerifyJobStatus struct {
+	Status          string `json:"status"`
+	ObjectsVerified uint64 `json:"objectsVerified"`
+	Mismatches      uint64 `json:"mismatches"`
+	Error           string `json:"error,omitempty"`
+}
+
+// ErrVerifyStreamEnded is returned when the mismatches stream of a
+// verification job ends before the job reported its final status.
+var ErrVerifyStreamEnded = errors.New("madmin: verify job stream ended before the job finished")
+
+// VerifyMismatchResult - contains a checksum mismatch, the final
+// status of the job as the last result, or an error
+type VerifyMismatchResult struct {
+	Mismatch VerifyMismatch
+	Status   *VerifyJobStatus
+	Err      error
+}
+
+// StartVerifyJob - starts a background job reading every object of the
+// bucket and prefix to verify its shards against their bitrot checksums.
+// Unlike heal, mismatches are only reported and never repaired.
+func (adm *AdminClient) StartVerifyJob(ctx context.Context, opts VerifyJobOpts) (VerifyJobInfo, error) {
+	if opts.Bucket == "" {
+		return VerifyJobInfo{}, ErrInvalidArgument("bucket cannot be empty")
+	}
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return VerifyJobInfo{}, err
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost,
+		requestData{
+			relPath: adminAPIPrefix + "/verify/start",
+			content: data,
+		},
+	)
+	if err != nil {
+		return VerifyJobInfo{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return VerifyJobInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	var info VerifyJobInfo
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	return info, err
+}
+
+// CancelVerifyJob - cancels a running verification job.
+func (adm *AdminClient) CancelVerifyJob(ctx context.Context, jobID string) error {
+	if jobID == "" {
+		return ErrInvalidArgument("job ID cannot be empty")
+	}
+	values := make(url.Values)
+	values.Set("jobId", jobID)
+
+	resp, err := adm.executeMethod(ctx, http.MethodDelete,
+		requestData{
+			relPath:     adminAPIPrefix + "/verify/cancel",
+			queryValues: values,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusNoContent {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// VerifyJobMismatches - streams the checksum mismatches found by a
+// verification job until the job finishes or the context is canceled.
+// The last result carries the final status of the job, if the stream
+// ends without it the last result has Err set to ErrVerifyStreamEnded.
+func (adm *AdminClient) VerifyJobMismatches(ctx context.Context, jobID string) <-chan VerifyMismatchResult {
+	resultCh := make(chan VerifyMismatchResult)
+
+	go func(resultCh chan<- VerifyMismatchResult) {
+		defer close(resultCh)
+
+		send := func(result VerifyMismatchResult) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case resultCh <- result:
+				return true
+			}
+		}
+
+		if jobID == "" {
+			send(VerifyMismatchResult{Err: ErrInvalidArgument("job ID cannot be empty")})
+			return
+		}
+		values := make(url.Values)
+		values.Set("jobId", jobID)
+
+		resp, err := adm.executeMethod(ctx, http.MethodGet,
+			requestData{
+				relPath:     adminAPIPrefix + "/verify/mismatches",
+				queryValues: values,
+				category:    RequestCategoryStreaming,
+			})
+		if err != nil {
+			send(VerifyMismatchResult{Err: err})
+			return
+		}
+		defer closeResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			send(VerifyMismatchResult{Err: httpRespToErrorResponse(resp)})
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var entry struct {
+				VerifyMismatch
+				JobStatus *VerifyJobStatus `json:"jobStatus,omitempty"`
+			}
+			if err = dec.Decode(&entry); err != nil {
+				if err == io.EOF {
+					err = ErrVerifyStreamEnded
+				}
+				send(VerifyMismatchResult{Err: err})
+				return
+			}
+			if entry.JobStatus != nil {
+				send(VerifyMismatchResult{Status: entry.JobStatus})
+				return
+			}
+			if !send(VerifyMismatchResult{Mismatch: entry.VerifyMismatch}) {
+				return
+			}
+		}
+	}(resultCh)
+
+	return resultCh
+}
@@ -0,0 +1,108 @@
+//
+// MinIO Object Storage (c) 2021 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestVerifyJobMismatchesStatus(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		enc.Encode(VerifyMismatch{Bucket: "photos", Object: "a.jpg"})
+		if r.URL.Query().Get("jobId") == "done" {
+			enc.Encode(map[string]VerifyJobStatus{
+				"jobStatus": {Status: VerifyJobCompleted, ObjectsVerified: 10, Mismatches: 1},
+			})
+		}
+	})
+
+	var results []VerifyMismatchResult
+	for res := range adm.VerifyJobMismatches(context.Background(), "done") {
+		results = append(results, res)
+	}
+	if len(results) != 2 || results[0].Mismatch.Object != "a.jpg" || results[0].Status != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if s := results[1].Status; s == nil || s.Status != VerifyJobCompleted || s.ObjectsVerified != 10 || results[1].Err != nil {
+		t.Fatalf("expected final job status, got %+v", results[1])
+	}
+
+	results = nil
+	for res := range adm.VerifyJobMismatches(context.Background(), "running") {
+		results = append(results, res)
+	}
+	if len(results) != 2 || results[1].Err != ErrVerifyStreamEnded {
+		t.Fatalf("expected %v, got %+v", ErrVerifyStreamEnded, results)
+	}
+}
+
+func TestVerifyJob(t *testing.T) {
+	adm := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/minio/admin/v3/verify/start":
+			var opts VerifyJobOpts
+			json.NewDecoder(r.Body).Decode(&opts)
+			if opts.Bucket != "photos" {
+				writeTestError(w, http.StatusNotFound, "NoSuchBucket")
+				return
+			}
+			json.NewEncoder(w).Encode(VerifyJobInfo{ID: "job1", Bucket: opts.Bucket, Prefix: opts.Prefix})
+		case r.Method == http.MethodDelete && r.URL.Path == "/minio/admin/v3/verify/cancel":
+			if r.URL.Query().Get("jobId") != "job1" {
+				writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchJob")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/verify/mismatches":
+			writeTestError(w, http.StatusNotFound, "XMinioAdminNoSuchJob")
+		default:
+			writeTestError(w, http.StatusNotFound, "NotImplemented")
+		}
+	})
+
+	ctx := context.Background()
+	info, err := adm.StartVerifyJob(ctx, VerifyJobOpts{Bucket: "photos", Prefix: "2021/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != "job1" || info.Prefix != "2021/" {
+		t.Fatalf("unexpected job %+v", info)
+	}
+	if _, err = adm.StartVerifyJob(ctx, VerifyJobOpts{Bucket: "videos"}); ToErrorResponse(err).Code != "NoSuchBucket" {
+		t.Errorf("expected NoSuchBucket, got %v", err)
+	}
+	if _, err = adm.StartVerifyJob(ctx, VerifyJobOpts{}); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if err = adm.CancelVerifyJob(ctx, "job1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = adm.CancelVerifyJob(ctx, "job2"); ToErrorResponse(err).Code != "XMinioAdminNoSuchJob" {
+		t.Errorf("expected XMinioAdminNoSuchJob, got %v", err)
+	}
+
+	for res := range adm.VerifyJobMismatches(ctx, "job2") {
+		if ToErrorResponse(res.Err).Code != "XMinioAdminNoSuchJob" {
+			t.Errorf("expected XMinioAdminNoSuchJob, got %+v", res)
+		}
+	}
+}